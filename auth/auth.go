@@ -0,0 +1,251 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/base"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultExpiryDelta is how long before its expiry a cached token is refreshed.
+const defaultExpiryDelta = 10 * time.Second
+
+// defaultFetchTimeout bounds the fetch of a token.
+const defaultFetchTimeout = 30 * time.Second
+
+// staticCredentials sends the same headers with every request.
+type staticCredentials struct {
+	headers map[string]string
+}
+
+// NewStaticToken returns a provider sending the token as a bearer token.
+func NewStaticToken(token string) base.CredentialsProvider {
+	return &staticCredentials{headers: map[string]string{"Authorization": "Bearer " + token}}
+}
+
+// NewBasicAuth returns a provider sending the username and password with the basic authentication scheme.
+func NewBasicAuth(username, password string) base.CredentialsProvider {
+	credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return &staticCredentials{headers: map[string]string{"Authorization": "Basic " + credentials}}
+}
+
+func (c *staticCredentials) Credentials(ctx context.Context) (map[string]string, error) {
+	return c.headers, nil
+}
+
+// Token is an access token issued by an authorization server.
+type Token struct {
+	AccessToken string
+	// TokenType is the scheme of the Authorization header. Defaults to "Bearer".
+	TokenType string
+	// Expiry is the time the token expires at. A zero value means the token never expires.
+	Expiry time.Time
+}
+
+// TokenFetcher fetches a new access token.
+type TokenFetcher func(ctx context.Context) (*Token, error)
+
+// Settings configures a refreshing token provider.
+type Settings struct {
+	// ExpiryDelta is how long before its expiry a token is refreshed. Defaults to 10 seconds.
+	ExpiryDelta time.Duration
+	// FetchTimeout bounds the fetch of a token. Defaults to 30 seconds.
+	FetchTimeout time.Duration
+}
+
+// TokenProvider is a base.CredentialsProvider caching a token until it expires.
+// It is invalidated by the clients when the server rejects the token.
+type TokenProvider interface {
+	base.CredentialsProvider
+	base.CredentialsInvalidator
+}
+
+// tokenProvider caches the token of a fetcher. Concurrent requests share a single refresh.
+type tokenProvider struct {
+	fetch    TokenFetcher
+	settings Settings
+	now      func() time.Time
+	// mu guards token and refresh. It is not held while fetching.
+	mu    sync.Mutex
+	token *Token
+	// refresh is the fetch in progress, nil when there is none.
+	refresh *refresh
+}
+
+// refresh is a fetch shared by the requests waiting for a token. done is closed once token or err is set.
+type refresh struct {
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+// NewRefreshingToken returns a provider sending the tokens of the fetcher, fetching a new one
+// shortly before the cached token expires or after the server rejected it.
+func NewRefreshingToken(fetch TokenFetcher, settings Settings) TokenProvider {
+	if settings.ExpiryDelta <= 0 {
+		settings.ExpiryDelta = defaultExpiryDelta
+	}
+	if settings.FetchTimeout <= 0 {
+		settings.FetchTimeout = defaultFetchTimeout
+	}
+	return &tokenProvider{
+		fetch:    fetch,
+		settings: settings,
+		now:      time.Now,
+	}
+}
+
+func (p *tokenProvider) Credentials(ctx context.Context) (map[string]string, error) {
+	p.mu.Lock()
+	if p.valid() {
+		token := p.token
+		p.mu.Unlock()
+		return headers(token), nil
+	}
+	r := p.refresh
+	if r == nil {
+		r = &refresh{done: make(chan struct{})}
+		p.refresh = r
+		// The fetch outlives the request starting it, which may be canceled while others wait for the token.
+		go p.refreshToken(context.WithoutCancel(ctx), r)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-r.done:
+		if r.err != nil {
+			return nil, r.err
+		}
+		return headers(r.token), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// refreshToken fetches a token for r and caches it.
+func (p *tokenProvider) refreshToken(ctx context.Context, r *refresh) {
+	ctx, cancel := context.WithTimeout(ctx, p.settings.FetchTimeout)
+	defer cancel()
+	token, err := p.fetch(ctx)
+	if err != nil {
+		err = fmt.Errorf("fetching access token: %w", err)
+	} else if token == nil || token.AccessToken == "" {
+		err = fmt.Errorf("fetching access token: empty token")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err == nil {
+		r.token = token
+		p.token = token
+	}
+	r.err = err
+	p.refresh = nil
+	close(r.done)
+}
+
+// Invalidate discards the cached token when it is the rejected one. A token fetched since then is kept.
+func (p *tokenProvider) Invalidate(rejected map[string]string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != nil && maps.Equal(headers(p.token), rejected) {
+		p.token = nil
+	}
+}
+
+// headers returns the Authorization header of a token.
+func headers(token *Token) map[string]string {
+	tokenType := token.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+	return map[string]string{"Authorization": tokenType + " " + token.AccessToken}
+}
+
+// valid reports whether the cached token can still be used.
+func (p *tokenProvider) valid() bool {
+	if p.token == nil {
+		return false
+	}
+	if p.token.Expiry.IsZero() {
+		return true
+	}
+	return p.now().Add(p.settings.ExpiryDelta).Before(p.token.Expiry)
+}
+
+// ClientCredentials describes an OAuth2 client credentials grant.
+type ClientCredentials struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// HTTPClient sends the token requests. Defaults to a client with a timeout of 30 seconds.
+	HTTPClient *http.Client
+}
+
+// defaultHTTPClient sends the token requests of the grants without HTTP client.
+var defaultHTTPClient = &http.Client{Timeout: defaultFetchTimeout}
+
+// tokenResponse is the successful response of an OAuth2 token endpoint.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// NewClientCredentialsFetcher returns a TokenFetcher requesting tokens from an OAuth2 token endpoint
+// with the client credentials grant.
+func NewClientCredentialsFetcher(grant ClientCredentials) TokenFetcher {
+	return func(ctx context.Context) (*Token, error) {
+		form := url.Values{"grant_type": {"client_credentials"}}
+		if len(grant.Scopes) > 0 {
+			form.Set("scope", strings.Join(grant.Scopes, " "))
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, grant.TokenURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+		req.SetBasicAuth(url.QueryEscape(grant.ClientID), url.QueryEscape(grant.ClientSecret))
+
+		httpClient := grant.HTTPClient
+		if httpClient == nil {
+			httpClient = defaultHTTPClient
+		}
+		start := time.Now()
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("token endpoint returned status code %d: %s", resp.StatusCode, string(body))
+		}
+
+		var response tokenResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, fmt.Errorf("decoding token response: %w", err)
+		}
+
+		token := &Token{AccessToken: response.AccessToken, TokenType: response.TokenType}
+		if response.ExpiresIn > 0 {
+			token.Expiry = start.Add(time.Duration(response.ExpiresIn) * time.Second)
+		}
+		return token, nil
+	}
+}
@@ -0,0 +1,309 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/base"
+	triton "github.com/Trendyol/go-triton-client/client/grpc"
+	"github.com/Trendyol/go-triton-client/client/grpc/grpc_generated_v2"
+	tritonhttp "github.com/Trendyol/go-triton-client/client/http"
+	"github.com/Trendyol/go-triton-client/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sequenceFetcher returns the tokens "token-1", "token-2"... and counts the fetches.
+type sequenceFetcher struct {
+	mu      sync.Mutex
+	fetches int
+	expiry  time.Time
+}
+
+func (f *sequenceFetcher) fetch(ctx context.Context) (*Token, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fetches++
+	return &Token{AccessToken: fmt.Sprintf("token-%d", f.fetches), Expiry: f.expiry}, nil
+}
+
+func TestNewStaticToken(t *testing.T) {
+	headers, err := NewStaticToken("secret").Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer secret"}, headers)
+}
+
+func TestNewBasicAuth(t *testing.T) {
+	headers, err := NewBasicAuth("user", "pass").Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Basic dXNlcjpwYXNz"}, headers)
+}
+
+func TestRefreshingToken_CachesUntilExpiry(t *testing.T) {
+	now := time.Now()
+	fetcher := &sequenceFetcher{expiry: now.Add(time.Hour)}
+	provider := NewRefreshingToken(fetcher.fetch, Settings{ExpiryDelta: time.Minute}).(*tokenProvider)
+	provider.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		headers, err := provider.Credentials(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token-1", headers["Authorization"])
+	}
+	assert.Equal(t, 1, fetcher.fetches)
+
+	now = now.Add(59 * time.Minute)
+	headers, err := provider.Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-2", headers["Authorization"])
+
+	provider.Invalidate(headers)
+	headers, err = provider.Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-3", headers["Authorization"])
+}
+
+func TestRefreshingToken_InvalidateKeepsNewerToken(t *testing.T) {
+	fetcher := &sequenceFetcher{}
+	provider := NewRefreshingToken(fetcher.fetch, Settings{})
+
+	rejected, err := provider.Credentials(context.Background())
+	require.NoError(t, err)
+
+	// Two requests sent with token-1 are rejected, the second rejection must not discard token-2.
+	provider.Invalidate(rejected)
+	headers, err := provider.Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-2", headers["Authorization"])
+
+	provider.Invalidate(rejected)
+	headers, err = provider.Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-2", headers["Authorization"])
+	assert.Equal(t, 2, fetcher.fetches)
+}
+
+func TestRefreshingToken_DoesNotLockDuringFetch(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	provider := NewRefreshingToken(func(ctx context.Context) (*Token, error) {
+		close(started)
+		<-release
+		return &Token{AccessToken: "token"}, nil
+	}, Settings{})
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := provider.Credentials(context.Background())
+		result <- err
+	}()
+	<-started
+
+	// The provider stays usable while the fetch is in progress.
+	provider.Invalidate(map[string]string{"Authorization": "Bearer other"})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := provider.Credentials(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, <-result)
+	headers, err := provider.Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token", headers["Authorization"])
+}
+
+func TestRefreshingToken_FetchTimeout(t *testing.T) {
+	provider := NewRefreshingToken(func(ctx context.Context) (*Token, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, Settings{FetchTimeout: 20 * time.Millisecond})
+
+	_, err := provider.Credentials(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRefreshingToken_SharesConcurrentRefresh(t *testing.T) {
+	fetcher := &sequenceFetcher{}
+	provider := NewRefreshingToken(fetcher.fetch, Settings{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := provider.Credentials(context.Background())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, fetcher.fetches)
+}
+
+func TestRefreshingToken_Errors(t *testing.T) {
+	failure := errors.New("unreachable")
+	provider := NewRefreshingToken(func(ctx context.Context) (*Token, error) {
+		return nil, failure
+	}, Settings{})
+	_, err := provider.Credentials(context.Background())
+	assert.ErrorIs(t, err, failure)
+
+	provider = NewRefreshingToken(func(ctx context.Context) (*Token, error) {
+		return &Token{}, nil
+	}, Settings{})
+	_, err = provider.Credentials(context.Background())
+	assert.ErrorContains(t, err, "empty token")
+}
+
+func TestClientCredentialsFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, _ := r.BasicAuth()
+		if clientID != "client" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		assert.Equal(t, "client_credentials", r.FormValue("grant_type"))
+		assert.Equal(t, "triton:infer triton:read", r.FormValue("scope"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"access","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	fetch := NewClientCredentialsFetcher(ClientCredentials{
+		TokenURL:     server.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Scopes:       []string{"triton:infer", "triton:read"},
+	})
+	token, err := fetch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.Expiry, time.Minute)
+
+	headers, err := NewRefreshingToken(fetch, Settings{}).Credentials(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer access", headers["Authorization"])
+
+	_, err = NewClientCredentialsFetcher(ClientCredentials{TokenURL: server.URL, ClientID: "client", ClientSecret: "wrong"})(context.Background())
+	assert.ErrorContains(t, err, "status code 401")
+}
+
+func TestHTTPClient_RetriesWithFreshTokenOn401(t *testing.T) {
+	var mu sync.Mutex
+	var authorizations []string
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		bodies = append(bodies, string(body))
+		mu.Unlock()
+
+		// The first token is revoked by the server before its expiry.
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/v2/health/live" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"model_name":"model","model_version":"1","outputs":[]}`))
+	}))
+	defer server.Close()
+
+	fetcher := &sequenceFetcher{expiry: time.Now().Add(time.Hour)}
+	client, err := tritonhttp.New(server.URL, base.WithCredentials(NewRefreshingToken(fetcher.fetch, Settings{})))
+	require.NoError(t, err)
+
+	live, err := client.IsServerLive(context.Background(), &options.Options{})
+	require.NoError(t, err)
+	assert.True(t, live)
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, authorizations)
+
+	input := tritonhttp.NewInferInput("input_ids", "INT64", []int64{1}, nil)
+	require.NoError(t, input.SetData([]int64{1}, false))
+	_, err = client.Infer(context.Background(), "model", "1", []base.InferInput{input}, nil, &options.InferOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetcher.fetches)
+	assert.Equal(t, "Bearer token-2", authorizations[2])
+	assert.NotEmpty(t, bodies[2])
+}
+
+func TestHTTPClient_DoesNotRetryStaticCredentials(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := tritonhttp.New(server.URL, base.WithCredentials(NewStaticToken("secret")))
+	require.NoError(t, err)
+
+	_, err = client.GetServerMetadata(context.Background(), &options.Options{})
+	assert.ErrorContains(t, err, "401")
+	assert.Equal(t, 1, requests)
+}
+
+type authenticatedServer struct {
+	grpc_generated_v2.UnimplementedGRPCInferenceServiceServer
+	mu             sync.Mutex
+	authorizations []string
+}
+
+func (s *authenticatedServer) ServerLive(ctx context.Context, _ *grpc_generated_v2.ServerLiveRequest) (*grpc_generated_v2.ServerLiveResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	authorization := md.Get("authorization")
+
+	s.mu.Lock()
+	s.authorizations = append(s.authorizations, authorization...)
+	s.mu.Unlock()
+
+	if len(authorization) != 1 || authorization[0] == "Bearer token-1" {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return &grpc_generated_v2.ServerLiveResponse{Live: true}, nil
+}
+
+func TestGRPCClient_RetriesWithFreshTokenOnUnauthenticated(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	service := &authenticatedServer{}
+	server := grpc.NewServer()
+	grpc_generated_v2.RegisterGRPCInferenceServiceServer(server, service)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	fetcher := &sequenceFetcher{expiry: time.Now().Add(time.Hour)}
+	client, err := triton.New(listener.Addr().String(),
+		base.WithCredentials(NewRefreshingToken(fetcher.fetch, Settings{})),
+		base.WithNetworkTimeout(5*time.Second),
+	)
+	require.NoError(t, err)
+
+	live, err := client.IsServerLive(context.Background(), &options.Options{})
+	require.NoError(t, err)
+	assert.True(t, live)
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, service.authorizations)
+
+	client, err = triton.New(listener.Addr().String(), base.WithCredentials(NewStaticToken("token-1")), base.WithNetworkTimeout(5*time.Second))
+	require.NoError(t, err)
+	_, err = client.IsServerLive(context.Background(), &options.Options{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Len(t, service.authorizations, 3)
+}
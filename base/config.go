@@ -0,0 +1,195 @@
+package base
+
+import (
+	"context"
+	"crypto/tls"
+	"google.golang.org/grpc"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Config holds the settings shared by the HTTP and gRPC clients.
+type Config struct {
+	// Verbose logs every call at debug level through Logger.
+	Verbose bool
+	// ConnectionTimeout bounds the time spent establishing a connection to the server.
+	ConnectionTimeout time.Duration
+	// NetworkTimeout bounds the duration of every call, including reading the response. It is applied through
+	// the context of the call, so it also bounds the calls sent with HTTPClient or GRPCConnection.
+	NetworkTimeout time.Duration
+	// TLSConfig enables TLS when set.
+	TLSConfig *tls.Config
+	// Logger receives the records of the verbose mode. Defaults to slog.Default().
+	Logger *slog.Logger
+	// Interceptors run around every call, the first being the outermost.
+	Interceptors []UnaryInterceptor
+	// Keepalive configures the liveness probes of idle connections.
+	Keepalive Keepalive
+	// UserAgent is sent with every request when set.
+	UserAgent string
+	// Credentials are attached to every request when set.
+	Credentials CredentialsProvider
+	// HTTPClient replaces the HTTP client built from the config. Only used by the HTTP client. Its transport
+	// and timeout replace ConnectionTimeout, TLSConfig and Keepalive, while NetworkTimeout still applies.
+	HTTPClient *http.Client
+	// GRPCConnection replaces the connection built from the config. Only used by the gRPC client.
+	GRPCConnection *grpc.ClientConn
+	// GRPC holds the settings specific to gRPC connections.
+	GRPC GRPCSettings
+}
+
+// GRPCSettings holds the settings specific to gRPC connections. They are ignored when a connection is given.
+type GRPCSettings struct {
+	// MaxSendMessageSize is the maximum size in bytes of a request. Defaults to the gRPC limit of 2 GiB.
+	MaxSendMessageSize int
+	// MaxReceiveMessageSize is the maximum size in bytes of a response. Defaults to the gRPC limit of 4 MiB.
+	MaxReceiveMessageSize int
+	// InitialWindowSize and InitialConnWindowSize are the HTTP/2 flow control windows of a call and of the connection.
+	// Values below 64 KiB are ignored by gRPC.
+	InitialWindowSize     int32
+	InitialConnWindowSize int32
+	// ServiceConfig is the default service config in JSON, e.g. the retry or load balancing policy.
+	// A service config provided by the name resolver takes precedence.
+	ServiceConfig string
+	// DialOptions are appended to the options built from the config.
+	DialOptions []grpc.DialOption
+}
+
+// Keepalive configures the liveness probes of idle connections.
+type Keepalive struct {
+	// Time is the interval between probes: TCP keep-alives for HTTP, HTTP/2 pings for gRPC.
+	Time time.Duration
+	// Timeout is how long an HTTP connection may stay idle, or how long gRPC waits for a ping acknowledgement.
+	Timeout time.Duration
+	// PermitWithoutStream lets gRPC send pings when there is no active call.
+	PermitWithoutStream bool
+}
+
+// Option configures a Config.
+type Option func(*Config)
+
+// NewConfig returns a Config with the given options applied.
+func NewConfig(opts ...Option) *Config {
+	config := &Config{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// WithVerbose enables logging every call at debug level.
+func WithVerbose(verbose bool) Option {
+	return func(c *Config) {
+		c.Verbose = verbose
+	}
+}
+
+// WithConnectionTimeout sets the maximum time spent establishing a connection.
+func WithConnectionTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.ConnectionTimeout = timeout
+	}
+}
+
+// WithNetworkTimeout sets the maximum duration of every call.
+func WithNetworkTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.NetworkTimeout = timeout
+	}
+}
+
+// WithTLSConfig enables TLS with the given configuration.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(c *Config) {
+		c.TLSConfig = tlsConfig
+	}
+}
+
+// WithLogger sets the logger used by the verbose mode.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Config) {
+		c.Logger = logger
+	}
+}
+
+// WithInterceptors appends interceptors that run around every call.
+func WithInterceptors(interceptors ...UnaryInterceptor) Option {
+	return func(c *Config) {
+		c.Interceptors = append(c.Interceptors, interceptors...)
+	}
+}
+
+// WithKeepalive configures the liveness probes of idle connections.
+func WithKeepalive(keepalive Keepalive) Option {
+	return func(c *Config) {
+		c.Keepalive = keepalive
+	}
+}
+
+// WithUserAgent sets the user agent sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Config) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithCredentials attaches the credentials of the provider to every request.
+func WithCredentials(provider CredentialsProvider) Option {
+	return func(c *Config) {
+		c.Credentials = provider
+	}
+}
+
+// WithHTTPClient makes the HTTP client use the given http.Client instead of building one.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Config) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithGRPCConnection makes the gRPC client use the given connection instead of dialing one.
+func WithGRPCConnection(grpcConnection *grpc.ClientConn) Option {
+	return func(c *Config) {
+		c.GRPCConnection = grpcConnection
+	}
+}
+
+// WithMaxMessageSizes sets the maximum sizes in bytes of the gRPC requests and responses. Zero keeps the gRPC default.
+func WithMaxMessageSizes(send, receive int) Option {
+	return func(c *Config) {
+		c.GRPC.MaxSendMessageSize = send
+		c.GRPC.MaxReceiveMessageSize = receive
+	}
+}
+
+// WithInitialWindowSizes sets the HTTP/2 flow control windows of gRPC calls and connections.
+func WithInitialWindowSizes(stream, connection int32) Option {
+	return func(c *Config) {
+		c.GRPC.InitialWindowSize = stream
+		c.GRPC.InitialConnWindowSize = connection
+	}
+}
+
+// WithServiceConfig sets the default gRPC service config in JSON.
+func WithServiceConfig(serviceConfig string) Option {
+	return func(c *Config) {
+		c.GRPC.ServiceConfig = serviceConfig
+	}
+}
+
+// WithDialOptions appends options to the ones used to create the gRPC connection.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(c *Config) {
+		c.GRPC.DialOptions = append(c.GRPC.DialOptions, opts...)
+	}
+}
+
+// NewTimeoutInterceptor returns an interceptor that bounds every call with the given timeout.
+func NewTimeoutInterceptor(timeout time.Duration) UnaryInterceptor {
+	return func(ctx context.Context, op Operation, req *Request, invoker Invoker) (any, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, req)
+	}
+}
@@ -0,0 +1,107 @@
+package base
+
+import (
+	"context"
+	"crypto/tls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewConfig(t *testing.T) {
+	tlsConfig := &tls.Config{ServerName: "triton"}
+	logger := slog.Default()
+	interceptor := func(ctx context.Context, op Operation, req *Request, invoker Invoker) (any, error) {
+		return invoker(ctx, req)
+	}
+	keepalive := Keepalive{Time: time.Minute, Timeout: 10 * time.Second, PermitWithoutStream: true}
+
+	config := NewConfig(
+		WithVerbose(true),
+		WithConnectionTimeout(time.Second),
+		WithNetworkTimeout(5*time.Second),
+		WithTLSConfig(tlsConfig),
+		WithLogger(logger),
+		WithInterceptors(interceptor),
+		WithInterceptors(interceptor),
+		WithKeepalive(keepalive),
+		WithUserAgent("triton-test"),
+	)
+
+	assert.True(t, config.Verbose)
+	assert.Equal(t, time.Second, config.ConnectionTimeout)
+	assert.Equal(t, 5*time.Second, config.NetworkTimeout)
+	assert.Same(t, tlsConfig, config.TLSConfig)
+	assert.Same(t, logger, config.Logger)
+	assert.Len(t, config.Interceptors, 2)
+	assert.Equal(t, keepalive, config.Keepalive)
+	assert.Equal(t, "triton-test", config.UserAgent)
+}
+
+func TestNewTimeoutInterceptor(t *testing.T) {
+	interceptor := NewTimeoutInterceptor(time.Minute)
+
+	_, err := interceptor(context.Background(), OperationIsServerLive, &Request{}, func(ctx context.Context, req *Request) (any, error) {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+		return true, nil
+	})
+	require.NoError(t, err)
+}
+
+func TestNewHttpClientFromConfig_SetsUserAgent(t *testing.T) {
+	var userAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+	}))
+	defer server.Close()
+
+	client := NewHttpClientFromConfig(NewConfig(WithUserAgent("triton-test"), WithNetworkTimeout(time.Second)))
+
+	resp, err := client.Get(context.Background(), server.URL, "v2", nil, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	resp, err = client.Get(context.Background(), server.URL, "v2", map[string]string{"User-Agent": "caller"}, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err = client.Do(request)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, []string{"triton-test", "caller", "triton-test"}, userAgents)
+}
+
+func TestNewHttpClientFromConfig_UsesGivenClient(t *testing.T) {
+	custom := &http.Client{}
+
+	client := NewHttpClientFromConfig(NewConfig(WithHTTPClient(custom)))
+
+	assert.Same(t, custom, client.(*httpClient).client)
+}
+
+func TestNewGrpcClientFromConfig(t *testing.T) {
+	client, err := NewGrpcClientFromConfig("localhost:8001", NewConfig(
+		WithTLSConfig(&tls.Config{}),
+		WithConnectionTimeout(time.Second),
+		WithKeepalive(Keepalive{Time: time.Minute, Timeout: time.Second}),
+		WithUserAgent("triton-test"),
+	))
+	require.NoError(t, err)
+	assert.NotNil(t, client.GetConnection())
+
+	reused, err := NewGrpcClientFromConfig("ignored", NewConfig(WithGRPCConnection(client.GetConnection())))
+	require.NoError(t, err)
+	assert.Same(t, client.GetConnection(), reused.GetConnection())
+
+	_, err = NewGrpcClientFromConfig("invalid_url\n", NewConfig())
+	assert.Error(t, err)
+}
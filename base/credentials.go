@@ -0,0 +1,82 @@
+package base
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"strings"
+	"sync"
+)
+
+// CredentialsProvider supplies the credentials attached to every request, as HTTP headers or gRPC metadata.
+type CredentialsProvider interface {
+	// Credentials returns the headers carrying the credentials, e.g. "Authorization".
+	Credentials(ctx context.Context) (map[string]string, error)
+}
+
+// CredentialsInvalidator is implemented by providers caching credentials. When the server rejects a request
+// with HTTP 401 or gRPC Unauthenticated, Invalidate is called and the request is retried once with fresh credentials.
+type CredentialsInvalidator interface {
+	// Invalidate discards the cached credentials when they are the rejected ones, the headers sent with
+	// the rejected request, so that concurrent rejections of the same credentials trigger a single refresh.
+	Invalidate(rejected map[string]string)
+}
+
+// sentCredentialsKey is the context key of the sentCredentials of a gRPC call.
+type sentCredentialsKey struct{}
+
+// sentCredentials records the credentials attached to a gRPC call by perRPCCredentials.
+type sentCredentials struct {
+	mu      sync.Mutex
+	headers map[string]string
+}
+
+// perRPCCredentials adapts a CredentialsProvider to gRPC per-RPC credentials.
+type perRPCCredentials struct {
+	provider CredentialsProvider
+}
+
+// NewPerRPCCredentials returns gRPC per-RPC credentials sending the credentials of the provider as metadata.
+// Like the HTTP client, they are sent over plaintext connections too.
+func NewPerRPCCredentials(provider CredentialsProvider) credentials.PerRPCCredentials {
+	return &perRPCCredentials{provider: provider}
+}
+
+func (c *perRPCCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	headers, err := c.provider.Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sent, ok := ctx.Value(sentCredentialsKey{}).(*sentCredentials); ok {
+		sent.mu.Lock()
+		sent.headers = headers
+		sent.mu.Unlock()
+	}
+	metadata := make(map[string]string, len(headers))
+	for key, value := range headers {
+		metadata[strings.ToLower(key)] = value
+	}
+	return metadata, nil
+}
+
+func (c *perRPCCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// newReauthenticatingInterceptor retries once the calls rejected as Unauthenticated after invalidating the credentials.
+func newReauthenticatingInterceptor(invalidator CredentialsInvalidator) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		sent := &sentCredentials{}
+		err := invoker(context.WithValue(ctx, sentCredentialsKey{}, sent), method, req, reply, cc, opts...)
+		if status.Code(err) != codes.Unauthenticated {
+			return err
+		}
+		sent.mu.Lock()
+		rejected := sent.headers
+		sent.mu.Unlock()
+		invalidator.Invalidate(rejected)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
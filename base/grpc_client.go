@@ -4,8 +4,10 @@ import (
 	"context"
 	"crypto/tls"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 	"time"
 )
 
@@ -20,46 +22,109 @@ type grpcClient struct {
 	grpcConnection *grpc.ClientConn
 }
 
-// NewGrpcClient creates a new gRPC client connection with the given parameters.
+// NewGrpcClient creates a new gRPC client connection with the given parameters. Timeouts are given in seconds;
+// the network timeout bounds the calls made without a deadline.
 func NewGrpcClient(url string, connectionTimeout float64, networkTimeout float64, ssl bool, insecureConnection bool) (GrpcClient, error) {
-	// Prepare dial options
-	var opts []grpc.DialOption
-
-	// Add SSL/TLS credentials
+	config := &Config{
+		ConnectionTimeout: time.Duration(connectionTimeout * float64(time.Second)),
+		NetworkTimeout:    time.Duration(networkTimeout * float64(time.Second)),
+	}
 	if ssl {
-		var creds credentials.TransportCredentials
-		if insecureConnection {
-			creds = credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})
-		} else {
-			creds = credentials.NewTLS(&tls.Config{})
-		}
-		opts = append(opts, grpc.WithTransportCredentials(creds))
+		config.TLSConfig = &tls.Config{InsecureSkipVerify: insecureConnection}
+	}
+	// The connection is used without the interceptors of a client, so it bounds the calls itself.
+	if config.NetworkTimeout > 0 {
+		config.GRPC.DialOptions = append(config.GRPC.DialOptions, grpc.WithChainUnaryInterceptor(newDefaultDeadlineInterceptor(config.NetworkTimeout)))
+	}
+	return NewGrpcClientFromConfig(url, config)
+}
+
+// NewGrpcClientFromConfig creates a gRPC client from the config. Config.GRPCConnection is used as is when set,
+// otherwise a connection is created from the TLS, connection timeout, keepalive, user agent, credentials and gRPC
+// settings. The network timeout is left to the interceptors of the client using the connection.
+func NewGrpcClientFromConfig(url string, config *Config) (GrpcClient, error) {
+	if config.GRPCConnection != nil {
+		return &grpcClient{grpcConnection: config.GRPCConnection}, nil
+	}
+
+	grpcConnection, err := grpc.NewClient(url, dialOptions(config)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpcClient{grpcConnection: grpcConnection}, nil
+}
+
+// dialOptions translates the config to dial options. The options of GRPC.DialOptions come last and take precedence.
+func dialOptions(config *Config) []grpc.DialOption {
+	var opts []grpc.DialOption
+	if config.TLSConfig != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(config.TLSConfig)))
 	} else {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
 
-	// Add connection timeout
-	if connectionTimeout > 0 {
+	if config.ConnectionTimeout > 0 {
 		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{
-			MinConnectTimeout: time.Duration(connectionTimeout * float64(time.Second)),
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: config.ConnectionTimeout,
+		}))
+	}
+
+	if config.Keepalive.Time > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                config.Keepalive.Time,
+			Timeout:             config.Keepalive.Timeout,
+			PermitWithoutStream: config.Keepalive.PermitWithoutStream,
 		}))
 	}
 
-	// Prepare context with network timeout
-	ctx := context.Background()
-	if networkTimeout > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, time.Duration(networkTimeout*float64(time.Second)))
-		defer cancel()
+	if config.UserAgent != "" {
+		opts = append(opts, grpc.WithUserAgent(config.UserAgent))
 	}
 
-	// Create gRPC client
-	grpcConnection, err := grpc.NewClient(url, opts...)
-	if err != nil {
-		return nil, err
+	if config.Credentials != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(NewPerRPCCredentials(config.Credentials)))
+		if invalidator, ok := config.Credentials.(CredentialsInvalidator); ok {
+			opts = append(opts, grpc.WithChainUnaryInterceptor(newReauthenticatingInterceptor(invalidator)))
+		}
 	}
 
-	return &grpcClient{grpcConnection: grpcConnection}, nil
+	var callOptions []grpc.CallOption
+	if config.GRPC.MaxSendMessageSize > 0 {
+		callOptions = append(callOptions, grpc.MaxCallSendMsgSize(config.GRPC.MaxSendMessageSize))
+	}
+	if config.GRPC.MaxReceiveMessageSize > 0 {
+		callOptions = append(callOptions, grpc.MaxCallRecvMsgSize(config.GRPC.MaxReceiveMessageSize))
+	}
+	if len(callOptions) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOptions...))
+	}
+
+	if config.GRPC.InitialWindowSize > 0 {
+		opts = append(opts, grpc.WithInitialWindowSize(config.GRPC.InitialWindowSize))
+	}
+	if config.GRPC.InitialConnWindowSize > 0 {
+		opts = append(opts, grpc.WithInitialConnWindowSize(config.GRPC.InitialConnWindowSize))
+	}
+
+	if config.GRPC.ServiceConfig != "" {
+		opts = append(opts, grpc.WithDefaultServiceConfig(config.GRPC.ServiceConfig))
+	}
+
+	return append(opts, config.GRPC.DialOptions...)
+}
+
+// newDefaultDeadlineInterceptor bounds the calls made without a deadline with the given timeout.
+func newDefaultDeadlineInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
 }
 
 func (g *grpcClient) GetConnection() *grpc.ClientConn {
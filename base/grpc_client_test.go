@@ -1,9 +1,15 @@
 package base
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"net"
 	"testing"
+	"time"
 )
 
 func TestNewGrpcClient(t *testing.T) {
@@ -73,3 +79,23 @@ func TestGrpcClient_GetConnection(t *testing.T) {
 	result := client.GetConnection()
 	assert.Equal(t, conn, result)
 }
+
+func TestNewGrpcClient_AppliesNetworkTimeout(t *testing.T) {
+	// The listener accepts connections but never completes the HTTP/2 handshake.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	client, err := NewGrpcClient(listener.Addr().String(), 1, 0.05, false, false)
+	assert.NoError(t, err)
+
+	start := time.Now()
+	err = client.GetConnection().Invoke(context.Background(), "/inference.GRPCInferenceService/ServerLive", &emptypb.Empty{}, &emptypb.Empty{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestNewGrpcClientFromConfig_InvalidServiceConfig(t *testing.T) {
+	_, err := NewGrpcClientFromConfig("localhost:8001", NewConfig(WithServiceConfig(`{"loadBalancingConfig": 1}`)))
+	assert.Error(t, err)
+}
@@ -2,27 +2,32 @@ package base
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"time"
 )
 
 type HttpClient interface {
-	// Get sends a GET request to the specified requestURI with the provided headers and query parameters.
-	// Returns the HTTP response and any error encountered.
-	Get(baseURL, requestURI string, headers map[string]string, queryParams map[string]string) (*http.Response, error)
-	// Post sends a POST request to the specified requestURI with the provided headers, query parameters, and request body.
-	// Returns the HTTP response and any error encountered.
-	Post(baseURL, requestURI string, requestBody string, headers map[string]string, queryParams map[string]string) (*http.Response, error)
-	// PostWithBytes sends a POST request to the specified requestURI with the provided headers, query parameters, and request body as bytes.
-	// Returns the HTTP response and any error encountered.
-	PostWithBytes(baseURL, requestURI string, requestBody []byte, headers, queryParams map[string]string) (*http.Response, error)
+	// Get sends a GET request to the specified requestURI with the provided headers and query parameters,
+	// canceled when ctx is done. Returns the HTTP response and any error encountered.
+	Get(ctx context.Context, baseURL, requestURI string, headers map[string]string, queryParams map[string]string) (*http.Response, error)
+	// Post sends a POST request to the specified requestURI with the provided headers, query parameters, and request body,
+	// canceled when ctx is done. Returns the HTTP response and any error encountered.
+	Post(ctx context.Context, baseURL, requestURI string, requestBody string, headers map[string]string, queryParams map[string]string) (*http.Response, error)
+	// PostWithBytes sends a POST request to the specified requestURI with the provided headers, query parameters, and request body as bytes,
+	// canceled when ctx is done. Returns the HTTP response and any error encountered.
+	PostWithBytes(ctx context.Context, baseURL, requestURI string, requestBody []byte, headers, queryParams map[string]string) (*http.Response, error)
 	Do(request *http.Request) (*http.Response, error)
 }
 
 type httpClient struct {
-	client *http.Client
+	client      *http.Client
+	userAgent   string
+	credentials CredentialsProvider
 }
 
 func NewHttpClient(connectionTimeout float64, insecure bool, client *http.Client) HttpClient {
@@ -37,8 +42,32 @@ func NewHttpClient(connectionTimeout float64, insecure bool, client *http.Client
 	return &httpClient{client: client}
 }
 
-func (h *httpClient) Get(baseURL, requestURI string, headers map[string]string, queryParams map[string]string) (*http.Response, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%s", baseURL, requestURI), nil)
+// NewHttpClientFromConfig creates an HttpClient from the config. Config.HTTPClient is used as is when set,
+// otherwise a client is built from the timeouts, TLS and keepalive settings.
+func NewHttpClientFromConfig(config *Config) HttpClient {
+	client := config.HTTPClient
+	if client == nil {
+		dialer := &net.Dialer{
+			Timeout:   config.ConnectionTimeout,
+			KeepAlive: config.Keepalive.Time,
+		}
+		client = &http.Client{
+			Timeout: config.NetworkTimeout,
+			Transport: &http.Transport{
+				Proxy:               http.ProxyFromEnvironment,
+				DialContext:         dialer.DialContext,
+				TLSClientConfig:     config.TLSConfig,
+				TLSHandshakeTimeout: config.ConnectionTimeout,
+				IdleConnTimeout:     config.Keepalive.Timeout,
+				ForceAttemptHTTP2:   true,
+			},
+		}
+	}
+	return &httpClient{client: client, userAgent: config.UserAgent, credentials: config.Credentials}
+}
+
+func (h *httpClient) Get(ctx context.Context, baseURL, requestURI string, headers map[string]string, queryParams map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/%s", baseURL, requestURI), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +75,7 @@ func (h *httpClient) Get(baseURL, requestURI string, headers map[string]string,
 	h.addHeaders(req, headers)
 	h.addQueryParameters(req, queryParams)
 
-	resp, err := h.client.Do(req)
+	resp, err := h.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -54,8 +83,8 @@ func (h *httpClient) Get(baseURL, requestURI string, headers map[string]string,
 	return resp, nil
 }
 
-func (h *httpClient) Post(baseURL, requestURI string, requestBody string, headers map[string]string, queryParams map[string]string) (*http.Response, error) {
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/%s", baseURL, requestURI), bytes.NewBufferString(requestBody))
+func (h *httpClient) Post(ctx context.Context, baseURL, requestURI string, requestBody string, headers map[string]string, queryParams map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/%s", baseURL, requestURI), bytes.NewBufferString(requestBody))
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +92,7 @@ func (h *httpClient) Post(baseURL, requestURI string, requestBody string, header
 	h.addHeaders(req, headers)
 	h.addQueryParameters(req, queryParams)
 
-	resp, err := h.client.Do(req)
+	resp, err := h.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -71,8 +100,8 @@ func (h *httpClient) Post(baseURL, requestURI string, requestBody string, header
 	return resp, nil
 }
 
-func (h *httpClient) PostWithBytes(baseURL, requestURI string, requestBody []byte, headers, queryParams map[string]string) (*http.Response, error) {
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/%s", baseURL, requestURI), bytes.NewBuffer(requestBody))
+func (h *httpClient) PostWithBytes(ctx context.Context, baseURL, requestURI string, requestBody []byte, headers, queryParams map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/%s", baseURL, requestURI), bytes.NewBuffer(requestBody))
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +109,7 @@ func (h *httpClient) PostWithBytes(baseURL, requestURI string, requestBody []byt
 	h.addHeaders(req, headers)
 	h.addQueryParameters(req, queryParams)
 
-	resp, err := h.client.Do(req)
+	resp, err := h.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +118,59 @@ func (h *httpClient) PostWithBytes(baseURL, requestURI string, requestBody []byt
 }
 
 func (h *httpClient) Do(request *http.Request) (*http.Response, error) {
-	return h.client.Do(request)
+	return h.do(request)
+}
+
+// do sends the request with the user agent and credentials. A request rejected with 401 is sent
+// once more with fresh credentials when the provider caches them and the body can be replayed.
+func (h *httpClient) do(req *http.Request) (*http.Response, error) {
+	h.setUserAgent(req)
+	if h.credentials == nil {
+		return h.client.Do(req)
+	}
+
+	sent, err := h.setCredentials(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	invalidator, ok := h.credentials.(CredentialsInvalidator)
+	if !ok || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return resp, nil
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	invalidator.Invalidate(sent)
+	if _, err := h.setCredentials(retry); err != nil {
+		return nil, err
+	}
+	return h.client.Do(retry)
+}
+
+// setCredentials sets the headers of the credentials provider on the request and returns them.
+func (h *httpClient) setCredentials(req *http.Request) (map[string]string, error) {
+	headers, err := h.credentials.Credentials(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("getting credentials: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	return headers, nil
 }
 
 // addHeaders adds the provided headers to the given HTTP request.
@@ -99,6 +180,13 @@ func (h *httpClient) addHeaders(req *http.Request, headers map[string]string) {
 	}
 }
 
+// setUserAgent sets the configured user agent on the request unless the caller provided one.
+func (h *httpClient) setUserAgent(req *http.Request) {
+	if h.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", h.userAgent)
+	}
+}
+
 // addQueryParameters adds the provided query parameters to the given HTTP request.
 func (h *httpClient) addQueryParameters(req *http.Request, queryParams map[string]string) {
 	q := req.URL.Query()
@@ -2,6 +2,7 @@ package base
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"errors"
 	"io/ioutil"
@@ -47,7 +48,7 @@ func TestHttpClient_Get(t *testing.T) {
 	client := NewHttpClient(5000, true, nil)
 	headers := map[string]string{"Header-Key": "Header-Value"}
 	queryParams := map[string]string{"param": "value"}
-	resp, err := client.Get(server.URL, "test", headers, queryParams)
+	resp, err := client.Get(context.Background(), server.URL, "test", headers, queryParams)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -56,6 +57,45 @@ func TestHttpClient_Get(t *testing.T) {
 	}
 }
 
+func TestHttpClient_CanceledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = ioutil.ReadAll(r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := NewHttpClient(0, true, nil)
+	calls := map[string]func(ctx context.Context) (*http.Response, error){
+		"Get": func(ctx context.Context) (*http.Response, error) {
+			return client.Get(ctx, server.URL, "test", nil, nil)
+		},
+		"Post": func(ctx context.Context) (*http.Response, error) {
+			return client.Post(ctx, server.URL, "test", "body", nil, nil)
+		},
+		"PostWithBytes": func(ctx context.Context) (*http.Response, error) {
+			return client.PostWithBytes(ctx, server.URL, "test", []byte("body"), nil, nil)
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			_, err := call(ctx)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Expected deadline exceeded, got %v", err)
+			}
+			if time.Since(start) > 2*time.Second {
+				t.Errorf("Expected the request to be canceled with its context")
+			}
+		})
+	}
+}
+
 func TestHttpClient_Do(t *testing.T) {
 	client := NewHttpClient(5000, true, nil)
 	req, _ := http.NewRequest(http.MethodGet, "unreachable://example.com", nil)
@@ -87,7 +127,7 @@ func TestHttpClient_AddQueryParameters(t *testing.T) {
 
 func TestHttpClient_Get_Error(t *testing.T) {
 	client := NewHttpClient(5000, true, nil)
-	_, err := client.Get(":", "test", nil, nil)
+	_, err := client.Get(context.Background(), ":", "test", nil, nil)
 	if err == nil {
 		t.Errorf("Expected error due to invalid URL")
 	}
@@ -95,7 +135,7 @@ func TestHttpClient_Get_Error(t *testing.T) {
 
 func TestHttpClient_Post_Error(t *testing.T) {
 	client := NewHttpClient(5000, true, nil)
-	_, err := client.Post(":", "test", "body", nil, nil)
+	_, err := client.Post(context.Background(), ":", "test", "body", nil, nil)
 	if err == nil {
 		t.Errorf("Expected error due to invalid URL")
 	}
@@ -103,7 +143,7 @@ func TestHttpClient_Post_Error(t *testing.T) {
 
 func TestHttpClient_PostWithBytes_Error(t *testing.T) {
 	client := NewHttpClient(5000, true, nil)
-	_, err := client.PostWithBytes(":", "test", []byte("body"), nil, nil)
+	_, err := client.PostWithBytes(context.Background(), ":", "test", []byte("body"), nil, nil)
 	if err == nil {
 		t.Errorf("Expected error due to invalid URL")
 	}
@@ -174,7 +214,7 @@ func TestHttpClient_Get_DoError(t *testing.T) {
 		},
 	}
 	expectedErr := "Get \"http://example.com/test\": do error"
-	_, err := client.Get("http://example.com", "test", nil, nil)
+	_, err := client.Get(context.Background(), "http://example.com", "test", nil, nil)
 	if err == nil || err.Error() != expectedErr {
 		t.Errorf("Expected 'do error', got %v", err)
 	}
@@ -207,7 +247,7 @@ func TestHttpClient_Post(t *testing.T) {
 	client := NewHttpClient(5000, true, nil)
 	headers := map[string]string{"Header-Key": "Header-Value"}
 	queryParams := map[string]string{"param": "value"}
-	resp, err := client.Post(server.URL, "test", "request body", headers, queryParams)
+	resp, err := client.Post(context.Background(), server.URL, "test", "request body", headers, queryParams)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -218,7 +258,7 @@ func TestHttpClient_Post(t *testing.T) {
 
 func TestHttpClient_Post_Error_NewRequest(t *testing.T) {
 	client := NewHttpClient(5000, true, nil)
-	_, err := client.Post(":", "test", "body", nil, nil)
+	_, err := client.Post(context.Background(), ":", "test", "body", nil, nil)
 	if err == nil {
 		t.Errorf("Expected error due to invalid URL")
 	}
@@ -238,7 +278,7 @@ func TestHttpClient_Post_Error_Do(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create request: %v", err)
 	}
-	_, err = client.Post("http://example.com", "test", "body", nil, nil)
+	_, err = client.Post(context.Background(), "http://example.com", "test", "body", nil, nil)
 	if err == nil || err.Error() != expectedErr {
 		t.Errorf("Expected transport error, got %v", err)
 	}
@@ -272,7 +312,7 @@ func TestHttpClient_PostWithBytes(t *testing.T) {
 	client := NewHttpClient(5000, true, nil)
 	headers := map[string]string{"Header-Key": "Header-Value"}
 	queryParams := map[string]string{"param": "value"}
-	resp, err := client.PostWithBytes(server.URL, "test", []byte("byte body"), headers, queryParams)
+	resp, err := client.PostWithBytes(context.Background(), server.URL, "test", []byte("byte body"), headers, queryParams)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -283,7 +323,7 @@ func TestHttpClient_PostWithBytes(t *testing.T) {
 
 func TestHttpClient_PostWithBytes_Error_NewRequest(t *testing.T) {
 	client := NewHttpClient(5000, true, nil)
-	_, err := client.PostWithBytes(":", "test", []byte("body"), nil, nil)
+	_, err := client.PostWithBytes(context.Background(), ":", "test", []byte("body"), nil, nil)
 	if err == nil {
 		t.Errorf("Expected error due to invalid URL")
 	}
@@ -303,7 +343,7 @@ func TestHttpClient_PostWithBytes_Error_Do(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create request: %v", err)
 	}
-	_, err = client.PostWithBytes("http://example.com", "test", []byte("body"), nil, nil)
+	_, err = client.PostWithBytes(context.Background(), "http://example.com", "test", []byte("body"), nil, nil)
 	if err == nil || err.Error() != expectedErr {
 		t.Errorf("Expected transport error, got %v", err)
 	}
@@ -66,3 +66,8 @@ func (r *BaseInferResult) GetShape(name string) ([]int64, error) {
 	}
 	return output.GetShape(), nil
 }
+
+// GetBufferSize returns the size in bytes of the binary output data received with the result.
+func (r *BaseInferResult) GetBufferSize() int {
+	return len(r.Buffer)
+}
@@ -0,0 +1,307 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/models"
+	"github.com/Trendyol/go-triton-client/options"
+)
+
+// Operation identifies the Client method an interceptor is invoked for.
+type Operation string
+
+const (
+	OperationIsServerLive                 Operation = "IsServerLive"
+	OperationIsServerReady                Operation = "IsServerReady"
+	OperationIsModelReady                 Operation = "IsModelReady"
+	OperationGetServerMetadata            Operation = "GetServerMetadata"
+	OperationGetModelMetadata             Operation = "GetModelMetadata"
+	OperationGetModelConfig               Operation = "GetModelConfig"
+	OperationGetModelRepositoryIndex      Operation = "GetModelRepositoryIndex"
+	OperationLoadModel                    Operation = "LoadModel"
+	OperationUnloadModel                  Operation = "UnloadModel"
+	OperationGetInferenceStatistics       Operation = "GetInferenceStatistics"
+	OperationGetTraceSettings             Operation = "GetTraceSettings"
+	OperationUpdateLogSettings            Operation = "UpdateLogSettings"
+	OperationGetLogSettings               Operation = "GetLogSettings"
+	OperationGetSystemSharedMemoryStatus  Operation = "GetSystemSharedMemoryStatus"
+	OperationRegisterSystemSharedMemory   Operation = "RegisterSystemSharedMemory"
+	OperationUnregisterSystemSharedMemory Operation = "UnregisterSystemSharedMemory"
+	OperationGetCUDASharedMemoryStatus    Operation = "GetCUDASharedMemoryStatus"
+	OperationRegisterCUDASharedMemory     Operation = "RegisterCUDASharedMemory"
+	OperationUnregisterCUDASharedMemory   Operation = "UnregisterCUDASharedMemory"
+	OperationInfer                        Operation = "Infer"
+)
+
+// Protocol identifies the transport a Client talks to the server with.
+type Protocol string
+
+const (
+	ProtocolHTTP Protocol = "http"
+	ProtocolGRPC Protocol = "grpc"
+)
+
+// Request describes a Client call as seen by interceptors.
+// Options is set for every call except Infer, which sets InferOptions, Inputs and Outputs instead.
+// Interceptors may replace the options; the invoker sends whatever the request holds when it is called.
+type Request struct {
+	// Protocol is the transport of the wrapped client, or empty when it does not report one.
+	Protocol     Protocol
+	ModelName    string
+	ModelVersion string
+	Options      *options.Options
+	InferOptions *options.InferOptions
+	Inputs       []InferInput
+	Outputs      []InferOutput
+}
+
+// Invoker performs the call described by the request and returns its result.
+type Invoker func(ctx context.Context, req *Request) (any, error)
+
+// UnaryInterceptor intercepts a Client call. It must call invoker to continue the call,
+// and may inspect or modify the context, the request and the result.
+type UnaryInterceptor func(ctx context.Context, op Operation, req *Request, invoker Invoker) (any, error)
+
+// ChainUnaryInterceptors composes the interceptors into one, the first being the outermost.
+func ChainUnaryInterceptors(interceptors ...UnaryInterceptor) UnaryInterceptor {
+	switch len(interceptors) {
+	case 0:
+		return nil
+	case 1:
+		return interceptors[0]
+	}
+
+	return func(ctx context.Context, op Operation, req *Request, invoker Invoker) (any, error) {
+		return interceptors[0](ctx, op, req, chainInvoker(interceptors[1:], op, invoker))
+	}
+}
+
+// chainInvoker builds an invoker that runs the remaining interceptors before the final invoker.
+func chainInvoker(interceptors []UnaryInterceptor, op Operation, final Invoker) Invoker {
+	if len(interceptors) == 0 {
+		return final
+	}
+	return func(ctx context.Context, req *Request) (any, error) {
+		return interceptors[0](ctx, op, req, chainInvoker(interceptors[1:], op, final))
+	}
+}
+
+// NewInterceptedClient wraps the client so that every call goes through the given interceptors.
+// It returns the client unchanged when no interceptor is given.
+func NewInterceptedClient(next Client, interceptors ...UnaryInterceptor) Client {
+	interceptor := ChainUnaryInterceptors(interceptors...)
+	if interceptor == nil {
+		return next
+	}
+	var protocol Protocol
+	if p, ok := next.(interface{ Protocol() Protocol }); ok {
+		protocol = p.Protocol()
+	}
+	return &interceptedClient{next: next, interceptor: interceptor, protocol: protocol}
+}
+
+// interceptedClient is a Client that runs an interceptor around every call of the wrapped client.
+type interceptedClient struct {
+	next        Client
+	interceptor UnaryInterceptor
+	protocol    Protocol
+}
+
+// Protocol returns the transport of the wrapped client.
+func (c *interceptedClient) Protocol() Protocol {
+	return c.protocol
+}
+
+// invoke runs call through the interceptor of the client and converts its result back to T.
+func invoke[T any](ctx context.Context, c *interceptedClient, op Operation, req *Request, call func(ctx context.Context, req *Request) (T, error)) (T, error) {
+	req.Protocol = c.protocol
+	result, err := c.interceptor(ctx, op, req, func(ctx context.Context, req *Request) (any, error) {
+		return call(ctx, req)
+	})
+
+	var zero T
+	if result == nil {
+		return zero, err
+	}
+	typed, ok := result.(T)
+	if !ok {
+		return zero, fmt.Errorf("interceptor returned %T for operation %s, expected %T", result, op, zero)
+	}
+	return typed, err
+}
+
+func (c *interceptedClient) IsServerLive(ctx context.Context, options *options.Options) (bool, error) {
+	return invoke(ctx, c, OperationIsServerLive, &Request{Options: options},
+		func(ctx context.Context, req *Request) (bool, error) {
+			return c.next.IsServerLive(ctx, req.Options)
+		})
+}
+
+func (c *interceptedClient) IsServerReady(ctx context.Context, options *options.Options) (bool, error) {
+	return invoke(ctx, c, OperationIsServerReady, &Request{Options: options},
+		func(ctx context.Context, req *Request) (bool, error) {
+			return c.next.IsServerReady(ctx, req.Options)
+		})
+}
+
+func (c *interceptedClient) IsModelReady(ctx context.Context, modelName string, modelVersion string, options *options.Options) (bool, error) {
+	return invoke(ctx, c, OperationIsModelReady, &Request{ModelName: modelName, ModelVersion: modelVersion, Options: options},
+		func(ctx context.Context, req *Request) (bool, error) {
+			return c.next.IsModelReady(ctx, req.ModelName, req.ModelVersion, req.Options)
+		})
+}
+
+func (c *interceptedClient) GetServerMetadata(ctx context.Context, options *options.Options) (*models.ServerMetadataResponse, error) {
+	return invoke(ctx, c, OperationGetServerMetadata, &Request{Options: options},
+		func(ctx context.Context, req *Request) (*models.ServerMetadataResponse, error) {
+			return c.next.GetServerMetadata(ctx, req.Options)
+		})
+}
+
+func (c *interceptedClient) GetModelMetadata(ctx context.Context, modelName string, modelVersion string, options *options.Options) (*models.ModelMetadataResponse, error) {
+	return invoke(ctx, c, OperationGetModelMetadata, &Request{ModelName: modelName, ModelVersion: modelVersion, Options: options},
+		func(ctx context.Context, req *Request) (*models.ModelMetadataResponse, error) {
+			return c.next.GetModelMetadata(ctx, req.ModelName, req.ModelVersion, req.Options)
+		})
+}
+
+func (c *interceptedClient) GetModelConfig(ctx context.Context, modelName string, modelVersion string, options *options.Options) (*models.ModelConfigResponse, error) {
+	return invoke(ctx, c, OperationGetModelConfig, &Request{ModelName: modelName, ModelVersion: modelVersion, Options: options},
+		func(ctx context.Context, req *Request) (*models.ModelConfigResponse, error) {
+			return c.next.GetModelConfig(ctx, req.ModelName, req.ModelVersion, req.Options)
+		})
+}
+
+func (c *interceptedClient) GetModelConfigJSON(ctx context.Context, modelName string, modelVersion string, options *options.Options) (string, error) {
+	return invoke(ctx, c, OperationGetModelConfig, &Request{ModelName: modelName, ModelVersion: modelVersion, Options: options},
+		func(ctx context.Context, req *Request) (string, error) {
+			return GetModelConfigJSON(ctx, c.next, req.ModelName, req.ModelVersion, req.Options)
+		})
+}
+
+func (c *interceptedClient) GetModelRepositoryIndex(ctx context.Context, options *options.Options) ([]models.ModelRepositoryIndexResponse, error) {
+	return invoke(ctx, c, OperationGetModelRepositoryIndex, &Request{Options: options},
+		func(ctx context.Context, req *Request) ([]models.ModelRepositoryIndexResponse, error) {
+			return c.next.GetModelRepositoryIndex(ctx, req.Options)
+		})
+}
+
+func (c *interceptedClient) LoadModel(ctx context.Context, modelName string, config string, files map[string][]byte, options *options.Options) error {
+	_, err := invoke(ctx, c, OperationLoadModel, &Request{ModelName: modelName, Options: options},
+		func(ctx context.Context, req *Request) (any, error) {
+			return nil, c.next.LoadModel(ctx, req.ModelName, config, files, req.Options)
+		})
+	return err
+}
+
+func (c *interceptedClient) LoadModelWithRequest(ctx context.Context, modelName string, request models.LoadModelRequest, options *options.Options) error {
+	_, err := invoke(ctx, c, OperationLoadModel, &Request{ModelName: modelName, Options: options},
+		func(ctx context.Context, req *Request) (any, error) {
+			return nil, LoadModelWithRequest(ctx, c.next, req.ModelName, request, req.Options)
+		})
+	return err
+}
+
+func (c *interceptedClient) UnloadModel(ctx context.Context, modelName string, unloadDependents bool, options *options.Options) error {
+	_, err := invoke(ctx, c, OperationUnloadModel, &Request{ModelName: modelName, Options: options},
+		func(ctx context.Context, req *Request) (any, error) {
+			return nil, c.next.UnloadModel(ctx, req.ModelName, unloadDependents, req.Options)
+		})
+	return err
+}
+
+func (c *interceptedClient) GetInferenceStatistics(ctx context.Context, modelName string, modelVersion string, options *options.Options) (*models.InferenceStatisticsResponse, error) {
+	return invoke(ctx, c, OperationGetInferenceStatistics, &Request{ModelName: modelName, ModelVersion: modelVersion, Options: options},
+		func(ctx context.Context, req *Request) (*models.InferenceStatisticsResponse, error) {
+			return c.next.GetInferenceStatistics(ctx, req.ModelName, req.ModelVersion, req.Options)
+		})
+}
+
+func (c *interceptedClient) GetTraceSettings(ctx context.Context, modelName string, options *options.Options) (*models.TraceSettingsResponse, error) {
+	return invoke(ctx, c, OperationGetTraceSettings, &Request{ModelName: modelName, Options: options},
+		func(ctx context.Context, req *Request) (*models.TraceSettingsResponse, error) {
+			return c.next.GetTraceSettings(ctx, req.ModelName, req.Options)
+		})
+}
+
+func (c *interceptedClient) UpdateLogSettings(ctx context.Context, request models.LogSettingsRequest, options *options.Options) error {
+	_, err := invoke(ctx, c, OperationUpdateLogSettings, &Request{Options: options},
+		func(ctx context.Context, req *Request) (any, error) {
+			return nil, c.next.UpdateLogSettings(ctx, request, req.Options)
+		})
+	return err
+}
+
+func (c *interceptedClient) GetLogSettings(ctx context.Context, options *options.Options) (*models.LogSettingsResponse, error) {
+	return invoke(ctx, c, OperationGetLogSettings, &Request{Options: options},
+		func(ctx context.Context, req *Request) (*models.LogSettingsResponse, error) {
+			return c.next.GetLogSettings(ctx, req.Options)
+		})
+}
+
+func (c *interceptedClient) GetSystemSharedMemoryStatus(ctx context.Context, regionName string, options *options.Options) ([]models.SystemSharedMemoryStatusResponse, error) {
+	return invoke(ctx, c, OperationGetSystemSharedMemoryStatus, &Request{Options: options},
+		func(ctx context.Context, req *Request) ([]models.SystemSharedMemoryStatusResponse, error) {
+			return c.next.GetSystemSharedMemoryStatus(ctx, regionName, req.Options)
+		})
+}
+
+func (c *interceptedClient) RegisterSystemSharedMemory(ctx context.Context, name string, key string, byteSize int, offset int, options *options.Options) error {
+	_, err := invoke(ctx, c, OperationRegisterSystemSharedMemory, &Request{Options: options},
+		func(ctx context.Context, req *Request) (any, error) {
+			return nil, c.next.RegisterSystemSharedMemory(ctx, name, key, byteSize, offset, req.Options)
+		})
+	return err
+}
+
+func (c *interceptedClient) UnregisterSystemSharedMemory(ctx context.Context, name string, options *options.Options) error {
+	_, err := invoke(ctx, c, OperationUnregisterSystemSharedMemory, &Request{Options: options},
+		func(ctx context.Context, req *Request) (any, error) {
+			return nil, c.next.UnregisterSystemSharedMemory(ctx, name, req.Options)
+		})
+	return err
+}
+
+func (c *interceptedClient) GetCUDASharedMemoryStatus(ctx context.Context, regionName string, options *options.Options) ([]models.CUDASharedMemoryStatusResponse, error) {
+	return invoke(ctx, c, OperationGetCUDASharedMemoryStatus, &Request{Options: options},
+		func(ctx context.Context, req *Request) ([]models.CUDASharedMemoryStatusResponse, error) {
+			return c.next.GetCUDASharedMemoryStatus(ctx, regionName, req.Options)
+		})
+}
+
+func (c *interceptedClient) RegisterCUDASharedMemory(ctx context.Context, name string, rawHandle []byte, deviceID int, byteSize int, options *options.Options) error {
+	_, err := invoke(ctx, c, OperationRegisterCUDASharedMemory, &Request{Options: options},
+		func(ctx context.Context, req *Request) (any, error) {
+			return nil, c.next.RegisterCUDASharedMemory(ctx, name, rawHandle, deviceID, byteSize, req.Options)
+		})
+	return err
+}
+
+func (c *interceptedClient) UnregisterCUDASharedMemory(ctx context.Context, name string, options *options.Options) error {
+	_, err := invoke(ctx, c, OperationUnregisterCUDASharedMemory, &Request{Options: options},
+		func(ctx context.Context, req *Request) (any, error) {
+			return nil, c.next.UnregisterCUDASharedMemory(ctx, name, req.Options)
+		})
+	return err
+}
+
+func (c *interceptedClient) Infer(
+	ctx context.Context,
+	modelName string,
+	modelVersion string,
+	inputs []InferInput,
+	outputs []InferOutput,
+	options *options.InferOptions,
+) (InferResult, error) {
+	req := &Request{
+		ModelName:    modelName,
+		ModelVersion: modelVersion,
+		InferOptions: options,
+		Inputs:       inputs,
+		Outputs:      outputs,
+	}
+	return invoke(ctx, c, OperationInfer, req,
+		func(ctx context.Context, req *Request) (InferResult, error) {
+			return c.next.Infer(ctx, req.ModelName, req.ModelVersion, req.Inputs, req.Outputs, req.InferOptions)
+		})
+}
@@ -0,0 +1,156 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"github.com/Trendyol/go-triton-client/models"
+	"github.com/Trendyol/go-triton-client/options"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestNewInterceptedClient_NoInterceptors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockClient(ctrl)
+
+	assert.Same(t, mockClient, NewInterceptedClient(mockClient))
+}
+
+func TestChainUnaryInterceptors_Order(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var calls []string
+	record := func(name string) UnaryInterceptor {
+		return func(ctx context.Context, op Operation, req *Request, invoker Invoker) (any, error) {
+			calls = append(calls, name+" before "+string(op))
+			result, err := invoker(ctx, req)
+			calls = append(calls, name+" after "+string(op))
+			return result, err
+		}
+	}
+
+	mockClient := NewMockClient(ctrl)
+	mockClient.EXPECT().IsServerLive(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, options *options.Options) (bool, error) {
+			calls = append(calls, "call")
+			return true, nil
+		})
+
+	c := NewInterceptedClient(mockClient, record("first"), record("second"))
+
+	live, err := c.IsServerLive(context.Background(), &options.Options{})
+	assert.NoError(t, err)
+	assert.True(t, live)
+	assert.Equal(t, []string{
+		"first before IsServerLive",
+		"second before IsServerLive",
+		"call",
+		"second after IsServerLive",
+		"first after IsServerLive",
+	}, calls)
+}
+
+func TestInterceptedClient_RequestDescribesCall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	requestOptions := &options.Options{Headers: map[string]string{"a": "b"}}
+	mockClient := NewMockClient(ctrl)
+	mockClient.EXPECT().GetModelMetadata(gomock.Any(), "model", "1", requestOptions).
+		Return(&models.ModelMetadataResponse{Name: "model"}, nil)
+
+	var seen *Request
+	var seenOp Operation
+	c := NewInterceptedClient(mockClient, func(ctx context.Context, op Operation, req *Request, invoker Invoker) (any, error) {
+		seen = req
+		seenOp = op
+		return invoker(ctx, req)
+	})
+
+	metadata, err := c.GetModelMetadata(context.Background(), "model", "1", requestOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, "model", metadata.Name)
+	assert.Equal(t, OperationGetModelMetadata, seenOp)
+	assert.Equal(t, "model", seen.ModelName)
+	assert.Equal(t, "1", seen.ModelVersion)
+	assert.Same(t, requestOptions, seen.Options)
+}
+
+func TestInterceptedClient_InterceptorReplacesOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	replaced := &options.Options{Headers: map[string]string{"Authorization": "Bearer token"}}
+	mockClient := NewMockClient(ctrl)
+	mockClient.EXPECT().UnloadModel(gomock.Any(), "model", true, replaced).Return(nil)
+
+	c := NewInterceptedClient(mockClient, func(ctx context.Context, op Operation, req *Request, invoker Invoker) (any, error) {
+		req.Options = replaced
+		return invoker(ctx, req)
+	})
+
+	err := c.UnloadModel(context.Background(), "model", true, &options.Options{})
+	assert.NoError(t, err)
+}
+
+func TestInterceptedClient_InferRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	inferOptions := &options.InferOptions{}
+	mockResult := NewMockInferResult(ctrl)
+	mockClient := NewMockClient(ctrl)
+	mockClient.EXPECT().Infer(gomock.Any(), "model", "2", gomock.Len(1), gomock.Nil(), inferOptions).Return(mockResult, nil)
+
+	c := NewInterceptedClient(mockClient, func(ctx context.Context, op Operation, req *Request, invoker Invoker) (any, error) {
+		assert.Equal(t, OperationInfer, op)
+		assert.Len(t, req.Inputs, 1)
+		assert.Same(t, inferOptions, req.InferOptions)
+		return invoker(ctx, req)
+	})
+
+	result, err := c.Infer(context.Background(), "model", "2", []InferInput{nil}, nil, inferOptions)
+	assert.NoError(t, err)
+	assert.Same(t, mockResult, result)
+}
+
+func TestInterceptedClient_ShortCircuit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockClient(ctrl)
+	rejected := errors.New("rejected")
+
+	c := NewInterceptedClient(mockClient, func(ctx context.Context, op Operation, req *Request, invoker Invoker) (any, error) {
+		return nil, rejected
+	})
+
+	ready, err := c.IsModelReady(context.Background(), "model", "", &options.Options{})
+	assert.ErrorIs(t, err, rejected)
+	assert.False(t, ready)
+
+	err = c.LoadModel(context.Background(), "model", "", nil, &options.Options{})
+	assert.ErrorIs(t, err, rejected)
+
+	result, err := c.Infer(context.Background(), "model", "", nil, nil, nil)
+	assert.ErrorIs(t, err, rejected)
+	assert.Nil(t, result)
+}
+
+func TestInterceptedClient_UnexpectedResultType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := NewMockClient(ctrl)
+
+	c := NewInterceptedClient(mockClient, func(ctx context.Context, op Operation, req *Request, invoker Invoker) (any, error) {
+		return "unexpected", nil
+	})
+
+	_, err := c.IsServerReady(context.Background(), &options.Options{})
+	assert.EqualError(t, err, "interceptor returned string for operation IsServerReady, expected bool")
+}
@@ -0,0 +1,95 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/models"
+	"github.com/Trendyol/go-triton-client/options"
+	"strings"
+)
+
+// RequestModelLoader is implemented by the clients loading a model with the configuration, files, versions and
+// parameters of a models.LoadModelRequest, such as the HTTP and gRPC clients.
+type RequestModelLoader interface {
+	LoadModelWithRequest(ctx context.Context, modelName string, request models.LoadModelRequest, options *options.Options) error
+}
+
+// LoadModelWithRequest loads a model with request through client. Clients not implementing RequestModelLoader
+// load it with LoadModel, which can't send additional parameters, so a request setting parameters fails with them.
+func LoadModelWithRequest(ctx context.Context, client Client, modelName string, request models.LoadModelRequest, options *options.Options) error {
+	if loader, ok := client.(RequestModelLoader); ok {
+		return loader.LoadModelWithRequest(ctx, modelName, request, options)
+	}
+
+	if len(request.Parameters) > 0 {
+		return fmt.Errorf("failed to load model '%s': %T can't send load parameters", modelName, client)
+	}
+	parameters, err := LoadModelParameters(request)
+	if err != nil {
+		return fmt.Errorf("failed to load model '%s': %w", modelName, err)
+	}
+	config, _ := parameters["config"].(string)
+	return client.LoadModel(ctx, modelName, config, request.Files, options)
+}
+
+// LoadModelParameters validates a load request and returns its parameters, keyed as in Triton's load API:
+// "config" with the version policy of the selected versions, the "file:" entries as []byte,
+// and the additional parameters, with their integers widened to int64.
+func LoadModelParameters(request models.LoadModelRequest) (map[string]any, error) {
+	parameters := make(map[string]any, len(request.Files)+len(request.Parameters)+1)
+
+	for key, value := range request.Parameters {
+		if key == "config" || strings.HasPrefix(key, "file:") {
+			return nil, fmt.Errorf("parameter '%s' must be set with the Config and Files fields", key)
+		}
+		switch v := value.(type) {
+		case string, bool, []byte, int64:
+			parameters[key] = v
+		case int:
+			parameters[key] = int64(v)
+		case int32:
+			parameters[key] = int64(v)
+		default:
+			return nil, fmt.Errorf("parameter '%s' has unsupported type %T", key, value)
+		}
+	}
+
+	config := request.Config
+	if len(request.Versions) > 0 {
+		if config == "" {
+			return nil, errors.New("selecting versions requires a configuration")
+		}
+		var err error
+		if config, err = withSpecificVersions(config, request.Versions); err != nil {
+			return nil, err
+		}
+	}
+	if config != "" {
+		parameters["config"] = config
+	}
+
+	for path, content := range request.Files {
+		parameters[path] = content
+	}
+	return parameters, nil
+}
+
+// withSpecificVersions replaces the version policy of a JSON model configuration by the given versions.
+func withSpecificVersions(config string, versions []int64) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(config), &fields); err != nil {
+		return "", fmt.Errorf("parsing the configuration: %w", err)
+	}
+	policy, err := json.Marshal(map[string]any{"specific": map[string]any{"versions": versions}})
+	if err != nil {
+		return "", err
+	}
+	fields["version_policy"] = policy
+	updated, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(updated), nil
+}
@@ -0,0 +1,98 @@
+package base
+
+import (
+	"context"
+	"github.com/Trendyol/go-triton-client/models"
+	"github.com/Trendyol/go-triton-client/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+)
+
+func TestLoadModelParameters(t *testing.T) {
+	parameters, err := LoadModelParameters(models.LoadModelRequest{
+		Config:     `{"name":"encoder","max_batch_size":8,"version_policy":{"latest":{"num_versions":1}}}`,
+		Files:      map[string][]byte{"file:1/model.onnx": []byte("onnx")},
+		Versions:   []int64{1, 3},
+		Parameters: map[string]any{"priority": 2, "warmup": true, "tag": "canary", "blob": []byte{0}},
+	})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"name":"encoder","max_batch_size":8,"version_policy":{"specific":{"versions":[1,3]}}}`, parameters["config"].(string))
+	assert.Equal(t, []byte("onnx"), parameters["file:1/model.onnx"])
+	assert.Equal(t, int64(2), parameters["priority"])
+	assert.Equal(t, true, parameters["warmup"])
+	assert.Equal(t, "canary", parameters["tag"])
+	assert.Equal(t, []byte{0}, parameters["blob"])
+}
+
+func TestLoadModelParameters_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  models.LoadModelRequest
+		expected string
+	}{
+		{
+			name:     "versions without config",
+			request:  models.LoadModelRequest{Versions: []int64{1}},
+			expected: "selecting versions requires a configuration",
+		},
+		{
+			name:     "invalid config",
+			request:  models.LoadModelRequest{Config: "name: encoder", Versions: []int64{1}},
+			expected: "parsing the configuration",
+		},
+		{
+			name:     "reserved parameter",
+			request:  models.LoadModelRequest{Parameters: map[string]any{"config": "{}"}},
+			expected: "parameter 'config' must be set with the Config and Files fields",
+		},
+		{
+			name:     "unsupported type",
+			request:  models.LoadModelRequest{Parameters: map[string]any{"ratio": 0.5}},
+			expected: "parameter 'ratio' has unsupported type float64",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadModelParameters(tt.request)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+type requestLoader struct {
+	*MockClient
+	request models.LoadModelRequest
+}
+
+func (l *requestLoader) LoadModelWithRequest(ctx context.Context, modelName string, request models.LoadModelRequest, options *options.Options) error {
+	l.request = request
+	return nil
+}
+
+func TestLoadModelWithRequest_UsesRequestModelLoader(t *testing.T) {
+	loader := &requestLoader{MockClient: NewMockClient(gomock.NewController(t))}
+	request := models.LoadModelRequest{Config: "{}", Parameters: map[string]any{"priority": 2}}
+
+	require.NoError(t, LoadModelWithRequest(context.Background(), loader, "encoder", request, &options.Options{}))
+	assert.Equal(t, request, loader.request)
+}
+
+func TestLoadModelWithRequest_FallsBackToLoadModel(t *testing.T) {
+	client := NewMockClient(gomock.NewController(t))
+	files := map[string][]byte{"file:2/model.onnx": []byte("onnx")}
+	client.EXPECT().LoadModel(gomock.Any(), "encoder", `{"name":"encoder","version_policy":{"specific":{"versions":[2]}}}`, files, gomock.Any()).Return(nil)
+
+	err := LoadModelWithRequest(context.Background(), client, "encoder", models.LoadModelRequest{
+		Config:   `{"name":"encoder"}`,
+		Files:    files,
+		Versions: []int64{2},
+	}, &options.Options{})
+	require.NoError(t, err)
+
+	err = LoadModelWithRequest(context.Background(), client, "encoder", models.LoadModelRequest{Parameters: map[string]any{"priority": 2}}, &options.Options{})
+	assert.ErrorContains(t, err, "failed to load model 'encoder': *base.MockClient can't send load parameters")
+}
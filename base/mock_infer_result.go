@@ -69,6 +69,21 @@ func (mr *MockInferResultMockRecorder) AsByteSlice(name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AsByteSlice", reflect.TypeOf((*MockInferResult)(nil).AsByteSlice), name)
 }
 
+// AsBytesSlice mocks base method.
+func (m *MockInferResult) AsBytesSlice(name string) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AsBytesSlice", name)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AsBytesSlice indicates an expected call of AsBytesSlice.
+func (mr *MockInferResultMockRecorder) AsBytesSlice(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AsBytesSlice", reflect.TypeOf((*MockInferResult)(nil).AsBytesSlice), name)
+}
+
 // AsFloat16Slice mocks base method.
 func (m *MockInferResult) AsFloat16Slice(name string) ([]float64, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,24 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/options"
+)
+
+// ModelConfigJSONGetter is implemented by the clients returning the configuration served for a model unchanged,
+// in Triton's JSON format, such as the HTTP and gRPC clients. Unlike GetModelConfig, it keeps every field,
+// e.g. max_batch_size, dynamic_batching or optimization, so it can be sent back as a LoadModel override.
+type ModelConfigJSONGetter interface {
+	GetModelConfigJSON(ctx context.Context, modelName string, modelVersion string, options *options.Options) (string, error)
+}
+
+// GetModelConfigJSON returns the configuration served for a model in Triton's JSON format through client,
+// and fails for clients not implementing ModelConfigJSONGetter.
+func GetModelConfigJSON(ctx context.Context, client Client, modelName string, modelVersion string, options *options.Options) (string, error) {
+	getter, ok := client.(ModelConfigJSONGetter)
+	if !ok {
+		return "", fmt.Errorf("%T can't return the configuration of model '%s' in JSON", client, modelName)
+	}
+	return getter.GetModelConfigJSON(ctx, modelName, modelVersion, options)
+}
@@ -0,0 +1,372 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/models"
+	"github.com/Trendyol/go-triton-client/options"
+	"sync"
+	"time"
+)
+
+// State represents the state of a circuit breaker.
+type State int
+
+const (
+	// StateClosed lets every request through and records its outcome.
+	StateClosed State = iota
+	// StateOpen rejects every request until the cool-down has elapsed.
+	StateOpen
+	// StateHalfOpen lets a limited number of probe requests through.
+	StateHalfOpen
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// ErrCircuitOpen is matched by errors.Is for every OpenError.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// OpenError is returned when a request is rejected because the circuit of its model is open.
+type OpenError struct {
+	ModelName  string
+	State      State
+	RetryAfter time.Duration
+}
+
+func (e *OpenError) Error() string {
+	return fmt.Sprintf("circuit breaker for model '%s' is %s, retry after %s", e.ModelName, e.State, e.RetryAfter)
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *OpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// Settings configures the circuit breaker.
+type Settings struct {
+	// WindowSize is the number of most recent calls used to compute the failure rate.
+	WindowSize int
+	// MinimumRequests is the number of calls required in the window before the circuit can open.
+	MinimumRequests int
+	// FailureRateThreshold is the failure ratio in (0, 1] at which the circuit opens.
+	FailureRateThreshold float64
+	// CoolDown is how long the circuit stays open before allowing probe requests.
+	CoolDown time.Duration
+	// HalfOpenMaxRequests is the number of probe requests that must succeed to close the circuit.
+	HalfOpenMaxRequests int
+	// IsFailure decides whether an error counts as a failure. Defaults to every error except context.Canceled.
+	// The calls failing with other errors are ignored: they count neither as successes nor as failures.
+	IsFailure func(err error) bool
+	// OnStateChange is called after the circuit of a model changes state, without holding the lock of the circuit,
+	// so it may call State or Reset.
+	OnStateChange func(modelName string, from State, to State)
+}
+
+// DefaultSettings returns the settings used for zero-valued fields.
+func DefaultSettings() Settings {
+	return Settings{
+		WindowSize:           20,
+		MinimumRequests:      10,
+		FailureRateThreshold: 0.5,
+		CoolDown:             30 * time.Second,
+		HalfOpenMaxRequests:  1,
+		IsFailure:            defaultIsFailure,
+	}
+}
+
+// CircuitBreaker is a base.Client that guards inference calls with a per-model circuit breaker.
+type CircuitBreaker interface {
+	base.Client
+	// State returns the current state of the circuit for the given model.
+	State(modelName string) State
+	// Reset forces the circuit for the given model back to the closed state.
+	Reset(modelName string)
+}
+
+// client decorates a base.Client. Only Infer is guarded; health, metadata and repository
+// calls are passed through so callers can still observe the server while a circuit is open.
+type client struct {
+	base.Client
+	settings Settings
+	now      func() time.Time
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+// NewCircuitBreaker wraps the given client with a per-model circuit breaker.
+func NewCircuitBreaker(next base.Client, settings Settings) CircuitBreaker {
+	return &client{
+		Client:   next,
+		settings: withDefaults(settings),
+		now:      time.Now,
+		breakers: make(map[string]*breaker),
+	}
+}
+
+func (c *client) State(modelName string) State {
+	b := c.breaker(modelName)
+	b.mu.Lock()
+	change := c.advance(b, modelName)
+	state := b.state
+	b.mu.Unlock()
+
+	c.notify(change)
+	return state
+}
+
+func (c *client) Reset(modelName string) {
+	b := c.breaker(modelName)
+	b.mu.Lock()
+	change := c.setState(b, modelName, StateClosed)
+	b.mu.Unlock()
+
+	c.notify(change)
+}
+
+func (c *client) Infer(
+	ctx context.Context,
+	modelName string,
+	modelVersion string,
+	inputs []base.InferInput,
+	outputs []base.InferOutput,
+	options *options.InferOptions,
+) (base.InferResult, error) {
+	b := c.breaker(modelName)
+	generation, err := c.allow(b, modelName)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.Client.Infer(ctx, modelName, modelVersion, inputs, outputs, options)
+	c.record(b, modelName, generation, err)
+	return result, err
+}
+
+// GetModelConfigJSON passes the call through, keeping the JSON configuration of the decorated client.
+func (c *client) GetModelConfigJSON(ctx context.Context, modelName string, modelVersion string, options *options.Options) (string, error) {
+	return base.GetModelConfigJSON(ctx, c.Client, modelName, modelVersion, options)
+}
+
+// LoadModelWithRequest passes the load through, keeping the request loading of the decorated client.
+func (c *client) LoadModelWithRequest(ctx context.Context, modelName string, request models.LoadModelRequest, options *options.Options) error {
+	return base.LoadModelWithRequest(ctx, c.Client, modelName, request, options)
+}
+
+// breaker returns the breaker for the given model, creating it on first use.
+func (c *client) breaker(modelName string) *breaker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.breakers[modelName]
+	if !ok {
+		b = &breaker{outcomes: make([]bool, c.settings.WindowSize)}
+		c.breakers[modelName] = b
+	}
+	return b
+}
+
+// allow checks whether a request may proceed and reserves a probe slot when half-open.
+// It returns the generation of the breaker the outcome of the request must be recorded against.
+func (c *client) allow(b *breaker, modelName string) (uint64, error) {
+	b.mu.Lock()
+	change := c.advance(b, modelName)
+	err := c.reserve(b, modelName)
+	generation := b.generation
+	b.mu.Unlock()
+
+	c.notify(change)
+	return generation, err
+}
+
+// reserve rejects the request while the circuit is open, and takes a probe slot when it is half-open.
+func (c *client) reserve(b *breaker, modelName string) error {
+	switch b.state {
+	case StateOpen:
+		return &OpenError{ModelName: modelName, State: StateOpen, RetryAfter: b.openedAt.Add(c.settings.CoolDown).Sub(c.now())}
+	case StateHalfOpen:
+		if b.probes >= c.settings.HalfOpenMaxRequests {
+			return &OpenError{ModelName: modelName, State: StateHalfOpen}
+		}
+		b.probes++
+	}
+	return nil
+}
+
+// record stores the outcome of a request admitted in the given generation and transitions the breaker if needed.
+func (c *client) record(b *breaker, modelName string, generation uint64, err error) {
+	b.mu.Lock()
+	change := c.recordLocked(b, modelName, generation, err)
+	b.mu.Unlock()
+
+	c.notify(change)
+}
+
+func (c *client) recordLocked(b *breaker, modelName string, generation uint64, err error) transition {
+	// A request admitted before the last change of state, such as a slow call admitted while the circuit was
+	// closed and completing once it is half-open, says nothing about the current state and isn't a probe.
+	if generation != b.generation {
+		return transition{}
+	}
+	// An error that is not a failure, such as a cancellation by the caller, says nothing about the backend:
+	// it counts neither as a success nor as a failure, and gives its probe slot back.
+	if err != nil && !c.settings.IsFailure(err) {
+		if b.state == StateHalfOpen && b.probes > 0 {
+			b.probes--
+		}
+		return transition{}
+	}
+
+	switch b.state {
+	case StateHalfOpen:
+		if err != nil {
+			return c.setState(b, modelName, StateOpen)
+		}
+		b.successes++
+		if b.successes >= c.settings.HalfOpenMaxRequests {
+			return c.setState(b, modelName, StateClosed)
+		}
+	case StateClosed:
+		b.add(err != nil)
+		if b.count >= c.settings.MinimumRequests && b.failureRate() >= c.settings.FailureRateThreshold {
+			return c.setState(b, modelName, StateOpen)
+		}
+	}
+	return transition{}
+}
+
+// advance moves an open breaker to half-open once the cool-down has elapsed.
+func (c *client) advance(b *breaker, modelName string) transition {
+	if b.state == StateOpen && !c.now().Before(b.openedAt.Add(c.settings.CoolDown)) {
+		return c.setState(b, modelName, StateHalfOpen)
+	}
+	return transition{}
+}
+
+// transition is a change of state reported to the state-change hook once the breaker is unlocked,
+// so that the hook can call State or Reset.
+type transition struct {
+	modelName string
+	from      State
+	to        State
+}
+
+// notify calls the state-change hook for a transition that changed the state. It must be called without
+// holding the lock of the breaker.
+func (c *client) notify(change transition) {
+	if change.from != change.to && c.settings.OnStateChange != nil {
+		c.settings.OnStateChange(change.modelName, change.from, change.to)
+	}
+}
+
+// setState resets the counters of the breaker and returns the transition to notify.
+func (c *client) setState(b *breaker, modelName string, to State) transition {
+	from := b.state
+	b.state = to
+	b.generation++
+	b.probes = 0
+	b.successes = 0
+	if to == StateOpen {
+		b.openedAt = c.now()
+	}
+	if to == StateClosed {
+		b.reset()
+	}
+
+	return transition{modelName: modelName, from: from, to: to}
+}
+
+// breaker holds the state of the circuit for a single model.
+type breaker struct {
+	mu    sync.Mutex
+	state State
+	// generation counts the changes of state, so that outcomes of requests admitted before are ignored.
+	generation uint64
+	openedAt   time.Time
+	probes     int
+	successes  int
+
+	outcomes []bool
+	next     int
+	count    int
+	failures int
+}
+
+// add records an outcome in the rolling window.
+func (b *breaker) add(failed bool) {
+	if b.count == len(b.outcomes) {
+		if b.outcomes[b.next] {
+			b.failures--
+		}
+	} else {
+		b.count++
+	}
+	b.outcomes[b.next] = failed
+	if failed {
+		b.failures++
+	}
+	b.next = (b.next + 1) % len(b.outcomes)
+}
+
+// reset clears the rolling window.
+func (b *breaker) reset() {
+	for i := range b.outcomes {
+		b.outcomes[i] = false
+	}
+	b.next = 0
+	b.count = 0
+	b.failures = 0
+}
+
+// failureRate returns the ratio of failures in the rolling window.
+func (b *breaker) failureRate() float64 {
+	if b.count == 0 {
+		return 0
+	}
+	return float64(b.failures) / float64(b.count)
+}
+
+// withDefaults fills zero-valued settings with their defaults.
+func withDefaults(settings Settings) Settings {
+	defaults := DefaultSettings()
+	if settings.WindowSize <= 0 {
+		settings.WindowSize = defaults.WindowSize
+	}
+	if settings.MinimumRequests <= 0 {
+		settings.MinimumRequests = defaults.MinimumRequests
+	}
+	if settings.MinimumRequests > settings.WindowSize {
+		settings.MinimumRequests = settings.WindowSize
+	}
+	if settings.FailureRateThreshold <= 0 || settings.FailureRateThreshold > 1 {
+		settings.FailureRateThreshold = defaults.FailureRateThreshold
+	}
+	if settings.CoolDown <= 0 {
+		settings.CoolDown = defaults.CoolDown
+	}
+	if settings.HalfOpenMaxRequests <= 0 {
+		settings.HalfOpenMaxRequests = defaults.HalfOpenMaxRequests
+	}
+	if settings.IsFailure == nil {
+		settings.IsFailure = defaults.IsFailure
+	}
+	return settings
+}
+
+// defaultIsFailure treats every error as a failure except a cancellation by the caller.
+func defaultIsFailure(err error) bool {
+	return !errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,333 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/options"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func newTestBreaker(next base.Client, settings Settings) (*client, *time.Time, *[]transition) {
+	now := time.Unix(0, 0)
+	var transitions []transition
+	settings.OnStateChange = func(modelName string, from State, to State) {
+		transitions = append(transitions, transition{modelName: modelName, from: from, to: to})
+	}
+	cb := NewCircuitBreaker(next, settings).(*client)
+	cb.now = func() time.Time { return now }
+	return cb, &now, &transitions
+}
+
+func infer(cb CircuitBreaker, modelName string) error {
+	_, err := cb.Infer(context.Background(), modelName, "", nil, nil, &options.InferOptions{})
+	return err
+}
+
+func TestCircuitBreaker_OpensOnFailureRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	mockClient.EXPECT().Infer(gomock.Any(), "model", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("overloaded")).Times(4)
+
+	cb, _, transitions := newTestBreaker(mockClient, Settings{WindowSize: 4, MinimumRequests: 4, FailureRateThreshold: 0.5})
+
+	for i := 0; i < 4; i++ {
+		err := infer(cb, "model")
+		assert.EqualError(t, err, "overloaded")
+	}
+
+	assert.Equal(t, StateOpen, cb.State("model"))
+	assert.Equal(t, []transition{{modelName: "model", from: StateClosed, to: StateOpen}}, *transitions)
+
+	err := infer(cb, "model")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	var openErr *OpenError
+	assert.True(t, errors.As(err, &openErr))
+	assert.Equal(t, "model", openErr.ModelName)
+	assert.Equal(t, 30*time.Second, openErr.RetryAfter)
+}
+
+func TestCircuitBreaker_StaysClosedBelowMinimumRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("overloaded")).Times(3)
+
+	cb, _, _ := newTestBreaker(mockClient, Settings{WindowSize: 10, MinimumRequests: 4})
+
+	for i := 0; i < 3; i++ {
+		_ = infer(cb, "model")
+	}
+
+	assert.Equal(t, StateClosed, cb.State("model"))
+}
+
+func TestCircuitBreaker_StatePerModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	mockClient.EXPECT().Infer(gomock.Any(), "failing", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("overloaded")).Times(2)
+	mockClient.EXPECT().Infer(gomock.Any(), "healthy", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).Times(1)
+
+	cb, _, _ := newTestBreaker(mockClient, Settings{WindowSize: 2, MinimumRequests: 2})
+
+	_ = infer(cb, "failing")
+	_ = infer(cb, "failing")
+
+	assert.NoError(t, infer(cb, "healthy"))
+	assert.Equal(t, StateOpen, cb.State("failing"))
+	assert.Equal(t, StateClosed, cb.State("healthy"))
+}
+
+func TestCircuitBreaker_HalfOpenProbeSuccessCloses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	gomock.InOrder(
+		mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("overloaded")).Times(2),
+		mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, nil).Times(1),
+	)
+
+	cb, now, transitions := newTestBreaker(mockClient, Settings{WindowSize: 2, MinimumRequests: 2, CoolDown: time.Second})
+
+	_ = infer(cb, "model")
+	_ = infer(cb, "model")
+	assert.Equal(t, StateOpen, cb.State("model"))
+
+	*now = now.Add(time.Second)
+	assert.Equal(t, StateHalfOpen, cb.State("model"))
+
+	assert.NoError(t, infer(cb, "model"))
+	assert.Equal(t, StateClosed, cb.State("model"))
+	assert.Equal(t, []transition{
+		{modelName: "model", from: StateClosed, to: StateOpen},
+		{modelName: "model", from: StateOpen, to: StateHalfOpen},
+		{modelName: "model", from: StateHalfOpen, to: StateClosed},
+	}, *transitions)
+}
+
+func TestCircuitBreaker_HalfOpenProbeFailureReopens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("overloaded")).Times(3)
+
+	cb, now, _ := newTestBreaker(mockClient, Settings{WindowSize: 2, MinimumRequests: 2, CoolDown: time.Second})
+
+	_ = infer(cb, "model")
+	_ = infer(cb, "model")
+
+	*now = now.Add(time.Second)
+	assert.EqualError(t, infer(cb, "model"), "overloaded")
+	assert.Equal(t, StateOpen, cb.State("model"))
+	assert.ErrorIs(t, infer(cb, "model"), ErrCircuitOpen)
+}
+
+func TestCircuitBreaker_HalfOpenLimitsProbes(t *testing.T) {
+	cb, _, _ := newTestBreaker(nil, Settings{HalfOpenMaxRequests: 1})
+
+	b := cb.breaker("model")
+	cb.setState(b, "model", StateHalfOpen)
+
+	_, err := cb.allow(b, "model")
+	assert.NoError(t, err)
+	_, err = cb.allow(b, "model")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, StateHalfOpen, err.(*OpenError).State)
+}
+
+func TestCircuitBreaker_IgnoresOutcomesOfEarlierStates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockClient := base.NewMockClient(ctrl)
+	gomock.InOrder(
+		mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(context.Context, string, string, []base.InferInput, []base.InferOutput, *options.InferOptions) (base.InferResult, error) {
+				close(started)
+				<-release
+				return nil, errors.New("timeout")
+			}),
+		mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("overloaded")).Times(2),
+		mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, nil).Times(1),
+	)
+
+	cb, now, transitions := newTestBreaker(mockClient, Settings{WindowSize: 2, MinimumRequests: 2, CoolDown: time.Second})
+
+	// A slow call is admitted while the circuit is closed.
+	slow := make(chan error)
+	go func() { slow <- infer(cb, "model") }()
+	<-started
+
+	_ = infer(cb, "model")
+	_ = infer(cb, "model")
+	*now = now.Add(time.Second)
+	assert.Equal(t, StateHalfOpen, cb.State("model"))
+
+	// Its failure, completing once the circuit is half-open, neither reopens it nor takes the probe slot.
+	close(release)
+	assert.EqualError(t, <-slow, "timeout")
+	assert.Equal(t, StateHalfOpen, cb.State("model"))
+
+	assert.NoError(t, infer(cb, "model"))
+	assert.Equal(t, StateClosed, cb.State("model"))
+	assert.Equal(t, []transition{
+		{modelName: "model", from: StateClosed, to: StateOpen},
+		{modelName: "model", from: StateOpen, to: StateHalfOpen},
+		{modelName: "model", from: StateHalfOpen, to: StateClosed},
+	}, *transitions)
+}
+
+func TestCircuitBreaker_IgnoresCanceledContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, context.Canceled).Times(2)
+
+	cb, _, _ := newTestBreaker(mockClient, Settings{WindowSize: 2, MinimumRequests: 2})
+
+	_ = infer(cb, "model")
+	_ = infer(cb, "model")
+
+	assert.Equal(t, StateClosed, cb.State("model"))
+}
+
+func TestCircuitBreaker_CanceledProbeReleasesSlot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	gomock.InOrder(
+		mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("overloaded")).Times(2),
+		mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, context.Canceled).Times(1),
+		mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, nil).Times(1),
+	)
+
+	cb, now, _ := newTestBreaker(mockClient, Settings{WindowSize: 2, MinimumRequests: 2, CoolDown: time.Second})
+
+	_ = infer(cb, "model")
+	_ = infer(cb, "model")
+	*now = now.Add(time.Second)
+
+	assert.ErrorIs(t, infer(cb, "model"), context.Canceled)
+	assert.Equal(t, StateHalfOpen, cb.State("model"))
+
+	assert.NoError(t, infer(cb, "model"))
+	assert.Equal(t, StateClosed, cb.State("model"))
+}
+
+func TestCircuitBreaker_CanceledCallsDontDiluteFailureRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	gomock.InOrder(
+		mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("overloaded")).Times(1),
+		mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, context.Canceled).Times(1),
+		mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("overloaded")).Times(1),
+	)
+
+	cb, _, _ := newTestBreaker(mockClient, Settings{WindowSize: 2, MinimumRequests: 2, FailureRateThreshold: 1})
+
+	_ = infer(cb, "model")
+	_ = infer(cb, "model")
+	assert.Equal(t, StateClosed, cb.State("model"))
+	_ = infer(cb, "model")
+	assert.Equal(t, StateOpen, cb.State("model"))
+}
+
+func TestCircuitBreaker_OnStateChangeCanCallBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("overloaded")).Times(2)
+
+	var cb CircuitBreaker
+	var states []State
+	cb = NewCircuitBreaker(mockClient, Settings{
+		WindowSize:      2,
+		MinimumRequests: 2,
+		OnStateChange: func(modelName string, from State, to State) {
+			states = append(states, cb.State(modelName))
+			if to == StateOpen {
+				cb.Reset(modelName)
+			}
+		},
+	})
+
+	_ = infer(cb, "model")
+	_ = infer(cb, "model")
+
+	assert.Equal(t, []State{StateOpen, StateClosed}, states)
+	assert.Equal(t, StateClosed, cb.State("model"))
+}
+
+func TestCircuitBreaker_Reset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("overloaded")).Times(2)
+
+	cb, _, _ := newTestBreaker(mockClient, Settings{WindowSize: 2, MinimumRequests: 2})
+
+	_ = infer(cb, "model")
+	_ = infer(cb, "model")
+	cb.Reset("model")
+
+	assert.Equal(t, StateClosed, cb.State("model"))
+}
+
+func TestCircuitBreaker_PassesThroughOtherCalls(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	mockClient.EXPECT().IsModelReady(gomock.Any(), "model", "1", gomock.Any()).Return(true, nil)
+
+	cb, _, _ := newTestBreaker(mockClient, Settings{})
+	cb.setState(cb.breaker("model"), "model", StateOpen)
+
+	ready, err := cb.IsModelReady(context.Background(), "model", "1", &options.Options{})
+	assert.NoError(t, err)
+	assert.True(t, ready)
+}
+
+func TestState_String(t *testing.T) {
+	assert.Equal(t, "closed", StateClosed.String())
+	assert.Equal(t, "open", StateOpen.String())
+	assert.Equal(t, "half-open", StateHalfOpen.String())
+	assert.Equal(t, "unknown(7)", State(7).String())
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/client/grpc"
+	"github.com/Trendyol/go-triton-client/client/http"
+	"strings"
+)
+
+// New creates an HTTP or a gRPC client depending on the scheme of url:
+// http:// and https:// select the HTTP client, grpc:// and grpcs:// the gRPC client.
+// The https and grpcs schemes enable TLS with the default configuration unless a TLS config is given.
+func New(url string, opts ...base.Option) (base.Client, error) {
+	scheme, address, found := strings.Cut(url, "://")
+	if !found {
+		return nil, fmt.Errorf("url '%s' should include one of the schemes http, https, grpc or grpcs", url)
+	}
+
+	switch scheme {
+	case "http":
+		return http.New(url, opts...)
+	case "https":
+		return http.New(url, withDefaultTLS(opts)...)
+	case "grpc":
+		return grpc.New(address, opts...)
+	case "grpcs":
+		return grpc.New(address, withDefaultTLS(opts)...)
+	default:
+		return nil, fmt.Errorf("unsupported scheme '%s', expected http, https, grpc or grpcs", scheme)
+	}
+}
+
+// withDefaultTLS prepends an option enabling TLS, so that a TLS config given by the caller takes precedence.
+func withDefaultTLS(opts []base.Option) []base.Option {
+	return append([]base.Option{base.WithTLSConfig(&tls.Config{})}, opts...)
+}
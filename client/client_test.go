@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/client/grpc/grpc_generated_v2"
+	"github.com/Trendyol/go-triton-client/models"
+	"github.com/Trendyol/go-triton-client/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNew_SelectsTransportFromScheme(t *testing.T) {
+	tests := []struct {
+		url      string
+		protocol base.Protocol
+	}{
+		{url: "http://localhost:8000", protocol: base.ProtocolHTTP},
+		{url: "https://localhost:8000", protocol: base.ProtocolHTTP},
+		{url: "grpc://localhost:8001", protocol: base.ProtocolGRPC},
+		{url: "grpcs://localhost:8001", protocol: base.ProtocolGRPC},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			var protocol base.Protocol
+			interceptor := func(ctx context.Context, op base.Operation, req *base.Request, invoker base.Invoker) (any, error) {
+				protocol = req.Protocol
+				return true, nil
+			}
+
+			c, err := New(tt.url, base.WithInterceptors(interceptor))
+			require.NoError(t, err)
+
+			_, err = c.IsServerLive(context.Background(), &options.Options{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.protocol, protocol)
+		})
+	}
+}
+
+func TestNew_HTTPS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, base.WithTLSConfig(&tls.Config{InsecureSkipVerify: true}))
+	require.NoError(t, err)
+
+	live, err := c.IsServerLive(context.Background(), &options.Options{})
+	require.NoError(t, err)
+	assert.True(t, live)
+}
+
+func TestNew_InvalidScheme(t *testing.T) {
+	_, err := New("localhost:8000")
+	assert.ErrorContains(t, err, "should include one of the schemes")
+
+	_, err = New("ftp://localhost:8000")
+	assert.ErrorContains(t, err, "unsupported scheme 'ftp'")
+}
+
+// repositoryServer records the parameters of the last gRPC load request.
+type repositoryServer struct {
+	grpc_generated_v2.UnimplementedGRPCInferenceServiceServer
+	parameters map[string]any
+}
+
+func (s *repositoryServer) RepositoryModelLoad(ctx context.Context, req *grpc_generated_v2.RepositoryModelLoadRequest) (*grpc_generated_v2.RepositoryModelLoadResponse, error) {
+	s.parameters = make(map[string]any)
+	for key, parameter := range req.Parameters {
+		switch choice := parameter.ParameterChoice.(type) {
+		case *grpc_generated_v2.ModelRepositoryParameter_StringParam:
+			s.parameters[key] = choice.StringParam
+		case *grpc_generated_v2.ModelRepositoryParameter_BoolParam:
+			s.parameters[key] = choice.BoolParam
+		case *grpc_generated_v2.ModelRepositoryParameter_Int64Param:
+			s.parameters[key] = choice.Int64Param
+		case *grpc_generated_v2.ModelRepositoryParameter_BytesParam:
+			s.parameters[key] = choice.BytesParam
+		}
+	}
+	return &grpc_generated_v2.RepositoryModelLoadResponse{}, nil
+}
+
+func TestLoadModelWithRequest_TransportParity(t *testing.T) {
+	request := models.LoadModelRequest{
+		Config:     `{"name":"encoder","backend":"onnxruntime"}`,
+		Files:      map[string][]byte{"file:1/model.onnx": {0x08, 0x00, 0xff}},
+		Versions:   []int64{1},
+		Parameters: map[string]any{"priority": 2, "warmup": true, "tag": "canary", "blob": []byte("raw")},
+	}
+
+	var httpParameters map[string]any
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Parameters map[string]any `json:"parameters"`
+		}
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+		require.NoError(t, decoder.Decode(&body))
+		httpParameters = body.Parameters
+	}))
+	defer httpServer.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	repository := &repositoryServer{}
+	grpc_generated_v2.RegisterGRPCInferenceServiceServer(grpcServer, repository)
+	go func() { _ = grpcServer.Serve(listener) }()
+	defer grpcServer.Stop()
+
+	httpClient, err := New(httpServer.URL)
+	require.NoError(t, err)
+	grpcClient, err := New("grpc://" + listener.Addr().String())
+	require.NoError(t, err)
+
+	require.NoError(t, base.LoadModelWithRequest(context.Background(), httpClient, "encoder", request, &options.Options{}))
+	require.NoError(t, base.LoadModelWithRequest(context.Background(), grpcClient, "encoder", request, &options.Options{}))
+
+	// gRPC sends the files and bytes parameters as bytes, HTTP sends them base64 encoded.
+	assert.Equal(t, []byte{0x08, 0x00, 0xff}, repository.parameters["file:1/model.onnx"])
+	assert.Equal(t, []byte("raw"), repository.parameters["blob"])
+	decoded := make(map[string]any, len(httpParameters))
+	for key, value := range httpParameters {
+		switch v := value.(type) {
+		case json.Number:
+			decoded[key], err = v.Int64()
+			require.NoError(t, err)
+		case string:
+			if _, ok := repository.parameters[key].([]byte); ok {
+				decoded[key], err = base64.StdEncoding.DecodeString(v)
+				require.NoError(t, err)
+			} else {
+				decoded[key] = v
+			}
+		default:
+			decoded[key] = v
+		}
+	}
+	assert.Equal(t, repository.parameters, decoded)
+	assert.JSONEq(t, `{"name":"encoder","backend":"onnxruntime","version_policy":{"specific":{"versions":[1]}}}`, repository.parameters["config"].(string))
+}
@@ -2,51 +2,88 @@ package grpc
 
 import (
 	"context"
-	"encoding/base64"
+	"crypto/tls"
 	"fmt"
 	"github.com/Trendyol/go-triton-client/base"
 	"github.com/Trendyol/go-triton-client/client/grpc/grpc_generated_v2"
+	"github.com/Trendyol/go-triton-client/logging"
+	"github.com/Trendyol/go-triton-client/modelconfig"
 	"github.com/Trendyol/go-triton-client/models"
 	"github.com/Trendyol/go-triton-client/options"
 	"google.golang.org/grpc"
 	"log"
+	"log/slog"
+	"time"
 )
 
 type client struct {
-	baseURL           string
-	verbose           bool
-	connectionTimeout float64
-	networkTimeout    float64
-	ssl               bool
-	insecure          bool
-	client            grpc_generated_v2.GRPCInferenceServiceClient
-	logger            *log.Logger
+	baseURL string
+	verbose bool
+	client  grpc_generated_v2.GRPCInferenceServiceClient
+	logger  *slog.Logger
 }
 
-// NewClient creates a new gRPCInferenceServerClient.
-func NewClient(url string, verbose bool, connectionTimeout float64, networkTimeout float64, ssl bool, insecureConnection bool, grpcConnection *grpc.ClientConn, logger *log.Logger) (base.Client, error) {
+// NewClient creates a new gRPCInferenceServerClient. Timeouts are given in seconds.
+// Every call of the returned client goes through the given interceptors, the first being the outermost.
+//
+// Deprecated: use New, which takes explicit durations and options.
+func NewClient(url string, verbose bool, connectionTimeout float64, networkTimeout float64, ssl bool, insecureConnection bool, grpcConnection *grpc.ClientConn, logger *log.Logger, interceptors ...base.UnaryInterceptor) (base.Client, error) {
+	config := &base.Config{
+		Verbose:           verbose,
+		ConnectionTimeout: seconds(connectionTimeout),
+		NetworkTimeout:    seconds(networkTimeout),
+		Logger:            logging.NewTextLogger(logger),
+		Interceptors:      interceptors,
+		GRPCConnection:    grpcConnection,
+	}
+	if ssl {
+		config.TLSConfig = &tls.Config{InsecureSkipVerify: insecureConnection}
+	}
+
+	return newClient(url, config)
+}
+
+// New creates a new gRPC client for the server at url, given as host:port.
+func New(url string, opts ...base.Option) (base.Client, error) {
+	return newClient(url, base.NewConfig(opts...))
+}
+
+func newClient(url string, config *base.Config) (base.Client, error) {
+	grpcClient, err := base.NewGrpcClientFromConfig(url, config)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := config.Logger
 	if logger == nil {
-		logger = log.Default()
+		logger = slog.Default()
 	}
 
-	if grpcConnection == nil {
-		grpcClient, err := base.NewGrpcClient(url, connectionTimeout, networkTimeout, ssl, insecureConnection)
-		if err != nil {
-			return nil, err
-		}
-		grpcConnection = grpcClient.GetConnection()
-	}
-
-	return &client{
-		baseURL:           url,
-		verbose:           verbose,
-		connectionTimeout: connectionTimeout,
-		networkTimeout:    networkTimeout,
-		ssl:               ssl,
-		insecure:          insecureConnection,
-		client:            grpc_generated_v2.NewGRPCInferenceServiceClient(grpcConnection),
-		logger:            logger,
-	}, nil
+	interceptors := config.Interceptors
+	if config.Verbose {
+		verboseInterceptor := logging.NewInterceptor(logger, logging.Settings{Level: slog.LevelDebug})
+		interceptors = append([]base.UnaryInterceptor{verboseInterceptor}, interceptors...)
+	}
+	if config.NetworkTimeout > 0 {
+		interceptors = append(interceptors, base.NewTimeoutInterceptor(config.NetworkTimeout))
+	}
+
+	return base.NewInterceptedClient(&client{
+		baseURL: url,
+		verbose: config.Verbose,
+		client:  grpc_generated_v2.NewGRPCInferenceServiceClient(grpcClient.GetConnection()),
+		logger:  logger,
+	}, interceptors...), nil
+}
+
+// seconds converts a timeout given in seconds to a time.Duration.
+func seconds(timeout float64) time.Duration {
+	return time.Duration(timeout * float64(time.Second))
+}
+
+// Protocol returns the transport used by the client.
+func (c *client) Protocol() base.Protocol {
+	return base.ProtocolGRPC
 }
 
 func (c *client) IsServerLive(ctx context.Context, options *options.Options) (bool, error) {
@@ -88,10 +125,6 @@ func (c *client) GetServerMetadata(ctx context.Context, options *options.Options
 		Extensions: resp.Extensions,
 	}
 
-	if c.verbose {
-		c.logger.Println(response)
-	}
-
 	return response, nil
 }
 
@@ -106,10 +139,6 @@ func (c *client) GetModelMetadata(ctx context.Context, modelName, modelVersion s
 		return nil, err
 	}
 
-	if c.verbose {
-		c.logger.Println(resp)
-	}
-
 	modelMetadata := &models.ModelMetadataResponse{
 		Name:     resp.Name,
 		Versions: resp.Versions,
@@ -140,10 +169,6 @@ func (c *client) GetModelMetadata(ctx context.Context, modelName, modelVersion s
 		})
 	}
 
-	if c.verbose {
-		c.logger.Println(modelMetadata)
-	}
-
 	return modelMetadata, nil
 }
 
@@ -158,75 +183,26 @@ func (c *client) GetModelConfig(ctx context.Context, modelName, modelVersion str
 		return nil, err
 	}
 
-	configResponse := &models.ModelConfigResponse{
-		Name:                 resp.Config.Name,
-		Platform:             resp.Config.Platform,
-		Backend:              resp.Config.Backend,
-		DefaultModelFileName: resp.Config.DefaultModelFilename,
-	}
-
-	if resp.Config.VersionPolicy != nil && resp.Config.VersionPolicy.GetLatest() != nil {
-		configResponse.VersionPolicy = models.ModelConfigVersionPolicy{
-			Latest: models.ModelConfigLatestVersionPolicy{
-				NumVersions: int(resp.Config.VersionPolicy.GetLatest().NumVersions),
-			},
-		}
-	}
-
-	for _, input := range resp.Config.Input {
-		inputDims := make([]int, len(input.Dims))
-		for i, dim := range input.Dims {
-			inputDims[i] = int(dim)
-		}
-		configResponse.Input = append(configResponse.Input, models.ModelConfigInput{
-			Name:             input.Name,
-			DataType:         input.DataType.String(),
-			Format:           input.Format.String(),
-			Dims:             inputDims,
-			IsShapeTensor:    input.IsShapeTensor,
-			AllowRaggedBatch: input.AllowRaggedBatch,
-			Optional:         input.Optional,
-		})
-	}
-
-	for _, output := range resp.Config.Output {
-		outputDims := make([]int, len(output.Dims))
-		for i, dim := range output.Dims {
-			outputDims[i] = int(dim)
-		}
-		configResponse.Output = append(configResponse.Output, models.ModelConfigOutput{
-			Name:          output.Name,
-			DataType:      output.DataType.String(),
-			Dims:          outputDims,
-			LabelFilename: output.LabelFilename,
-			IsShapeTensor: output.IsShapeTensor,
-		})
-	}
-
-	for _, group := range resp.Config.InstanceGroup {
-		gpus := make([]string, len(group.Gpus))
-		for i, gpu := range group.Gpus {
-			gpus[i] = fmt.Sprintf("%d", gpu)
-		}
-		configResponse.InstanceGroup = append(configResponse.InstanceGroup, models.ModelConfigInstanceGroup{
-			Name:       group.Name,
-			Kind:       group.Kind.String(),
-			Count:      int(group.Count),
-			GPUs:       gpus,
-			Passive:    group.Passive,
-			HostPolicy: group.HostPolicy,
-		})
-	}
+	return modelconfig.ToModel(resp.Config), nil
+}
 
-	if c.verbose {
-		c.logger.Println(configResponse)
+func (c *client) GetModelConfigJSON(ctx context.Context, modelName, modelVersion string, options *options.Options) (string, error) {
+	resp, err := c.client.ModelConfig(ctx, &grpc_generated_v2.ModelConfigRequest{
+		Name:    modelName,
+		Version: modelVersion,
+	})
+	if err != nil {
+		return "", err
 	}
 
-	return configResponse, nil
+	return modelconfig.RenderJSON(resp.Config)
 }
 
 func (c *client) GetModelRepositoryIndex(ctx context.Context, options *options.Options) ([]models.ModelRepositoryIndexResponse, error) {
 	req := &grpc_generated_v2.RepositoryIndexRequest{}
+	if options != nil {
+		req.Ready = options.ReadyOnly
+	}
 
 	resp, err := c.client.RepositoryIndex(ctx, req)
 	if err != nil {
@@ -238,49 +214,48 @@ func (c *client) GetModelRepositoryIndex(ctx context.Context, options *options.O
 		index = append(index, models.ModelRepositoryIndexResponse{
 			Name:    model.Name,
 			Version: model.Version,
-			State:   model.State,
+			State:   models.ModelState(model.State),
 			Reason:  model.Reason,
 		})
 	}
 
-	if c.verbose {
-		c.logger.Println(index)
-	}
-
 	return index, nil
 }
 
 func (c *client) LoadModel(ctx context.Context, modelName string, config string, files map[string][]byte, options *options.Options) error {
-	loadRequest := &grpc_generated_v2.RepositoryModelLoadRequest{
-		ModelName:  modelName,
-		Parameters: make(map[string]*grpc_generated_v2.ModelRepositoryParameter),
-	}
+	return c.LoadModelWithRequest(ctx, modelName, models.LoadModelRequest{Config: config, Files: files}, options)
+}
 
-	if config != "" {
-		loadRequest.Parameters["config"] = &grpc_generated_v2.ModelRepositoryParameter{
-			ParameterChoice: &grpc_generated_v2.ModelRepositoryParameter_StringParam{
-				StringParam: config,
-			},
-		}
+func (c *client) LoadModelWithRequest(ctx context.Context, modelName string, request models.LoadModelRequest, options *options.Options) error {
+	parameters, err := base.LoadModelParameters(request)
+	if err != nil {
+		return fmt.Errorf("failed to load model '%s': %w", modelName, err)
 	}
 
-	for path, content := range files {
-		loadRequest.Parameters[path] = &grpc_generated_v2.ModelRepositoryParameter{
-			ParameterChoice: &grpc_generated_v2.ModelRepositoryParameter_StringParam{
-				StringParam: base64.StdEncoding.EncodeToString(content),
-			},
+	loadRequest := &grpc_generated_v2.RepositoryModelLoadRequest{
+		ModelName:  modelName,
+		Parameters: make(map[string]*grpc_generated_v2.ModelRepositoryParameter, len(parameters)),
+	}
+	for key, value := range parameters {
+		parameter := &grpc_generated_v2.ModelRepositoryParameter{}
+		switch v := value.(type) {
+		case string:
+			parameter.ParameterChoice = &grpc_generated_v2.ModelRepositoryParameter_StringParam{StringParam: v}
+		case bool:
+			parameter.ParameterChoice = &grpc_generated_v2.ModelRepositoryParameter_BoolParam{BoolParam: v}
+		case int64:
+			parameter.ParameterChoice = &grpc_generated_v2.ModelRepositoryParameter_Int64Param{Int64Param: v}
+		case []byte:
+			parameter.ParameterChoice = &grpc_generated_v2.ModelRepositoryParameter_BytesParam{BytesParam: v}
 		}
+		loadRequest.Parameters[key] = parameter
 	}
 
-	_, err := c.client.RepositoryModelLoad(ctx, loadRequest)
+	_, err = c.client.RepositoryModelLoad(ctx, loadRequest)
 	if err != nil {
 		return fmt.Errorf("failed to load model '%s': %w", modelName, err)
 	}
 
-	if c.verbose {
-		c.logger.Printf("successfully loaded model '%s'\n", modelName)
-	}
-
 	return nil
 }
 
@@ -301,10 +276,6 @@ func (c *client) UnloadModel(ctx context.Context, modelName string, unloadDepend
 		return fmt.Errorf("failed to unload model '%s': %w", modelName, err)
 	}
 
-	if c.verbose {
-		c.logger.Printf("successfully unloaded model '%s'\n", modelName)
-	}
-
 	return nil
 }
 
@@ -347,10 +318,6 @@ func (c *client) GetInferenceStatistics(ctx context.Context, modelName, modelVer
 		inferenceStatsResponse.ModelStats[i] = stat
 	}
 
-	if c.verbose {
-		c.logger.Println(inferenceStatsResponse)
-	}
-
 	return inferenceStatsResponse, nil
 }
 
@@ -389,10 +356,6 @@ func (c *client) GetTraceSettings(ctx context.Context, modelName string, options
 		}
 	}
 
-	if c.verbose {
-		c.logger.Println(traceSettings)
-	}
-
 	return traceSettings, nil
 }
 
@@ -437,10 +400,6 @@ func (c *client) UpdateLogSettings(ctx context.Context, request models.LogSettin
 		return fmt.Errorf("failed to update log settings: %w", err)
 	}
 
-	if c.verbose {
-		c.logger.Println("updated log settings")
-	}
-
 	return nil
 }
 
@@ -477,10 +436,6 @@ func (c *client) GetLogSettings(ctx context.Context, options *options.Options) (
 		}
 	}
 
-	if c.verbose {
-		c.logger.Println(logSettings)
-	}
-
 	return logSettings, nil
 }
 
@@ -501,10 +456,6 @@ func (c *client) GetSystemSharedMemoryStatus(ctx context.Context, name string, o
 		})
 	}
 
-	if c.verbose {
-		c.logger.Println(status)
-	}
-
 	return status, nil
 }
 
@@ -521,10 +472,6 @@ func (c *client) RegisterSystemSharedMemory(ctx context.Context, name, key strin
 		return fmt.Errorf("failed to register system shared memory with name '%s': %w", name, err)
 	}
 
-	if c.verbose {
-		c.logger.Printf("registered system shared memory with name '%s'\n", name)
-	}
-
 	return nil
 }
 
@@ -536,10 +483,6 @@ func (c *client) UnregisterSystemSharedMemory(ctx context.Context, name string,
 		return err
 	}
 
-	if c.verbose {
-		c.logger.Printf("unregistered system shared memory with name '%s'\n", name)
-	}
-
 	return nil
 }
 
@@ -562,10 +505,6 @@ func (c *client) GetCUDASharedMemoryStatus(ctx context.Context, name string, opt
 		})
 	}
 
-	if c.verbose {
-		c.logger.Println(status)
-	}
-
 	return status, nil
 }
 
@@ -582,10 +521,6 @@ func (c *client) RegisterCUDASharedMemory(ctx context.Context, name string, rawH
 		return err
 	}
 
-	if c.verbose {
-		c.logger.Printf("registered CUDA shared memory with name '%s'\n", name)
-	}
-
 	return nil
 }
 
@@ -597,10 +532,6 @@ func (c *client) UnregisterCUDASharedMemory(ctx context.Context, name string, op
 		return err
 	}
 
-	if c.verbose {
-		c.logger.Printf("unregistered CUDA shared memory with name '%s'\n", name)
-	}
-
 	return nil
 }
 
@@ -628,5 +559,9 @@ func (c *client) Infer(
 
 	// Map the response to the InferResult model
 	responseWrapper := NewResponseWrapper(resp)
-	return NewInferResult(responseWrapper, c.verbose)
+	var logger *slog.Logger
+	if c.verbose {
+		logger = c.logger
+	}
+	return NewInferResultWithLogger(responseWrapper, logger)
 }
@@ -10,8 +10,13 @@ import (
 	"github.com/Trendyol/go-triton-client/options"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
-	"log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"net"
 	"testing"
+	"time"
 )
 
 func TestNewClient_Success(t *testing.T) {
@@ -34,6 +39,23 @@ func TestNewClient_Error(t *testing.T) {
 	assert.Nil(t, c)
 }
 
+func TestNewClient_WithInterceptors(t *testing.T) {
+	rejected := errors.New("rejected")
+	var seen []base.Operation
+	interceptor := func(ctx context.Context, op base.Operation, req *base.Request, invoker base.Invoker) (any, error) {
+		seen = append(seen, op)
+		assert.Equal(t, "model", req.ModelName)
+		return nil, rejected
+	}
+
+	c, err := NewClient("localhost:50051", false, 30.0, 10.0, false, false, nil, nil, interceptor)
+	assert.NoError(t, err)
+
+	_, err = c.Infer(context.Background(), "model", "1", nil, nil, nil)
+	assert.ErrorIs(t, err, rejected)
+	assert.Equal(t, []base.Operation{base.OperationInfer}, seen)
+}
+
 func TestIsServerLive(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -101,7 +123,7 @@ func TestGetServerMetadata(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	metadata, err := c.GetServerMetadata(context.Background(), &options.Options{})
@@ -144,7 +166,7 @@ func TestGetModelMetadata(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	metadata, err := c.GetModelMetadata(context.Background(), modelName, modelVersion, &options.Options{})
@@ -223,7 +245,7 @@ func TestGetModelConfig(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	config, err := c.GetModelConfig(context.Background(), modelName, modelVersion, &options.Options{})
@@ -232,6 +254,29 @@ func TestGetModelConfig(t *testing.T) {
 	assert.Equal(t, resp.Config.Platform, config.Platform)
 }
 
+func TestGetModelConfigJSON_KeepsEveryField(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	resp := &grpc_generated_v2.ModelConfigResponse{
+		Config: &grpc_generated_v2.ModelConfig{
+			Name:         "test_model",
+			MaxBatchSize: 8,
+			SchedulingChoice: &grpc_generated_v2.ModelConfig_DynamicBatching{
+				DynamicBatching: &grpc_generated_v2.ModelDynamicBatching{PreferredBatchSize: []int32{4, 8}},
+			},
+		},
+	}
+	mockClient := mocks.NewMockGRPCInferenceServiceClient(ctrl)
+	mockClient.EXPECT().ModelConfig(gomock.Any(), gomock.Any()).Return(resp, nil)
+
+	c := &client{client: mockClient, logger: slog.Default()}
+
+	config, err := c.GetModelConfigJSON(context.Background(), "test_model", "", &options.Options{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"test_model","max_batch_size":8,"dynamic_batching":{"preferred_batch_size":[4,8]}}`, config)
+}
+
 func TestGetModelRepositoryIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -253,13 +298,30 @@ func TestGetModelRepositoryIndex(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	index, err := c.GetModelRepositoryIndex(context.Background(), &options.Options{})
 	assert.NoError(t, err)
 	assert.Len(t, index, 1)
 	assert.Equal(t, resp.Models[0].Name, index[0].Name)
+	assert.Equal(t, models.ModelStateReady, index[0].State)
+}
+
+func TestGetModelRepositoryIndex_ReadyOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockGRPCInferenceServiceClient(ctrl)
+	mockClient.EXPECT().RepositoryIndex(gomock.Any(), &grpc_generated_v2.RepositoryIndexRequest{Ready: true}).Return(&grpc_generated_v2.RepositoryIndexResponse{}, nil)
+
+	c := &client{
+		client: mockClient,
+		logger: slog.Default(),
+	}
+
+	_, err := c.GetModelRepositoryIndex(context.Background(), &options.Options{ReadyOnly: true})
+	assert.NoError(t, err)
 }
 
 func TestLoadModel(t *testing.T) {
@@ -274,7 +336,7 @@ func TestLoadModel(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	err := c.LoadModel(context.Background(), modelName, "test-config", map[string][]byte{"test-key": []byte("test-data")}, &options.Options{})
@@ -293,7 +355,7 @@ func TestUnloadModel(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	err := c.UnloadModel(context.Background(), modelName, true, &options.Options{})
@@ -330,7 +392,7 @@ func TestGetInferenceStatistics(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	stats, err := c.GetInferenceStatistics(context.Background(), modelName, modelVersion, &options.Options{})
@@ -361,7 +423,7 @@ func TestGetTraceSettings(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	settings, err := c.GetTraceSettings(context.Background(), modelName, &options.Options{})
@@ -392,7 +454,7 @@ func TestUpdateLogSettings(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	err := c.UpdateLogSettings(context.Background(), request, &options.Options{})
@@ -420,7 +482,7 @@ func TestGetLogSettings(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	settings, err := c.GetLogSettings(context.Background(), &options.Options{})
@@ -447,7 +509,7 @@ func TestGetSystemSharedMemoryStatus(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	status, err := c.GetSystemSharedMemoryStatus(context.Background(), name, &options.Options{})
@@ -468,7 +530,7 @@ func TestRegisterSystemSharedMemory(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	err := c.RegisterSystemSharedMemory(context.Background(), name, "key", 1024, 0, &options.Options{})
@@ -487,7 +549,7 @@ func TestUnregisterSystemSharedMemory(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	err := c.UnregisterSystemSharedMemory(context.Background(), name, &options.Options{})
@@ -515,7 +577,7 @@ func TestGetCUDASharedMemoryStatus(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	status, err := c.GetCUDASharedMemoryStatus(context.Background(), name, &options.Options{})
@@ -536,7 +598,7 @@ func TestRegisterCUDASharedMemory(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	err := c.RegisterCUDASharedMemory(context.Background(), name, []byte{0x00}, 0, 1024, &options.Options{})
@@ -555,7 +617,7 @@ func TestUnregisterCUDASharedMemory(t *testing.T) {
 	c := &client{
 		client:  mockClient,
 		verbose: true,
-		logger:  log.Default(),
+		logger:  slog.Default(),
 	}
 
 	err := c.UnregisterCUDASharedMemory(context.Background(), name, &options.Options{})
@@ -1011,3 +1073,123 @@ func TestInfer_PrepareRequestError(t *testing.T) {
 
 	assert.Error(t, err)
 }
+
+func TestNew_WithOptions(t *testing.T) {
+	c, err := New("localhost:50051",
+		base.WithConnectionTimeout(time.Second),
+		base.WithNetworkTimeout(time.Millisecond),
+		base.WithKeepalive(base.Keepalive{Time: time.Minute, Timeout: time.Second}),
+		base.WithUserAgent("triton-test"),
+	)
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+
+	_, err = New("invalid_url\n")
+	assert.Error(t, err)
+}
+
+func TestNew_AppliesNetworkTimeout(t *testing.T) {
+	// The listener accepts connections but never completes the HTTP/2 handshake.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	c, err := New(listener.Addr().String(), base.WithNetworkTimeout(50*time.Millisecond))
+	assert.NoError(t, err)
+
+	start := time.Now()
+	_, err = c.IsServerLive(context.Background(), &options.Options{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+// inferenceServer answers inferences with a raw output of outputSize bytes and fails the first
+// failures calls with UNAVAILABLE.
+type inferenceServer struct {
+	grpc_generated_v2.UnimplementedGRPCInferenceServiceServer
+	outputSize int
+	failures   int
+	calls      int
+}
+
+func (s *inferenceServer) ModelInfer(ctx context.Context, req *grpc_generated_v2.ModelInferRequest) (*grpc_generated_v2.ModelInferResponse, error) {
+	s.calls++
+	if s.calls <= s.failures {
+		return nil, status.Error(codes.Unavailable, "loading")
+	}
+	return &grpc_generated_v2.ModelInferResponse{
+		ModelName: req.ModelName,
+		Outputs: []*grpc_generated_v2.ModelInferResponse_InferOutputTensor{
+			{Name: "output", Datatype: "BYTES", Shape: []int64{1}},
+		},
+		RawOutputContents: [][]byte{make([]byte, s.outputSize)},
+	}, nil
+}
+
+func startInferenceServer(t *testing.T, service *inferenceServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	grpc_generated_v2.RegisterGRPCInferenceServiceServer(server, service)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestNew_MaxReceiveMessageSize(t *testing.T) {
+	address := startInferenceServer(t, &inferenceServer{outputSize: 5 << 20})
+
+	c, err := New(address, base.WithNetworkTimeout(5*time.Second))
+	assert.NoError(t, err)
+	_, err = c.Infer(context.Background(), "model", "", nil, nil, nil)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	c, err = New(address, base.WithNetworkTimeout(5*time.Second), base.WithMaxMessageSizes(0, 16<<20), base.WithInitialWindowSizes(1<<20, 1<<22))
+	assert.NoError(t, err)
+	result, err := c.Infer(context.Background(), "model", "", nil, nil, nil)
+	assert.NoError(t, err)
+	shape, err := result.GetShape("output")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, shape)
+}
+
+func TestNew_ServiceConfigRetryPolicy(t *testing.T) {
+	service := &inferenceServer{failures: 2}
+	address := startInferenceServer(t, service)
+
+	c, err := New(address, base.WithNetworkTimeout(5*time.Second), base.WithServiceConfig(`{
+		"methodConfig": [{
+			"name": [{"service": "inference.GRPCInferenceService"}],
+			"retryPolicy": {
+				"maxAttempts": 3,
+				"initialBackoff": "0.01s",
+				"maxBackoff": "0.01s",
+				"backoffMultiplier": 1,
+				"retryableStatusCodes": ["UNAVAILABLE"]
+			}
+		}]
+	}`))
+	assert.NoError(t, err)
+
+	_, err = c.Infer(context.Background(), "model", "", nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, service.calls)
+
+	_, err = New(address, base.WithServiceConfig("{invalid"))
+	assert.Error(t, err)
+}
+
+func TestNew_DialOptions(t *testing.T) {
+	var methods []string
+	interceptor := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		methods = append(methods, method)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	address := startInferenceServer(t, &inferenceServer{})
+
+	c, err := New(address, base.WithNetworkTimeout(5*time.Second), base.WithDialOptions(grpc.WithChainUnaryInterceptor(interceptor)))
+	assert.NoError(t, err)
+	_, err = c.Infer(context.Background(), "model", "", nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/inference.GRPCInferenceService/ModelInfer"}, methods)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/Trendyol/go-triton-client/base"
 	"github.com/Trendyol/go-triton-client/client/grpc/grpc_generated_v2"
 	"github.com/Trendyol/go-triton-client/converter"
+	"log/slog"
 )
 
 // InferResult represents the result of an inference operation using gRPC.
@@ -13,7 +14,18 @@ type InferResult struct {
 }
 
 // NewInferResult creates a new gRPC InferResult instance.
+// When verbose is true, a debug record describing the response is sent to slog.Default().
 func NewInferResult(responseWrapper base.ResponseWrapper, verbose bool) (base.InferResult, error) {
+	var logger *slog.Logger
+	if verbose {
+		logger = slog.Default()
+	}
+	return NewInferResultWithLogger(responseWrapper, logger)
+}
+
+// NewInferResultWithLogger creates a new gRPC InferResult instance.
+// When logger is not nil, a debug record describes the response without its tensor data.
+func NewInferResultWithLogger(responseWrapper base.ResponseWrapper, logger *slog.Logger) (base.InferResult, error) {
 	result := base.InferOutputs{}
 	buffer := []byte{}
 	outputNameToBufferMap := make(map[string]int)
@@ -45,9 +57,19 @@ func NewInferResult(responseWrapper base.ResponseWrapper, verbose bool) (base.In
 
 	result.Outputs = outputs
 
-	if verbose {
-		fmt.Println("GRPC Response:", response)
-		fmt.Println("Buffer:", buffer)
+	if logger != nil {
+		outputNames := make([]string, len(outputs))
+		for i, output := range outputs {
+			outputNames[i] = output.GetName()
+		}
+		logger.Debug("parsed inference response",
+			slog.String("protocol", string(base.ProtocolGRPC)),
+			slog.String("model_name", result.ModelName),
+			slog.String("model_version", result.ModelVersion),
+			slog.String("request_id", response.Id),
+			slog.Any("outputs", outputNames),
+			slog.Int("binary_bytes", len(buffer)),
+		)
 	}
 
 	return &InferResult{
@@ -111,6 +133,10 @@ func (r *InferResult) AsByteSlice(name string) ([]string, error) {
 	return getAsSlice[string](name, r, converter.DeserializeBytesTensor)
 }
 
+func (r *InferResult) AsBytesSlice(name string) ([][]byte, error) {
+	return getAsSlice[[]byte](name, r, converter.DeserializeSliceOfBytesTensor)
+}
+
 func getAsSlice[T any](name string, inferResult *InferResult, deserializer func(buffer []byte) ([]T, error)) ([]T, error) {
 	_, err := inferResult.GetOutput(name)
 	if err != nil {
@@ -3,6 +3,7 @@ package grpc
 import (
 	"github.com/Trendyol/go-triton-client/client/grpc/grpc_generated_v2"
 	"github.com/Trendyol/go-triton-client/mocks"
+	"log/slog"
 	"testing"
 
 	"go.uber.org/mock/gomock"
@@ -29,7 +30,7 @@ func TestNewInferResult_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	result, err := NewInferResult(mockResponseWrapper, true)
+	result, err := NewInferResultWithLogger(mockResponseWrapper, slog.Default())
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -46,7 +47,7 @@ func TestNewInferResult_InvalidResponseType(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return("invalid_response").Times(1)
 
-	result, err := NewInferResult(mockResponseWrapper, true)
+	result, err := NewInferResult(mockResponseWrapper, false)
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -79,7 +80,7 @@ func TestNewInferResult_Verbose(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	result, err := NewInferResult(mockResponseWrapper, true)
+	result, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -103,7 +104,7 @@ func TestNewInferResult_NoOutputs(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	result, err := NewInferResult(mockResponseWrapper, true)
+	result, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -133,7 +134,7 @@ func TestInferResult_AsFloat16Slice_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -169,7 +170,7 @@ func TestInferResult_AsFloat32Slice_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -205,7 +206,7 @@ func TestInferResult_AsFloat64Slice_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -241,7 +242,7 @@ func TestInferResult_AsInt8Slice_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -277,7 +278,7 @@ func TestInferResult_AsInt16Slice_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -313,7 +314,7 @@ func TestInferResult_AsInt32Slice_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -349,7 +350,7 @@ func TestInferResult_AsInt64Slice_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -385,7 +386,7 @@ func TestInferResult_AsUint8Slice_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -421,7 +422,7 @@ func TestInferResult_AsUint16Slice_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -457,7 +458,7 @@ func TestInferResult_AsUint32Slice_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -493,7 +494,7 @@ func TestInferResult_AsUint64Slice_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -529,7 +530,7 @@ func TestInferResult_AsBoolSlice_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -565,7 +566,7 @@ func TestInferResult_AsBytesSlice_Success(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -603,7 +604,7 @@ func TestInferResult_AsSlice_OutputNotFound(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -639,7 +640,7 @@ func TestInferResult_GetOutput_Found(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -677,7 +678,7 @@ func TestInferResult_GetOutput_NotFound(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -713,7 +714,7 @@ func TestInferResult_AsSlice_MissingBufferMapEntry(t *testing.T) {
 
 	mockResponseWrapper.EXPECT().GetResponse().Return(response).Times(1)
 
-	resultInterface, err := NewInferResult(mockResponseWrapper, true)
+	resultInterface, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
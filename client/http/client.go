@@ -1,17 +1,20 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/logging"
 	"github.com/Trendyol/go-triton-client/marshaller"
 	"github.com/Trendyol/go-triton-client/models"
 	"github.com/Trendyol/go-triton-client/options"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
@@ -19,56 +22,90 @@ import (
 )
 
 type client struct {
-	baseURL           string
-	verbose           bool
-	connectionTimeout float64
-	networkTimeout    float64
-	ssl               bool
-	insecure          bool
-	httpClient        base.HttpClient
-	marshaller        base.Marshaller
-	logger            *log.Logger
+	baseURL    string
+	verbose    bool
+	httpClient base.HttpClient
+	marshaller base.Marshaller
+	logger     *slog.Logger
 }
 
-// NewClient creates a new httpInferenceServerClient.
-func NewClient(url string, verbose bool, connectionTimeout float64, networkTimeout float64, ssl bool, insecure bool, httpClient *http.Client, logger *log.Logger) (base.Client, error) {
+// NewClient creates a new httpInferenceServerClient. Timeouts are given in seconds.
+// Every call of the returned client goes through the given interceptors, the first being the outermost.
+//
+// Deprecated: use New, which takes explicit durations and options.
+func NewClient(url string, verbose bool, connectionTimeout float64, networkTimeout float64, ssl bool, insecure bool, httpClient *http.Client, logger *log.Logger, interceptors ...base.UnaryInterceptor) (base.Client, error) {
 	if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
 		return nil, fmt.Errorf("url should not include the scheme")
 	}
 
-	scheme := "http://"
+	config := &base.Config{
+		Verbose:           verbose,
+		ConnectionTimeout: seconds(connectionTimeout),
+		NetworkTimeout:    seconds(networkTimeout),
+		Logger:            logging.NewTextLogger(logger),
+		Interceptors:      interceptors,
+		HTTPClient:        httpClient,
+	}
 	if ssl {
-		scheme = "https://"
+		config.TLSConfig = &tls.Config{InsecureSkipVerify: insecure}
+	}
+
+	return newClient(url, config), nil
+}
+
+// New creates a new HTTP client for the server at url, which may start with http:// or https://.
+// Without a scheme, https is used when a TLS config is given.
+func New(url string, opts ...base.Option) (base.Client, error) {
+	if url == "" {
+		return nil, fmt.Errorf("url should not be empty")
 	}
+	return newClient(url, base.NewConfig(opts...)), nil
+}
 
-	if httpClient == nil {
-		httpClient = &http.Client{
-			Timeout: time.Duration(connectionTimeout) * time.Second,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
-			},
+func newClient(url string, config *base.Config) base.Client {
+	baseURL := url
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		baseURL = "http://" + url
+		if config.TLSConfig != nil {
+			baseURL = "https://" + url
 		}
 	}
 
+	logger := config.Logger
 	if logger == nil {
-		logger = log.Default()
-	}
-
-	return &client{
-		baseURL:           scheme + url,
-		verbose:           verbose,
-		connectionTimeout: connectionTimeout,
-		networkTimeout:    networkTimeout,
-		ssl:               ssl,
-		insecure:          insecure,
-		httpClient:        base.NewHttpClient(connectionTimeout, insecure, httpClient),
-		logger:            logger,
-		marshaller:        marshaller.NewJSONMarshaller(),
-	}, nil
+		logger = slog.Default()
+	}
+
+	interceptors := config.Interceptors
+	if config.Verbose {
+		verboseInterceptor := logging.NewInterceptor(logger, logging.Settings{Level: slog.LevelDebug})
+		interceptors = append([]base.UnaryInterceptor{verboseInterceptor}, interceptors...)
+	}
+	if config.NetworkTimeout > 0 {
+		interceptors = append(interceptors, base.NewTimeoutInterceptor(config.NetworkTimeout))
+	}
+
+	return base.NewInterceptedClient(&client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		verbose:    config.Verbose,
+		httpClient: base.NewHttpClientFromConfig(config),
+		logger:     logger,
+		marshaller: marshaller.NewJSONMarshaller(),
+	}, interceptors...)
+}
+
+// seconds converts a timeout given in seconds to a time.Duration.
+func seconds(timeout float64) time.Duration {
+	return time.Duration(timeout * float64(time.Second))
+}
+
+// Protocol returns the transport used by the client.
+func (c *client) Protocol() base.Protocol {
+	return base.ProtocolHTTP
 }
 
 func (c *client) IsServerLive(ctx context.Context, options *options.Options) (bool, error) {
-	resp, err := c.httpClient.Get(c.baseURL, "v2/health/live", options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Get(ctx, c.baseURL, "v2/health/live", options.Headers, options.QueryParams)
 	if err != nil {
 		return false, err
 	}
@@ -78,7 +115,7 @@ func (c *client) IsServerLive(ctx context.Context, options *options.Options) (bo
 }
 
 func (c *client) IsServerReady(ctx context.Context, options *options.Options) (bool, error) {
-	resp, err := c.httpClient.Get(c.baseURL, "v2/health/ready", options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Get(ctx, c.baseURL, "v2/health/ready", options.Headers, options.QueryParams)
 	if err != nil {
 		return false, err
 	}
@@ -93,7 +130,7 @@ func (c *client) IsModelReady(ctx context.Context, modelName string, modelVersio
 		requestURI = fmt.Sprintf("v2/models/%s/versions/%s/ready", url.QueryEscape(modelName), url.QueryEscape(modelVersion))
 	}
 
-	resp, err := c.httpClient.Get(c.baseURL, requestURI, options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Get(ctx, c.baseURL, requestURI, options.Headers, options.QueryParams)
 	if err != nil {
 		return false, err
 	}
@@ -103,7 +140,7 @@ func (c *client) IsModelReady(ctx context.Context, modelName string, modelVersio
 }
 
 func (c *client) GetServerMetadata(ctx context.Context, options *options.Options) (*models.ServerMetadataResponse, error) {
-	resp, err := c.httpClient.Get(c.baseURL, "v2", options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Get(ctx, c.baseURL, "v2", options.Headers, options.QueryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -118,10 +155,6 @@ func (c *client) GetServerMetadata(ctx context.Context, options *options.Options
 		return nil, err
 	}
 
-	if c.verbose {
-		c.logger.Println(response)
-	}
-
 	return &response, nil
 }
 
@@ -131,7 +164,7 @@ func (c *client) GetModelMetadata(ctx context.Context, modelName string, modelVe
 		requestURI = fmt.Sprintf("v2/models/%s/versions/%s", url.QueryEscape(modelName), url.QueryEscape(modelVersion))
 	}
 
-	resp, err := c.httpClient.Get(c.baseURL, requestURI, options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Get(ctx, c.baseURL, requestURI, options.Headers, options.QueryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -146,20 +179,44 @@ func (c *client) GetModelMetadata(ctx context.Context, modelName string, modelVe
 		return nil, err
 	}
 
-	if c.verbose {
-		c.logger.Println(response)
+	return &response, nil
+}
+
+func (c *client) GetModelConfig(ctx context.Context, modelName string, modelVersion string, options *options.Options) (*models.ModelConfigResponse, error) {
+	body, err := c.getModelConfig(ctx, modelName, modelVersion, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.ModelConfigResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (c *client) GetModelConfig(ctx context.Context, modelName string, modelVersion string, options *options.Options) (*models.ModelConfigResponse, error) {
+func (c *client) GetModelConfigJSON(ctx context.Context, modelName string, modelVersion string, options *options.Options) (string, error) {
+	body, err := c.getModelConfig(ctx, modelName, modelVersion, options)
+	if err != nil {
+		return "", err
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, body); err != nil {
+		return "", err
+	}
+	return compacted.String(), nil
+}
+
+// getModelConfig returns the body of the model configuration endpoint.
+func (c *client) getModelConfig(ctx context.Context, modelName string, modelVersion string, options *options.Options) ([]byte, error) {
 	requestURI := fmt.Sprintf("v2/models/%s/config", url.QueryEscape(modelName))
 	if modelVersion != "" {
 		requestURI = fmt.Sprintf("v2/models/%s/versions/%s/config", url.QueryEscape(modelName), url.QueryEscape(modelVersion))
 	}
 
-	resp, err := c.httpClient.Get(c.baseURL, requestURI, options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Get(ctx, c.baseURL, requestURI, options.Headers, options.QueryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -169,20 +226,15 @@ func (c *client) GetModelConfig(ctx context.Context, modelName string, modelVers
 		return nil, fmt.Errorf("failed to get model configuration. Status code: %d", resp.StatusCode)
 	}
 
-	var response models.ModelConfigResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, err
-	}
-
-	if c.verbose {
-		c.logger.Println(response)
-	}
-
-	return &response, nil
+	return io.ReadAll(resp.Body)
 }
 
 func (c *client) GetModelRepositoryIndex(ctx context.Context, options *options.Options) ([]models.ModelRepositoryIndexResponse, error) {
-	resp, err := c.httpClient.Post(c.baseURL, "v2/repository/index", "", options.Headers, options.QueryParams)
+	requestBody := ""
+	if options.ReadyOnly {
+		requestBody = `{"ready":true}`
+	}
+	resp, err := c.httpClient.Post(ctx, c.baseURL, "v2/repository/index", requestBody, options.Headers, options.QueryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -197,29 +249,27 @@ func (c *client) GetModelRepositoryIndex(ctx context.Context, options *options.O
 		return nil, err
 	}
 
-	if c.verbose {
-		c.logger.Println(response)
-	}
-
 	return response, nil
 }
 
 func (c *client) LoadModel(ctx context.Context, modelName string, config string, files map[string][]byte, options *options.Options) error {
-	requestURI := fmt.Sprintf("v2/repository/models/%s/load", url.QueryEscape(modelName))
+	return c.LoadModelWithRequest(ctx, modelName, models.LoadModelRequest{Config: config, Files: files}, options)
+}
 
-	loadRequest := make(map[string]any)
-	parameters := make(map[string]any)
+func (c *client) LoadModelWithRequest(ctx context.Context, modelName string, request models.LoadModelRequest, options *options.Options) error {
+	requestURI := fmt.Sprintf("v2/repository/models/%s/load", url.QueryEscape(modelName))
 
-	if config != "" {
-		parameters["config"] = config
+	parameters, err := base.LoadModelParameters(request)
+	if err != nil {
+		return fmt.Errorf("failed to load model '%s': %w", modelName, err)
 	}
-
-	if files != nil {
-		for path, content := range files {
-			parameters[path] = base64.StdEncoding.EncodeToString(content)
+	for key, value := range parameters {
+		if content, ok := value.([]byte); ok {
+			parameters[key] = base64.StdEncoding.EncodeToString(content)
 		}
 	}
 
+	loadRequest := make(map[string]any)
 	if len(parameters) > 0 {
 		loadRequest["parameters"] = parameters
 	}
@@ -229,7 +279,7 @@ func (c *client) LoadModel(ctx context.Context, modelName string, config string,
 		return err
 	}
 
-	resp, err := c.httpClient.Post(c.baseURL, requestURI, string(requestBody), options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Post(ctx, c.baseURL, requestURI, string(requestBody), options.Headers, options.QueryParams)
 	if err != nil {
 		return err
 	}
@@ -239,10 +289,6 @@ func (c *client) LoadModel(ctx context.Context, modelName string, config string,
 		return fmt.Errorf("failed to load model '%s'. Status code: %d", modelName, resp.StatusCode)
 	}
 
-	if c.verbose {
-		c.logger.Printf("Loaded model '%s'\n", modelName)
-	}
-
 	return nil
 }
 
@@ -260,7 +306,7 @@ func (c *client) UnloadModel(ctx context.Context, modelName string, unloadDepend
 		return err
 	}
 
-	resp, err := c.httpClient.Post(c.baseURL, requestURI, string(requestBody), options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Post(ctx, c.baseURL, requestURI, string(requestBody), options.Headers, options.QueryParams)
 	if err != nil {
 		return err
 	}
@@ -270,10 +316,6 @@ func (c *client) UnloadModel(ctx context.Context, modelName string, unloadDepend
 		return fmt.Errorf("failed to unload model '%s'. Status code: %d", modelName, resp.StatusCode)
 	}
 
-	if c.verbose {
-		c.logger.Printf("Unloaded model '%s'\n", modelName)
-	}
-
 	return nil
 }
 
@@ -283,7 +325,7 @@ func (c *client) GetInferenceStatistics(ctx context.Context, modelName string, m
 		requestURI = fmt.Sprintf("v2/models/%s/versions/%s/stats", url.QueryEscape(modelName), url.QueryEscape(modelVersion))
 	}
 
-	resp, err := c.httpClient.Get(c.baseURL, requestURI, options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Get(ctx, c.baseURL, requestURI, options.Headers, options.QueryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -298,10 +340,6 @@ func (c *client) GetInferenceStatistics(ctx context.Context, modelName string, m
 		return nil, err
 	}
 
-	if c.verbose {
-		c.logger.Println(response)
-	}
-
 	return &response, nil
 }
 
@@ -311,7 +349,7 @@ func (c *client) GetTraceSettings(ctx context.Context, modelName string, options
 		requestURI = fmt.Sprintf("v2/models/%s/trace/setting", url.QueryEscape(modelName))
 	}
 
-	resp, err := c.httpClient.Get(c.baseURL, requestURI, options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Get(ctx, c.baseURL, requestURI, options.Headers, options.QueryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -326,10 +364,6 @@ func (c *client) GetTraceSettings(ctx context.Context, modelName string, options
 		return nil, err
 	}
 
-	if c.verbose {
-		c.logger.Println(response)
-	}
-
 	return &response, nil
 }
 
@@ -339,7 +373,7 @@ func (c *client) UpdateLogSettings(ctx context.Context, request models.LogSettin
 		return err
 	}
 
-	resp, err := c.httpClient.Post(c.baseURL, "v2/logging", string(requestBody), options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Post(ctx, c.baseURL, "v2/logging", string(requestBody), options.Headers, options.QueryParams)
 	if err != nil {
 		return err
 	}
@@ -349,15 +383,11 @@ func (c *client) UpdateLogSettings(ctx context.Context, request models.LogSettin
 		return fmt.Errorf("failed to update log settings. Status code: %d", resp.StatusCode)
 	}
 
-	if c.verbose {
-		c.logger.Println("Updated log settings")
-	}
-
 	return nil
 }
 
 func (c *client) GetLogSettings(ctx context.Context, options *options.Options) (*models.LogSettingsResponse, error) {
-	resp, err := c.httpClient.Get(c.baseURL, "v2/logging", options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Get(ctx, c.baseURL, "v2/logging", options.Headers, options.QueryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -372,10 +402,6 @@ func (c *client) GetLogSettings(ctx context.Context, options *options.Options) (
 		return nil, err
 	}
 
-	if c.verbose {
-		c.logger.Println(response)
-	}
-
 	return &response, nil
 }
 
@@ -385,7 +411,7 @@ func (c *client) GetSystemSharedMemoryStatus(ctx context.Context, regionName str
 		requestURI = fmt.Sprintf("v2/systemsharedmemory/region/%s/status", url.QueryEscape(regionName))
 	}
 
-	resp, err := c.httpClient.Get(c.baseURL, requestURI, options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Get(ctx, c.baseURL, requestURI, options.Headers, options.QueryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -400,10 +426,6 @@ func (c *client) GetSystemSharedMemoryStatus(ctx context.Context, regionName str
 		return nil, err
 	}
 
-	if c.verbose {
-		c.logger.Println(response)
-	}
-
 	return response, nil
 }
 
@@ -420,7 +442,7 @@ func (c *client) RegisterSystemSharedMemory(ctx context.Context, name string, ke
 		return err
 	}
 
-	resp, err := c.httpClient.Post(c.baseURL, requestURI, string(requestBody), options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Post(ctx, c.baseURL, requestURI, string(requestBody), options.Headers, options.QueryParams)
 	if err != nil {
 		return err
 	}
@@ -430,10 +452,6 @@ func (c *client) RegisterSystemSharedMemory(ctx context.Context, name string, ke
 		return fmt.Errorf("failed to register system shared memory. Status code: %d", resp.StatusCode)
 	}
 
-	if c.verbose {
-		c.logger.Printf("Registered system shared memory with name '%s'\n", name)
-	}
-
 	return nil
 }
 
@@ -443,7 +461,7 @@ func (c *client) UnregisterSystemSharedMemory(ctx context.Context, name string,
 		requestURI = fmt.Sprintf("v2/systemsharedmemory/region/%s/unregister", url.QueryEscape(name))
 	}
 
-	resp, err := c.httpClient.Post(c.baseURL, requestURI, "", options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Post(ctx, c.baseURL, requestURI, "", options.Headers, options.QueryParams)
 	if err != nil {
 		return err
 	}
@@ -453,14 +471,6 @@ func (c *client) UnregisterSystemSharedMemory(ctx context.Context, name string,
 		return fmt.Errorf("failed to unregister system shared memory. Status code: %d", resp.StatusCode)
 	}
 
-	if c.verbose {
-		if name != "" {
-			c.logger.Printf("Unregistered system shared memory with name '%s'\n", name)
-		} else {
-			c.logger.Println("Unregistered all system shared memory regions")
-		}
-	}
-
 	return nil
 }
 
@@ -470,7 +480,7 @@ func (c *client) GetCUDASharedMemoryStatus(ctx context.Context, regionName strin
 		requestURI = fmt.Sprintf("v2/cudasharedmemory/region/%s/status", url.QueryEscape(regionName))
 	}
 
-	resp, err := c.httpClient.Get(c.baseURL, requestURI, options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Get(ctx, c.baseURL, requestURI, options.Headers, options.QueryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -485,10 +495,6 @@ func (c *client) GetCUDASharedMemoryStatus(ctx context.Context, regionName strin
 		return nil, err
 	}
 
-	if c.verbose {
-		c.logger.Println(response)
-	}
-
 	return response, nil
 }
 
@@ -507,7 +513,7 @@ func (c *client) RegisterCUDASharedMemory(ctx context.Context, name string, rawH
 		return err
 	}
 
-	resp, err := c.httpClient.Post(c.baseURL, requestURI, string(requestBody), options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Post(ctx, c.baseURL, requestURI, string(requestBody), options.Headers, options.QueryParams)
 	if err != nil {
 		return err
 	}
@@ -517,10 +523,6 @@ func (c *client) RegisterCUDASharedMemory(ctx context.Context, name string, rawH
 		return fmt.Errorf("failed to register CUDA shared memory. Status code: %d", resp.StatusCode)
 	}
 
-	if c.verbose {
-		c.logger.Printf("Registered CUDA shared memory with name '%s'\n", name)
-	}
-
 	return nil
 }
 
@@ -530,7 +532,7 @@ func (c *client) UnregisterCUDASharedMemory(ctx context.Context, name string, op
 		requestURI = fmt.Sprintf("v2/cudasharedmemory/region/%s/unregister", url.QueryEscape(name))
 	}
 
-	resp, err := c.httpClient.Post(c.baseURL, requestURI, "", options.Headers, options.QueryParams)
+	resp, err := c.httpClient.Post(ctx, c.baseURL, requestURI, "", options.Headers, options.QueryParams)
 	if err != nil {
 		return err
 	}
@@ -540,14 +542,6 @@ func (c *client) UnregisterCUDASharedMemory(ctx context.Context, name string, op
 		return fmt.Errorf("failed to unregister CUDA shared memory. Status code: %d", resp.StatusCode)
 	}
 
-	if c.verbose {
-		if name != "" {
-			c.logger.Printf("Unregistered CUDA shared memory with name '%s'\n", name)
-		} else {
-			c.logger.Println("Unregistered all CUDA shared memory regions")
-		}
-	}
-
 	return nil
 }
 
@@ -568,7 +562,7 @@ func (c *client) Infer(
 	}
 
 	// Make the HTTP call
-	resp, err := c.httpClient.Do(request)
+	resp, err := c.httpClient.Do(request.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -581,5 +575,9 @@ func (c *client) Infer(
 
 	// Map the response to the InferResult model
 	responseWrapper := NewResponseWrapper(resp)
-	return NewInferResult(responseWrapper, c.verbose)
+	var logger *slog.Logger
+	if c.verbose {
+		logger = c.logger
+	}
+	return NewInferResultWithLogger(responseWrapper, logger)
 }
@@ -15,7 +15,9 @@ import (
 	"go.uber.org/mock/gomock"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
@@ -44,7 +46,7 @@ func TestIsServerLive(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -67,7 +69,7 @@ func TestIsServerReady(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -90,7 +92,7 @@ func TestIsModelReady(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -117,12 +119,12 @@ func TestGetServerMetadata(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 	}
 	response, err := c.GetServerMetadata(context.Background(), &options.Options{})
 	if err != nil {
@@ -145,12 +147,12 @@ func TestGetModelMetadata(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 	}
 	response, err := c.GetModelMetadata(context.Background(), "model", "1", &options.Options{})
 	if err != nil {
@@ -173,12 +175,12 @@ func TestGetModelConfig(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 	}
 	response, err := c.GetModelConfig(context.Background(), "model", "1", &options.Options{})
 	if err != nil {
@@ -203,12 +205,12 @@ func TestGetModelRepositoryIndex(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
-	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 	}
 	response, err := c.GetModelRepositoryIndex(context.Background(), &options.Options{})
 	if err != nil {
@@ -219,6 +221,29 @@ func TestGetModelRepositoryIndex(t *testing.T) {
 	}
 }
 
+func TestGetModelRepositoryIndex_ReadyOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockHttpClient := mocks.NewMockHttpClient(ctrl)
+	mockResponse := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`[{"name":"Model1","version":"1","state":"READY"}]`)),
+	}
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), "v2/repository/index", `{"ready":true}`, gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	c := &client{
+		baseURL:    "http://localhost",
+		httpClient: mockHttpClient,
+		logger:     slog.Default(),
+	}
+	response, err := c.GetModelRepositoryIndex(context.Background(), &options.Options{ReadyOnly: true})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(response) != 1 || response[0].State != models.ModelStateReady {
+		t.Errorf("Expected 'Model1' to be %s", models.ModelStateReady)
+	}
+}
+
 func TestLoadModel(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -227,11 +252,11 @@ func TestLoadModel(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 		marshaller: marshaller.NewJSONMarshaller(),
 		verbose:    true,
 	}
@@ -249,11 +274,11 @@ func TestUnloadModel(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 		marshaller: marshaller.NewJSONMarshaller(),
 		verbose:    true,
 	}
@@ -279,12 +304,12 @@ func TestGetInferenceStatistics(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 	}
 	response, err := c.GetInferenceStatistics(context.Background(), "model", "1", &options.Options{})
 	if err != nil {
@@ -311,12 +336,12 @@ func TestGetTraceSettings(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 	}
 	response, err := c.GetTraceSettings(context.Background(), "model", &options.Options{})
 	if err != nil {
@@ -335,11 +360,11 @@ func TestUpdateLogSettings(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 		marshaller: marshaller.NewJSONMarshaller(),
 		verbose:    true,
 	}
@@ -366,12 +391,12 @@ func TestGetLogSettings(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 	}
 	response, err := c.GetLogSettings(context.Background(), &options.Options{})
 	if err != nil {
@@ -396,12 +421,12 @@ func TestGetSystemSharedMemoryStatus(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 	}
 	response, err := c.GetSystemSharedMemoryStatus(context.Background(), "", &options.Options{})
 	if err != nil {
@@ -420,11 +445,11 @@ func TestRegisterSystemSharedMemory(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 		marshaller: marshaller.NewJSONMarshaller(),
 		verbose:    true,
 	}
@@ -442,12 +467,12 @@ func TestUnregisterSystemSharedMemory(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 	}
 	err := c.UnregisterSystemSharedMemory(context.Background(), "region1", &options.Options{})
 	if err != nil {
@@ -469,12 +494,12 @@ func TestGetCUDASharedMemoryStatus(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 	}
 	response, err := c.GetCUDASharedMemoryStatus(context.Background(), "", &options.Options{})
 	if err != nil {
@@ -493,12 +518,12 @@ func TestRegisterCUDASharedMemory(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 		marshaller: marshaller.NewJSONMarshaller(),
 	}
 	err := c.RegisterCUDASharedMemory(context.Background(), "cuda_region1", []byte("handle"), 0, 1024, &options.Options{})
@@ -515,12 +540,12 @@ func TestUnregisterCUDASharedMemory(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 	}
 	err := c.UnregisterCUDASharedMemory(context.Background(), "cuda_region1", &options.Options{})
 	if err != nil {
@@ -552,7 +577,7 @@ func TestInfer(t *testing.T) {
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 		marshaller: marshaller.NewJSONMarshaller(),
 	}
 	inputs := []base.InferInput{
@@ -582,12 +607,12 @@ func TestErrorResponses(t *testing.T) {
 		StatusCode: http.StatusInternalServerError,
 		Body:       io.NopCloser(strings.NewReader("Internal Server Error")),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(errorResponse, nil)
-	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(errorResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(errorResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(errorResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 		marshaller: marshaller.NewJSONMarshaller(),
 		verbose:    true,
 	}
@@ -646,7 +671,7 @@ func TestErrorInHttpClientDo(t *testing.T) {
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 		marshaller: marshaller.NewJSONMarshaller(),
 		verbose:    true,
 	}
@@ -687,6 +712,40 @@ func TestNewClient_WithCustomHttpClientAndLogger(t *testing.T) {
 	}
 }
 
+func TestNewClient_WithInterceptors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/health/ready" || r.Header.Get("X-Intercepted") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var operations []base.Operation
+	interceptor := func(ctx context.Context, op base.Operation, req *base.Request, invoker base.Invoker) (any, error) {
+		operations = append(operations, op)
+		req.Options = &options.Options{Headers: map[string]string{"X-Intercepted": "true"}}
+		return invoker(ctx, req)
+	}
+
+	c, err := NewClient(strings.TrimPrefix(server.URL, "http://"), false, 1000, 1000, false, false, nil, nil, interceptor)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ready, err := c.IsServerReady(context.Background(), &options.Options{})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !ready {
+		t.Errorf("Expected the interceptor to add the header")
+	}
+	if len(operations) != 1 || operations[0] != base.OperationIsServerReady {
+		t.Errorf("Expected interceptor to be called for IsServerReady, got %v", operations)
+	}
+}
+
 func TestIsServerLive_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -695,7 +754,7 @@ func TestIsServerLive_Success(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), "v2/health/live", gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2/health/live", gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -714,7 +773,7 @@ func TestIsServerLive_NetworkError(t *testing.T) {
 	defer ctrl.Finish()
 	mockHttpClient := mocks.NewMockHttpClient(ctrl)
 	expectedErr := errors.New("network error")
-	mockHttpClient.EXPECT().Get(gomock.Any(), "v2/health/live", gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2/health/live", gomock.Any(), gomock.Any()).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -736,7 +795,7 @@ func TestIsServerLive_NonOKStatus(t *testing.T) {
 		StatusCode: http.StatusServiceUnavailable,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), "v2/health/live", gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2/health/live", gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -758,7 +817,7 @@ func TestIsServerReady_Success(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), "v2/health/ready", gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2/health/ready", gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -777,7 +836,7 @@ func TestIsServerReady_NetworkError(t *testing.T) {
 	defer ctrl.Finish()
 	mockHttpClient := mocks.NewMockHttpClient(ctrl)
 	expectedErr := errors.New("network error")
-	mockHttpClient.EXPECT().Get(gomock.Any(), "v2/health/ready", gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2/health/ready", gomock.Any(), gomock.Any()).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -799,7 +858,7 @@ func TestIsServerReady_NonOKStatus(t *testing.T) {
 		StatusCode: http.StatusServiceUnavailable,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), "v2/health/ready", gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2/health/ready", gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -824,7 +883,7 @@ func TestIsModelReady_Success(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -846,7 +905,7 @@ func TestIsModelReady_NetworkError(t *testing.T) {
 	modelVersion := "1"
 	requestURI := fmt.Sprintf("v2/models/%s/versions/%s/ready", url.QueryEscape(modelName), url.QueryEscape(modelVersion))
 	expectedErr := errors.New("network error")
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), gomock.Any()).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -871,7 +930,7 @@ func TestIsModelReady_NonOKStatus(t *testing.T) {
 		StatusCode: http.StatusNotFound,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -890,7 +949,7 @@ func TestGetServerMetadata_NetworkError(t *testing.T) {
 	defer ctrl.Finish()
 	mockHttpClient := mocks.NewMockHttpClient(ctrl)
 	expectedErr := errors.New("network error")
-	mockHttpClient.EXPECT().Get(gomock.Any(), "v2", gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2", gomock.Any(), gomock.Any()).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -909,7 +968,7 @@ func TestGetServerMetadata_NonOKStatus(t *testing.T) {
 		StatusCode: http.StatusInternalServerError,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), "v2", gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2", gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -929,7 +988,7 @@ func TestGetServerMetadata_InvalidJSON(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader(invalidJSON)),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), "v2", gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2", gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -948,7 +1007,7 @@ func TestGetModelMetadata_NetworkError(t *testing.T) {
 	modelVersion := "1"
 	requestURI := fmt.Sprintf("v2/models/%s/versions/%s", url.QueryEscape(modelName), url.QueryEscape(modelVersion))
 	expectedErr := errors.New("network error")
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), gomock.Any()).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -970,7 +1029,7 @@ func TestGetModelMetadata_NonOKStatus(t *testing.T) {
 		StatusCode: http.StatusNotFound,
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -993,7 +1052,7 @@ func TestGetModelMetadata_InvalidJSON(t *testing.T) {
 		StatusCode: http.StatusOK,
 		Body:       io.NopCloser(strings.NewReader(invalidJSON)),
 	}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), gomock.Any()).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1010,8 +1069,8 @@ func TestLoadModel_InvalidJSON(t *testing.T) {
 	mockHttpClient := mocks.NewMockHttpClient(ctrl)
 	modelName := "model"
 	requestURI := fmt.Sprintf("v2/repository/models/%s/load", url.QueryEscape(modelName))
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("network error"))
-	logger := log.Default()
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("network error"))
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1038,8 +1097,8 @@ func TestLoadModel_WithConfigAndFiles(t *testing.T) {
 	files := map[string][]byte{
 		"file1": []byte("content1"),
 	}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
-		func(baseURL, uri, body string, headers, params map[string]string) (*http.Response, error) {
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, baseURL, uri, body string, headers, params map[string]string) (*http.Response, error) {
 			var loadRequest map[string]any
 			json.Unmarshal([]byte(body), &loadRequest)
 			if (loadRequest["parameters"].(map[string]any)["file1"]).(string) != base64.StdEncoding.EncodeToString([]byte("content1")) {
@@ -1047,7 +1106,7 @@ func TestLoadModel_WithConfigAndFiles(t *testing.T) {
 			}
 			return mockResponse, nil
 		})
-	logger := log.Default()
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1171,7 +1230,7 @@ func TestGetModelConfig_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1185,6 +1244,30 @@ func TestGetModelConfig_Success(t *testing.T) {
 	}
 }
 
+func TestGetModelConfigJSON_KeepsEveryField(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockHttpClient := mocks.NewMockHttpClient(ctrl)
+	body := `{"name": "test_model", "max_batch_size": 8, "dynamic_batching": {"preferred_batch_size": [4, 8]}}`
+	mockResponse := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	options := &options.Options{}
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2/models/test_model/config", options.Headers, options.QueryParams).Return(mockResponse, nil)
+	c := &client{
+		baseURL:    "http://localhost",
+		httpClient: mockHttpClient,
+	}
+	config, err := c.GetModelConfigJSON(context.Background(), "test_model", "", options)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if expected := `{"name":"test_model","max_batch_size":8,"dynamic_batching":{"preferred_batch_size":[4,8]}}`; config != expected {
+		t.Errorf("Expected configuration '%s', got '%s'", expected, config)
+	}
+}
+
 func TestGetModelConfig_NetworkError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1194,7 +1277,7 @@ func TestGetModelConfig_NetworkError(t *testing.T) {
 	requestURI := fmt.Sprintf("v2/models/%s/versions/%s/config", url.QueryEscape(modelName), url.QueryEscape(modelVersion))
 	expectedErr := errors.New("network error")
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1216,7 +1299,7 @@ func TestGetModelConfig_NonOKStatus(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("Not Found")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1239,7 +1322,7 @@ func TestGetModelConfig_InvalidJSON(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(invalidJSON)),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1263,7 +1346,7 @@ func TestGetModelRepositoryIndex_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), "v2/repository/index", "", options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), "v2/repository/index", "", options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1283,7 +1366,7 @@ func TestGetModelRepositoryIndex_NetworkError(t *testing.T) {
 	mockHttpClient := mocks.NewMockHttpClient(ctrl)
 	expectedErr := errors.New("network error")
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), "v2/repository/index", "", options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), "v2/repository/index", "", options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1303,7 +1386,7 @@ func TestGetModelRepositoryIndex_NonOKStatus(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("Internal Server Error")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), "v2/repository/index", "", options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), "v2/repository/index", "", options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1324,7 +1407,7 @@ func TestGetModelRepositoryIndex_InvalidJSON(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(invalidJSON)),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), "v2/repository/index", "", options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), "v2/repository/index", "", options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1346,8 +1429,8 @@ func TestLoadModel_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1369,7 +1452,7 @@ func TestLoadModel_NetworkError(t *testing.T) {
 	requestURI := fmt.Sprintf("v2/repository/models/%s/load", url.QueryEscape(modelName))
 	expectedErr := errors.New("network error")
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1393,7 +1476,7 @@ func TestLoadModel_NonOKStatus(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("Bad Request")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1417,8 +1500,8 @@ func TestUnloadModel_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1440,7 +1523,7 @@ func TestUnloadModel_NetworkError(t *testing.T) {
 	requestURI := fmt.Sprintf("v2/repository/models/%s/unload", url.QueryEscape(modelName))
 	expectedErr := errors.New("network error")
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1464,7 +1547,7 @@ func TestUnloadModel_NonOKStatus(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("Internal Server Error")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1496,8 +1579,8 @@ func TestGetTraceSettings_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1520,7 +1603,7 @@ func TestGetTraceSettings_NetworkError(t *testing.T) {
 	requestURI := "v2/trace/setting"
 	expectedErr := errors.New("network error")
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		marshaller: marshaller.NewJSONMarshaller(),
@@ -1543,7 +1626,7 @@ func TestGetTraceSettings_NonOKStatus(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("Forbidden")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1565,7 +1648,7 @@ func TestGetTraceSettings_InvalidJSON(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(invalidJSON)),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1594,8 +1677,8 @@ func TestUpdateLogSettings_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), "v2/logging", string(bodyBytes), options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), "v2/logging", string(bodyBytes), options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		logger:     logger,
@@ -1616,7 +1699,7 @@ func TestUpdateLogSettings_NetworkError(t *testing.T) {
 	requestBody := models.LogSettingsRequest{}
 	expectedErr := errors.New("network error")
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), "v2/logging", gomock.Any(), options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), "v2/logging", gomock.Any(), options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1639,7 +1722,7 @@ func TestUpdateLogSettings_NonOKStatus(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("Unauthorized")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), "v2/logging", gomock.Any(), options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), "v2/logging", gomock.Any(), options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1670,8 +1753,8 @@ func TestGetLogSettings_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), "v2/logging", options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2/logging", options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		logger:     logger,
@@ -1694,7 +1777,7 @@ func TestGetLogSettings_NetworkError(t *testing.T) {
 	mockHttpClient := mocks.NewMockHttpClient(ctrl)
 	expectedErr := errors.New("network error")
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), "v2/logging", options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2/logging", options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1716,7 +1799,7 @@ func TestGetLogSettings_NonOKStatus(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("Forbidden")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), "v2/logging", options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2/logging", options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1739,7 +1822,7 @@ func TestGetLogSettings_InvalidJSON(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(invalidJSON)),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), "v2/logging", options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), "v2/logging", options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1821,8 +1904,8 @@ func TestGetSystemSharedMemoryStatus_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1853,13 +1936,13 @@ func TestGetSystemSharedMemoryStatus_NoRegion(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
 		marshaller: marshaller.NewJSONMarshaller(),
 		verbose:    true,
-		logger:     log.Default(),
+		logger:     slog.Default(),
 	}
 	response, err := c.GetSystemSharedMemoryStatus(context.Background(), "", options)
 	if err != nil {
@@ -1877,7 +1960,7 @@ func TestGetSystemSharedMemoryStatus_NetworkError(t *testing.T) {
 	requestURI := "v2/systemsharedmemory/status"
 	expectedErr := errors.New("network error")
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1900,7 +1983,7 @@ func TestGetSystemSharedMemoryStatus_NonOKStatus(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("Internal Server Error")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1924,7 +2007,7 @@ func TestGetSystemSharedMemoryStatus_InvalidJSON(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(invalidJSON)),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -1949,8 +2032,8 @@ func TestRegisterSystemSharedMemory_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, `{"key":"key1","offset":0,"byte_size":1024}`, options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, `{"key":"key1","offset":0,"byte_size":1024}`, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		marshaller: mockMarshaller,
@@ -1988,7 +2071,7 @@ func TestRegisterSystemSharedMemory_NetworkError(t *testing.T) {
 	expectedErr := errors.New("network error")
 	requestURI := "v2/systemsharedmemory/region/region1/register"
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		marshaller: mockMarshaller,
@@ -2012,7 +2095,7 @@ func TestRegisterSystemSharedMemory_NonOKStatus(t *testing.T) {
 	}
 	requestURI := "v2/systemsharedmemory/region/region1/register"
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, gomock.Any(), options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		marshaller: mockMarshaller,
@@ -2034,8 +2117,8 @@ func TestUnregisterSystemSharedMemory_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2059,8 +2142,8 @@ func TestUnregisterSystemSharedMemory_SuccessWithoutName(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2081,7 +2164,7 @@ func TestUnregisterSystemSharedMemory_NetworkError(t *testing.T) {
 	expectedErr := errors.New("network error")
 	requestURI := "v2/systemsharedmemory/unregister"
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2104,7 +2187,7 @@ func TestUnregisterSystemSharedMemory_NonOKStatus(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("Forbidden")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2132,8 +2215,8 @@ func TestGetCUDASharedMemoryStatus_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2157,7 +2240,7 @@ func TestGetCUDASharedMemoryStatus_NetworkError(t *testing.T) {
 	requestURI := "v2/cudasharedmemory/status"
 	expectedErr := errors.New("network error")
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2180,7 +2263,7 @@ func TestGetCUDASharedMemoryStatus_NonOKStatus(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("Not Found")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2204,7 +2287,7 @@ func TestGetCUDASharedMemoryStatus_InvalidJSON(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(invalidJSON)),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2232,8 +2315,8 @@ func TestRegisterCUDASharedMemory_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, expectedRequest, options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, expectedRequest, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		marshaller: mockMarshaller,
@@ -2274,7 +2357,7 @@ func TestRegisterCUDASharedMemory_NetworkError(t *testing.T) {
 	expectedErr := errors.New("network error")
 	requestURI := "v2/cudasharedmemory/region/cuda_region1/register"
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, expectedRequest, options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, expectedRequest, options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		marshaller: mockMarshaller,
@@ -2301,7 +2384,7 @@ func TestRegisterCUDASharedMemory_NonOKStatus(t *testing.T) {
 	}
 	requestURI := "v2/cudasharedmemory/region/cuda_region1/register"
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, expectedRequest, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, expectedRequest, options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		marshaller: mockMarshaller,
@@ -2323,8 +2406,8 @@ func TestUnregisterCUDASharedMemory_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2348,8 +2431,8 @@ func TestUnregisterCUDASharedMemory_SuccessWithoutName(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2370,7 +2453,7 @@ func TestUnregisterCUDASharedMemory_NetworkError(t *testing.T) {
 	expectedErr := errors.New("network error")
 	requestURI := "v2/cudasharedmemory/unregister"
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2393,7 +2476,7 @@ func TestUnregisterCUDASharedMemory_NonOKStatus(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("Unauthorized")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Post(gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Post(gomock.Any(), gomock.Any(), requestURI, "", options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2424,8 +2507,8 @@ func TestGetInferenceStatistics_Success(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(string(bodyBytes))),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
-	logger := log.Default()
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	logger := slog.Default()
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2450,7 +2533,7 @@ func TestGetInferenceStatistics_NetworkError(t *testing.T) {
 	requestURI := fmt.Sprintf("v2/models/%s/stats", url.QueryEscape(modelName))
 	expectedErr := errors.New("network error")
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(nil, expectedErr)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(nil, expectedErr)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2474,7 +2557,7 @@ func TestGetInferenceStatistics_NonOKStatus(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader("Service Unavailable")),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2499,7 +2582,7 @@ func TestGetInferenceStatistics_InvalidJSON(t *testing.T) {
 		Body:       io.NopCloser(strings.NewReader(invalidJSON)),
 	}
 	options := &options.Options{}
-	mockHttpClient.EXPECT().Get(gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
+	mockHttpClient.EXPECT().Get(gomock.Any(), gomock.Any(), requestURI, options.Headers, options.QueryParams).Return(mockResponse, nil)
 	c := &client{
 		baseURL:    "http://localhost",
 		httpClient: mockHttpClient,
@@ -2511,3 +2594,89 @@ func TestGetInferenceStatistics_InvalidJSON(t *testing.T) {
 		t.Errorf("Expected error due to invalid JSON")
 	}
 }
+
+func TestNew_WithOptions(t *testing.T) {
+	var userAgent string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, err := New(strings.TrimPrefix(server.URL, "https://"),
+		base.WithTLSConfig(&tls.Config{InsecureSkipVerify: true}),
+		base.WithConnectionTimeout(time.Second),
+		base.WithNetworkTimeout(5*time.Second),
+		base.WithUserAgent("triton-test"),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	live, err := c.IsServerLive(context.Background(), &options.Options{})
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !live {
+		t.Errorf("Expected server to be live")
+	}
+	if userAgent != "triton-test" {
+		t.Errorf("Expected user agent triton-test, got %s", userAgent)
+	}
+}
+
+func TestNew_NetworkTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, base.WithNetworkTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	start := time.Now()
+	_, err = c.Infer(context.Background(), "model", "", nil, nil, &options.InferOptions{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("Expected the call to be cancelled by the network timeout")
+	}
+}
+
+func TestNew_NetworkTimeoutWithHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, base.WithHTTPClient(&http.Client{}), base.WithNetworkTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	start := time.Now()
+	_, err = c.IsServerLive(context.Background(), &options.Options{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("Expected the call to be cancelled by the network timeout")
+	}
+}
+
+func TestNew_EmptyURL(t *testing.T) {
+	_, err := New("")
+	if err == nil {
+		t.Errorf("Expected error for empty URL")
+	}
+}
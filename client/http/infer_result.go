@@ -9,6 +9,7 @@ import (
 	"github.com/Trendyol/go-triton-client/base"
 	"github.com/Trendyol/go-triton-client/converter"
 	"io"
+	"log/slog"
 	"strconv"
 )
 
@@ -18,7 +19,18 @@ type InferResult struct {
 }
 
 // NewInferResult creates a new HTTP InferResult instance.
+// When verbose is true, a debug record describing the response is sent to slog.Default().
 func NewInferResult(response base.ResponseWrapper, verbose bool) (base.InferResult, error) {
+	var logger *slog.Logger
+	if verbose {
+		logger = slog.Default()
+	}
+	return NewInferResultWithLogger(response, logger)
+}
+
+// NewInferResultWithLogger creates a new HTTP InferResult instance.
+// When logger is not nil, a debug record describes the response without its tensor data.
+func NewInferResultWithLogger(response base.ResponseWrapper, logger *slog.Logger) (base.InferResult, error) {
 	headerLength := response.GetHeader("Inference-Header-Content-Length")
 
 	var decompressedData []byte
@@ -55,13 +67,11 @@ func NewInferResult(response base.ResponseWrapper, verbose bool) (base.InferResu
 
 	if headerLength == "" {
 		content := decompressedData
-		if verbose {
-			fmt.Println(string(content))
-		}
 		var result base.InferOutputs
 		if err := json.Unmarshal(content, &result); err != nil {
 			return nil, err
 		}
+		logInferResult(logger, result, len(content), 0)
 
 		return &InferResult{
 			BaseInferResult: &base.BaseInferResult{
@@ -75,9 +85,6 @@ func NewInferResult(response base.ResponseWrapper, verbose bool) (base.InferResu
 		return nil, err
 	}
 	content := decompressedData[:headerLengthInt]
-	if verbose {
-		fmt.Println(string(content))
-	}
 
 	var result base.InferOutputs
 	if err := json.Unmarshal(content, &result); err != nil {
@@ -85,6 +92,7 @@ func NewInferResult(response base.ResponseWrapper, verbose bool) (base.InferResu
 	}
 
 	buffer := decompressedData[headerLengthInt:]
+	logInferResult(logger, result, len(content), len(buffer))
 	outputNameToBufferMap := make(map[string]int)
 	bufferIndex := 0
 	for _, output := range result.Outputs {
@@ -104,6 +112,27 @@ func NewInferResult(response base.ResponseWrapper, verbose bool) (base.InferResu
 	}, nil
 }
 
+// logInferResult emits a debug record with the model, outputs and sizes of an inference response.
+func logInferResult(logger *slog.Logger, result base.InferOutputs, headerSize int, bufferSize int) {
+	if logger == nil {
+		return
+	}
+
+	outputs := make([]string, len(result.Outputs))
+	for i, output := range result.Outputs {
+		outputs[i] = output.GetName()
+	}
+
+	logger.Debug("parsed inference response",
+		slog.String("protocol", string(base.ProtocolHTTP)),
+		slog.String("model_name", result.ModelName),
+		slog.String("model_version", result.ModelVersion),
+		slog.Any("outputs", outputs),
+		slog.Int("header_bytes", headerSize),
+		slog.Int("binary_bytes", bufferSize),
+	)
+}
+
 func (r *InferResult) AsInt8Slice(name string) ([]int8, error) {
 	return getAsSlice[int8](name, r, converter.DeserializeInt8Tensor)
 }
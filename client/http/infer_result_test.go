@@ -5,7 +5,9 @@ import (
 	"compress/gzip"
 	"compress/zlib"
 	"errors"
+	"log/slog"
 	"strconv"
+	"strings"
 	"testing"
 
 	"go.uber.org/mock/gomock"
@@ -39,7 +41,7 @@ func TestNewInferResult_WithHeaderLength(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(body, nil)
 
-	result, err := NewInferResult(mockResponseWrapper, true)
+	result, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -48,6 +50,33 @@ func TestNewInferResult_WithHeaderLength(t *testing.T) {
 	}
 }
 
+func TestNewInferResult_LogsSizesWithoutPayload(t *testing.T) {
+	header := []byte(`{"model_name":"test_model","model_version":"1","outputs":[{"name":"output0","datatype":"BYTES","shape":[1],"parameters":{"binary_data_size":10}}]}`)
+	body := append(append([]byte{}, header...), []byte("\x06\x00\x00\x00secret")...)
+	mockController := gomock.NewController(t)
+	defer mockController.Finish()
+	mockResponseWrapper := mocks.NewMockResponseWrapper(mockController)
+	mockResponseWrapper.EXPECT().GetHeader("Inference-Header-Content-Length").Return(strconv.Itoa(len(header)))
+	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
+	mockResponseWrapper.EXPECT().GetBody().Return(body, nil)
+
+	var buffer bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	_, err := NewInferResultWithLogger(mockResponseWrapper, logger)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	output := buffer.String()
+	if !strings.Contains(output, "model_name=test_model") || !strings.Contains(output, "binary_bytes=10") {
+		t.Errorf("Expected model name and sizes in log record, got %s", output)
+	}
+	if strings.Contains(output, "secret") {
+		t.Errorf("Expected tensor data to be left out of the log record, got %s", output)
+	}
+}
+
 func TestNewInferResult_UnmarshalError(t *testing.T) {
 	body := []byte(`{"invalid_json"`)
 	mockController := gomock.NewController(t)
@@ -57,7 +86,7 @@ func TestNewInferResult_UnmarshalError(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(body, nil)
 
-	_, err := NewInferResult(mockResponseWrapper, true)
+	_, err := NewInferResult(mockResponseWrapper, false)
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -76,7 +105,7 @@ func TestNewInferResult_Gzip(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(body, nil)
 
-	result, err := NewInferResult(mockResponseWrapper, true)
+	result, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -98,7 +127,7 @@ func TestNewInferResult_Deflate(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(body, nil)
 
-	result, err := NewInferResult(mockResponseWrapper, true)
+	result, err := NewInferResult(mockResponseWrapper, false)
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -115,7 +144,7 @@ func TestNewInferResult_InvalidContentEncoding(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("invalid")
 	mockResponseWrapper.EXPECT().GetBody().Return([]byte(`data`), nil)
 
-	_, err := NewInferResult(mockResponseWrapper, true)
+	_, err := NewInferResult(mockResponseWrapper, false)
 	if err == nil {
 		t.Errorf("Expected error")
 	}
@@ -130,7 +159,7 @@ func TestInferResult_AsFloat32Slice_HasBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, 16)...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsFloat32Slice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -149,7 +178,7 @@ func TestInferResult_AsFloat16Slice_HasBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, 16)...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsFloat16Slice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -168,7 +197,7 @@ func TestInferResult_AsFloat64Slice_HasBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, 16)...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsFloat64Slice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -187,7 +216,7 @@ func TestInferResult_AsInt8Slice_HasBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, int8(16))...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsInt8Slice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -206,7 +235,7 @@ func TestInferResult_AsInt16Slice_HasBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, int16(16))...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsInt16Slice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -225,7 +254,7 @@ func TestInferResult_AsInt32Slice_HasBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, int32(16))...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsInt32Slice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -244,7 +273,7 @@ func TestInferResult_AsInt64Slice_HasBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, int64(16))...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsInt64Slice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -263,7 +292,7 @@ func TestInferResult_AsUint8Slice_HasBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, uint8(16))...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsUint8Slice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -282,7 +311,7 @@ func TestInferResult_AsUint16Slice_HasBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, uint16(16))...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsUint16Slice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -301,7 +330,7 @@ func TestInferResult_AsUint32Slice_HasBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, uint32(16))...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsUint32Slice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -320,7 +349,7 @@ func TestInferResult_AsUint64Slice_HasBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, uint64(16))...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsUint64Slice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -339,7 +368,7 @@ func TestInferResult_AsBoolSlice_HasBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, 1)...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsBoolSlice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -358,7 +387,7 @@ func TestInferResult_AsByteSlice_HasBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, 16)...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsByteSlice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -377,7 +406,7 @@ func TestInferResult_AsBytesSlice_HasNotBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, 16)...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsBytesSlice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -400,7 +429,7 @@ func TestInferResult_AsFloat32Slice_NoBinaryData(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(body, nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsFloat32Slice("output0")
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -419,7 +448,7 @@ func TestInferResult_AsFloat32Slice_NoBinaryData_ConvertError(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(body, nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	data, err := result.AsFloat64Slice("output0")
 	if err == nil {
 		t.Errorf("Expected error, got nil")
@@ -438,7 +467,7 @@ func TestInferResult_GetOutput_NotFound(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(body, nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	_, err := result.GetOutput("output0")
 	if err == nil || err.Error() != "output output0 not found" {
 		t.Errorf("Expected error 'output output0 not found', got %v", err)
@@ -454,7 +483,7 @@ func TestInferResult_AsSlice_NotFound(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(append(body, make([]byte, 16)...), nil)
 
-	result, _ := NewInferResult(mockResponseWrapper, true)
+	result, _ := NewInferResult(mockResponseWrapper, false)
 	_, err := result.AsFloat32Slice("output1")
 	if err == nil || err.Error() != "output output1 not found" {
 		t.Errorf("Expected error 'output output1 not found', got %v", err)
@@ -469,7 +498,7 @@ func TestNewInferResult_BodyError(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return(nil, errors.New("body error"))
 
-	_, err := NewInferResult(mockResponseWrapper, true)
+	_, err := NewInferResult(mockResponseWrapper, false)
 	if err == nil || err.Error() != "body error" {
 		t.Errorf("Expected error 'body error', got %v", err)
 	}
@@ -488,7 +517,7 @@ func TestNewInferResult_Deflate_ReadAllError(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("deflate")
 	mockResponseWrapper.EXPECT().GetBody().Return(faultyData, nil)
 
-	_, err := NewInferResult(mockResponseWrapper, true)
+	_, err := NewInferResult(mockResponseWrapper, false)
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -507,7 +536,7 @@ func TestNewInferResult_Gzip_ReadAllError(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("gzip")
 	mockResponseWrapper.EXPECT().GetBody().Return(faultyData, nil)
 
-	_, err := NewInferResult(mockResponseWrapper, true)
+	_, err := NewInferResult(mockResponseWrapper, false)
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -521,7 +550,7 @@ func TestNewInferResult_GzipReaderError(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("gzip")
 	mockResponseWrapper.EXPECT().GetBody().Return([]byte("invalid gzip data"), nil)
 
-	_, err := NewInferResult(mockResponseWrapper, true)
+	_, err := NewInferResult(mockResponseWrapper, false)
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -535,7 +564,7 @@ func TestNewInferResult_ZlibReaderError(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("deflate")
 	mockResponseWrapper.EXPECT().GetBody().Return([]byte("invalid deflate data"), nil)
 
-	_, err := NewInferResult(mockResponseWrapper, true)
+	_, err := NewInferResult(mockResponseWrapper, false)
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -549,7 +578,7 @@ func TestNewInferResult_InvalidHeaderLength(t *testing.T) {
 	mockResponseWrapper.EXPECT().GetHeader("Content-Encoding").Return("")
 	mockResponseWrapper.EXPECT().GetBody().Return([]byte("data"), nil)
 
-	_, err := NewInferResult(mockResponseWrapper, true)
+	_, err := NewInferResult(mockResponseWrapper, false)
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -76,6 +76,14 @@ func (w *RequestWrapper) PrepareRequest() (*http.Request, error) {
 		return nil, err
 	}
 
+	if len(w.Options.QueryParams) > 0 {
+		query := req.URL.Query()
+		for key, value := range w.Options.QueryParams {
+			query.Add(key, value)
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
@@ -138,6 +146,10 @@ func (w *RequestWrapper) getInferenceRequest() ([]byte, *int, error) {
 // and includes the length of the JSON portion of the request if applicable.
 func (w *RequestWrapper) prepareHeaders(jsonSize *int) map[string]string {
 	headers := make(map[string]string)
+	for key, value := range w.Options.Headers {
+		headers[key] = value
+	}
+
 	if w.Options.RequestCompressionAlgorithm != nil && *w.Options.RequestCompressionAlgorithm != "" {
 		switch *w.Options.RequestCompressionAlgorithm {
 		case "gzip":
@@ -643,6 +643,35 @@ func TestPrepareRequest_SetHeaders(t *testing.T) {
 	}
 }
 
+func TestPrepareRequest_CustomHeadersAndQueryParams(t *testing.T) {
+	opts := &options.InferOptions{
+		Headers:     map[string]string{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+		QueryParams: map[string]string{"tenant": "search"},
+	}
+
+	wrapper := NewRequestWrapper(
+		"http://localhost:8000",
+		"test_model",
+		"",
+		[]base.InferInput{},
+		[]base.InferOutput{},
+		marshaller.NewJSONMarshaller(),
+		opts,
+	)
+
+	req, err := wrapper.PrepareRequest()
+	if err != nil {
+		t.Fatalf("PrepareRequest returned error: %v", err)
+	}
+
+	if req.Header.Get("traceparent") != opts.Headers["traceparent"] {
+		t.Errorf("Expected traceparent header to be set, got %s", req.Header.Get("traceparent"))
+	}
+	if req.URL.Query().Get("tenant") != "search" {
+		t.Errorf("Expected tenant query parameter to be set, got %s", req.URL.RawQuery)
+	}
+}
+
 func TestGetInferenceRequest_JSONMarshalError(t *testing.T) {
 	invalidValue := make(chan int)
 	wrapper := NewRequestWrapper(
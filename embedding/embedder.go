@@ -0,0 +1,390 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/base"
+	tritongrpc "github.com/Trendyol/go-triton-client/client/grpc"
+	tritonhttp "github.com/Trendyol/go-triton-client/client/http"
+	"github.com/Trendyol/go-triton-client/converter"
+	"github.com/Trendyol/go-triton-client/options"
+	"github.com/Trendyol/go-triton-client/postprocess"
+	"github.com/Trendyol/go-triton-client/tokenizer/models"
+	tokenizerOptions "github.com/Trendyol/go-triton-client/tokenizer/options"
+)
+
+// Encoder tokenizes the texts to embed. It is implemented by tokenizer.Tokenizer.
+type Encoder interface {
+	Encode(text string, encodeOptions *tokenizerOptions.EncodeOptions) *models.EncodeResponse
+}
+
+// PoolingNone takes the output as it is, for models returning pooled embeddings of shape [batch, dimension].
+const PoolingNone postprocess.PoolingStrategy = "none"
+
+// Settings configures an Embedder.
+type Settings struct {
+	// ModelName is the name of the embedding model. Required.
+	ModelName string
+	// ModelVersion is the version of the model. Defaults to the version selected by the server.
+	ModelVersion string
+	// InputIDsName is the name of the token IDs input. Defaults to "input_ids".
+	InputIDsName string
+	// AttentionMaskName is the name of the attention mask input. Defaults to "attention_mask".
+	AttentionMaskName string
+	// TokenTypeIDsName is the name of the token type IDs input. Defaults to "token_type_ids".
+	TokenTypeIDsName string
+	// SkipTokenTypeIDs omits the token type IDs input, for models such as RoBERTa that don't take it.
+	SkipTokenTypeIDs bool
+	// InputDatatype is the datatype of the inputs, INT64 or INT32. Defaults to INT64.
+	InputDatatype string
+	// OutputName is the name of the output holding the embeddings. Defaults to "logits".
+	OutputName string
+	// OutputDatatype is the datatype of the output, FP16, FP32 or FP64. Defaults to the datatype
+	// reported in the response.
+	OutputDatatype string
+	// Pooling pools the token embeddings, ignoring the padding. Defaults to postprocess.PoolingMean.
+	Pooling postprocess.PoolingStrategy
+	// Normalize scales the embeddings to unit L2 norm, so that their dot product is their cosine similarity.
+	Normalize bool
+	// BatchSize is the number of texts sent per inference. Defaults to 32.
+	BatchSize int
+	// PadID pads the token IDs of the texts shorter than the longest text of a batch.
+	PadID uint32
+	// MaxLength truncates the texts to at most MaxLength tokens, special tokens included, such as the max
+	// sequence length of the model. The text tokens are removed from the end, keeping the special tokens
+	// marked by the special tokens mask of the encoder. Zero sends the texts as the encoder returns them.
+	MaxLength int
+	// Options are sent with every inference.
+	Options *options.InferOptions
+	// Protocol selects the kind of inputs and outputs. Defaults to the protocol of the client,
+	// and must be set with clients not exposing it, such as decorated clients.
+	Protocol base.Protocol
+}
+
+// Embedder computes the embeddings of texts with a model served by Triton.
+type Embedder interface {
+	// Embed returns the embedding of every text, in the order of texts. The texts are sent in batches of
+	// Settings.BatchSize, and the first failing batch fails the whole call.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+type embedder struct {
+	encoder        Encoder
+	client         base.Client
+	postprocessor  postprocess.PostprocessManager
+	settings       Settings
+	newInferInput  func(name string, datatype string, shape []int64) base.InferInput
+	newInferOutput func(name string) base.InferOutput
+}
+
+// NewEmbedder creates an Embedder tokenizing with encoder, inferring with client and pooling with postprocessor.
+// A nil postprocessor defaults to postprocess.NewPostprocessManager.
+func NewEmbedder(encoder Encoder, client base.Client, postprocessor postprocess.PostprocessManager, settings Settings) (Embedder, error) {
+	if encoder == nil || client == nil {
+		return nil, fmt.Errorf("an encoder and a client are required")
+	}
+	if settings.ModelName == "" {
+		return nil, fmt.Errorf("a model name is required")
+	}
+	settings = withDefaults(settings)
+	if settings.InputDatatype != "INT64" && settings.InputDatatype != "INT32" {
+		return nil, fmt.Errorf("unsupported input datatype '%s', expected INT64 or INT32", settings.InputDatatype)
+	}
+	if settings.Pooling != PoolingNone {
+		pooling, err := postprocess.ParsePoolingStrategy(string(settings.Pooling))
+		if err != nil {
+			return nil, err
+		}
+		settings.Pooling = pooling
+	}
+	if settings.BatchSize < 0 {
+		return nil, fmt.Errorf("batch size can't be negative")
+	}
+	if settings.MaxLength < 0 {
+		return nil, fmt.Errorf("max length can't be negative")
+	}
+	if postprocessor == nil {
+		postprocessor = postprocess.NewPostprocessManager()
+	}
+
+	e := &embedder{encoder: encoder, client: client, postprocessor: postprocessor, settings: settings}
+	protocol := settings.Protocol
+	if protocol == "" {
+		p, ok := client.(interface{ Protocol() base.Protocol })
+		if !ok {
+			return nil, fmt.Errorf("cannot determine the protocol of %T, set Settings.Protocol", client)
+		}
+		protocol = p.Protocol()
+	}
+	switch protocol {
+	case base.ProtocolHTTP:
+		e.newInferInput = func(name string, datatype string, shape []int64) base.InferInput {
+			return tritonhttp.NewInferInput(name, datatype, shape, nil)
+		}
+		e.newInferOutput = func(name string) base.InferOutput {
+			return tritonhttp.NewInferOutput(name, map[string]any{"binary_data": true})
+		}
+	case base.ProtocolGRPC:
+		e.newInferInput = func(name string, datatype string, shape []int64) base.InferInput {
+			return tritongrpc.NewInferInput(name, datatype, shape, nil)
+		}
+		e.newInferOutput = func(name string) base.InferOutput {
+			return tritongrpc.NewInferOutput(name, nil)
+		}
+	default:
+		return nil, fmt.Errorf("unknown protocol '%s'", protocol)
+	}
+	return e, nil
+}
+
+func withDefaults(settings Settings) Settings {
+	if settings.InputIDsName == "" {
+		settings.InputIDsName = "input_ids"
+	}
+	if settings.AttentionMaskName == "" {
+		settings.AttentionMaskName = "attention_mask"
+	}
+	if settings.TokenTypeIDsName == "" {
+		settings.TokenTypeIDsName = "token_type_ids"
+	}
+	if settings.InputDatatype == "" {
+		settings.InputDatatype = "INT64"
+	}
+	if settings.OutputName == "" {
+		settings.OutputName = "logits"
+	}
+	if settings.Pooling == "" {
+		settings.Pooling = postprocess.PoolingMean
+	}
+	if settings.BatchSize == 0 {
+		settings.BatchSize = 32
+	}
+	return settings
+}
+
+func (e *embedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += e.settings.BatchSize {
+		end := min(start+e.settings.BatchSize, len(texts))
+		batch, err := e.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("embedding texts %d to %d: %w", start, end-1, err)
+		}
+		embeddings = append(embeddings, batch...)
+	}
+	return embeddings, nil
+}
+
+type inputTensor struct {
+	name string
+	data []int64
+}
+
+// embedBatch tokenizes texts, truncates them to the max length, pads them to the longest one and infers
+// their embeddings in one request.
+func (e *embedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	encodeOptions := &tokenizerOptions.EncodeOptions{
+		EncodeSpecialTokens:     true,
+		ReturnTypeIDs:           true,
+		ReturnAttentionMask:     true,
+		ReturnSpecialTokensMask: true,
+	}
+	encodings := make([]*models.EncodeResponse, len(texts))
+	length := 0
+	for i, text := range texts {
+		encodings[i] = e.encoder.Encode(text, encodeOptions)
+		if e.settings.MaxLength > 0 {
+			if err := truncate(encodings[i], e.settings.MaxLength); err != nil {
+				return nil, fmt.Errorf("text %d: %w", i, err)
+			}
+		}
+		length = max(length, len(encodings[i].IDs))
+	}
+
+	ids := make([]int64, 0, len(texts)*length)
+	typeIDs := make([]int64, 0, len(texts)*length)
+	attentionMask := make([][]int64, len(texts))
+	for i, encoding := range encodings {
+		attentionMask[i] = make([]int64, length)
+		for j := 0; j < length; j++ {
+			if j >= len(encoding.IDs) {
+				ids = append(ids, int64(e.settings.PadID))
+				typeIDs = append(typeIDs, 0)
+				continue
+			}
+			ids = append(ids, int64(encoding.IDs[j]))
+			typeIDs = append(typeIDs, int64(valueAt(encoding.TypeIDs, j, 0)))
+			attentionMask[i][j] = int64(valueAt(encoding.AttentionMask, j, 1))
+		}
+	}
+
+	shape := []int64{int64(len(texts)), int64(length)}
+	tensors := []inputTensor{
+		{e.settings.InputIDsName, ids},
+		{e.settings.AttentionMaskName, flatten(attentionMask)},
+	}
+	if !e.settings.SkipTokenTypeIDs {
+		tensors = append(tensors, inputTensor{e.settings.TokenTypeIDsName, typeIDs})
+	}
+	inputs := make([]base.InferInput, 0, len(tensors))
+	for _, tensor := range tensors {
+		input := e.newInferInput(tensor.name, e.settings.InputDatatype, shape)
+		var data any = tensor.data
+		if e.settings.InputDatatype == "INT32" {
+			data = toInt32(tensor.data)
+		}
+		if err := input.SetData(data, true); err != nil {
+			return nil, fmt.Errorf("setting input '%s': %w", tensor.name, err)
+		}
+		inputs = append(inputs, input)
+	}
+
+	result, err := e.client.Infer(ctx, e.settings.ModelName, e.settings.ModelVersion, inputs,
+		[]base.InferOutput{e.newInferOutput(e.settings.OutputName)}, e.settings.Options)
+	if err != nil {
+		return nil, err
+	}
+	embeddings, err := e.pool(result, attentionMask)
+	if err != nil {
+		return nil, err
+	}
+	if len(embeddings) != len(texts) {
+		return nil, fmt.Errorf("got %d embeddings for %d texts", len(embeddings), len(texts))
+	}
+	if e.settings.Normalize {
+		embeddings = postprocess.NormalizeL2(embeddings)
+	}
+	return embeddings, nil
+}
+
+// pool reads the output in its datatype and pools it into one embedding per text.
+func (e *embedder) pool(result base.InferResult, attentionMask [][]int64) ([][]float32, error) {
+	name := e.settings.OutputName
+	datatype := e.settings.OutputDatatype
+	if datatype == "" {
+		output, err := result.GetOutput(name)
+		if err != nil {
+			return nil, err
+		}
+		datatype = output.GetDatatype()
+	}
+	shape, err := result.GetShape(name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch datatype {
+	case "FP32":
+		data, err := result.AsFloat32Slice(name)
+		if err != nil {
+			return nil, err
+		}
+		return pool(data, shape, attentionMask, e.settings.Pooling, e.postprocessor.MeanPoolingFloat32Slice3D)
+	case "FP16", "FP64":
+		var data []float64
+		if datatype == "FP16" {
+			data, err = result.AsFloat16Slice(name)
+		} else {
+			data, err = result.AsFloat64Slice(name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		pooled, err := pool(data, shape, attentionMask, e.settings.Pooling, e.postprocessor.MeanPoolingFloat64Slice3D)
+		if err != nil {
+			return nil, err
+		}
+		return e.postprocessor.Float64ToFloat32Slice2D(pooled), nil
+	default:
+		return nil, fmt.Errorf("unsupported output datatype '%s', expected FP16, FP32 or FP64", datatype)
+	}
+}
+
+// pool pools the output with the mean pooling of the postprocessor, or with postprocess.Pool for the other strategies.
+func pool[T float32 | float64](data []T, shape []int64, attentionMask [][]int64, pooling postprocess.PoolingStrategy,
+	meanPooling func([][][]T, [][]int64) ([][]T, error)) ([][]T, error) {
+	if pooling == PoolingNone {
+		return converter.Reshape2D(data, shape)
+	}
+	tokenEmbeddings, err := converter.Reshape3D(data, shape)
+	if err != nil {
+		return nil, err
+	}
+	if pooling == postprocess.PoolingMean {
+		return meanPooling(tokenEmbeddings, attentionMask)
+	}
+	return postprocess.Pool(pooling, tokenEmbeddings, attentionMask)
+}
+
+// truncate removes the last text tokens of an encoding longer than maxLength, keeping the special tokens
+// added around the text. Encoders not returning the special tokens mask have their last tokens removed.
+func truncate(encoding *models.EncodeResponse, maxLength int) error {
+	if len(encoding.IDs) <= maxLength {
+		return nil
+	}
+	isSpecial := func(i int) bool {
+		return valueAt(encoding.SpecialTokensMask, i, 0) == 1
+	}
+
+	specialTokens := 0
+	for i := range encoding.IDs {
+		if isSpecial(i) {
+			specialTokens++
+		}
+	}
+	if specialTokens > maxLength {
+		return fmt.Errorf("max length %d is shorter than the %d special tokens", maxLength, specialTokens)
+	}
+
+	keepText := maxLength - specialTokens
+	var indices []int
+	for i := range encoding.IDs {
+		if isSpecial(i) {
+			indices = append(indices, i)
+		} else if keepText > 0 {
+			indices = append(indices, i)
+			keepText--
+		}
+	}
+	encoding.IDs = pick(encoding.IDs, indices)
+	encoding.TypeIDs = pick(encoding.TypeIDs, indices)
+	encoding.AttentionMask = pick(encoding.AttentionMask, indices)
+	encoding.SpecialTokensMask = pick(encoding.SpecialTokensMask, indices)
+	return nil
+}
+
+// pick returns the values at the given indices, or values itself when the attribute was not returned.
+func pick(values []uint32, indices []int) []uint32 {
+	if len(values) == 0 {
+		return values
+	}
+	picked := make([]uint32, len(indices))
+	for i, index := range indices {
+		picked[i] = valueAt(values, index, 0)
+	}
+	return picked
+}
+
+// valueAt returns values[i], or fallback when the tokenizer didn't return the attribute.
+func valueAt(values []uint32, i int, fallback uint32) uint32 {
+	if i < len(values) {
+		return values[i]
+	}
+	return fallback
+}
+
+func flatten(rows [][]int64) []int64 {
+	var flat []int64
+	for _, row := range rows {
+		flat = append(flat, row...)
+	}
+	return flat
+}
+
+func toInt32(values []int64) []int32 {
+	converted := make([]int32, len(values))
+	for i, value := range values {
+		converted[i] = int32(value)
+	}
+	return converted
+}
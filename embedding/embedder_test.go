@@ -0,0 +1,235 @@
+package embedding
+
+import (
+	"context"
+	"errors"
+	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/converter"
+	"github.com/Trendyol/go-triton-client/postprocess"
+	"github.com/Trendyol/go-triton-client/tokenizer/models"
+	tokenizerOptions "github.com/Trendyol/go-triton-client/tokenizer/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"testing"
+)
+
+// wordEncoder encodes every word of a text as its length, between the IDs 101 and 102.
+type wordEncoder struct{}
+
+func (wordEncoder) Encode(text string, _ *tokenizerOptions.EncodeOptions) *models.EncodeResponse {
+	response := &models.EncodeResponse{IDs: []uint32{101}}
+	for _, word := range strings.Fields(text) {
+		response.IDs = append(response.IDs, uint32(len(word)))
+	}
+	response.IDs = append(response.IDs, 102)
+	response.TypeIDs = make([]uint32, len(response.IDs))
+	response.AttentionMask = make([]uint32, len(response.IDs))
+	for i := range response.AttentionMask {
+		response.AttentionMask[i] = 1
+	}
+	response.SpecialTokensMask = make([]uint32, len(response.IDs))
+	response.SpecialTokensMask[0], response.SpecialTokensMask[len(response.IDs)-1] = 1, 1
+	return response
+}
+
+func inputData(t *testing.T, input base.InferInput) []int64 {
+	data, err := converter.DeserializeInt64Tensor(input.GetRawData())
+	require.NoError(t, err)
+	return data
+}
+
+func TestEmbedder_MeanPooling(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	result := base.NewMockInferResult(ctrl)
+
+	client.EXPECT().Infer(gomock.Any(), "encoder", "2", gomock.Any(), gomock.Any(), gomock.Nil()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, inputs []base.InferInput, outputs []base.InferOutput, _ any) (base.InferResult, error) {
+			require.Len(t, inputs, 3)
+			assert.Equal(t, "input_ids", inputs[0].GetName())
+			assert.Equal(t, "INT64", inputs[0].GetDatatype())
+			assert.Equal(t, []int64{2, 4}, inputs[0].GetShape())
+			assert.Equal(t, []int64{101, 2, 3, 102, 101, 1, 102, 0}, inputData(t, inputs[0]))
+			assert.Equal(t, "attention_mask", inputs[1].GetName())
+			assert.Equal(t, []int64{1, 1, 1, 1, 1, 1, 1, 0}, inputData(t, inputs[1]))
+			assert.Equal(t, "token_type_ids", inputs[2].GetName())
+			assert.Equal(t, []int64{0, 0, 0, 0, 0, 0, 0, 0}, inputData(t, inputs[2]))
+			require.Len(t, outputs, 1)
+			assert.Equal(t, "logits", outputs[0].GetName())
+			return result, nil
+		})
+	result.EXPECT().GetShape("logits").Return([]int64{2, 4, 2}, nil)
+	result.EXPECT().AsFloat32Slice("logits").Return([]float32{
+		1, 0, 3, 0, 5, 0, 7, 0,
+		2, 2, 4, 4, 6, 6, 100, 100,
+	}, nil)
+
+	embedder, err := NewEmbedder(wordEncoder{}, client, nil, Settings{
+		ModelName:      "encoder",
+		ModelVersion:   "2",
+		OutputDatatype: "FP32",
+		Protocol:       base.ProtocolHTTP,
+	})
+	require.NoError(t, err)
+
+	embeddings, err := embedder.Embed(context.Background(), []string{"ab cde", "a"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{4, 0}, {4, 4}}, embeddings)
+}
+
+func TestEmbedder_BatchesAndNormalizes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+
+	var batches [][]int64
+	client.EXPECT().Infer(gomock.Any(), "pooled", "", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, inputs []base.InferInput, _ []base.InferOutput, _ any) (base.InferResult, error) {
+			require.Len(t, inputs, 2)
+			assert.Equal(t, "ids", inputs[0].GetName())
+			assert.Equal(t, "mask", inputs[1].GetName())
+			batches = append(batches, inputs[0].GetShape())
+
+			rows := int(inputs[0].GetShape()[0])
+			data := make([]float64, 0, 2*rows)
+			for i := 0; i < rows; i++ {
+				data = append(data, 3, 4)
+			}
+			result := base.NewMockInferResult(ctrl)
+			result.EXPECT().GetOutput("embedding").Return(&base.BaseInferOutput{Name: "embedding", Datatype: "FP16"}, nil)
+			result.EXPECT().GetShape("embedding").Return([]int64{int64(rows), 2}, nil)
+			result.EXPECT().AsFloat16Slice("embedding").Return(data, nil)
+			return result, nil
+		}).Times(2)
+
+	embedder, err := NewEmbedder(wordEncoder{}, client, nil, Settings{
+		ModelName:         "pooled",
+		InputIDsName:      "ids",
+		AttentionMaskName: "mask",
+		SkipTokenTypeIDs:  true,
+		OutputName:        "embedding",
+		Pooling:           PoolingNone,
+		Normalize:         true,
+		BatchSize:         2,
+		Protocol:          base.ProtocolGRPC,
+	})
+	require.NoError(t, err)
+
+	embeddings, err := embedder.Embed(context.Background(), []string{"a", "b c", "d e f"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]int64{{2, 4}, {1, 5}}, batches)
+	require.Len(t, embeddings, 3)
+	for _, embedding := range embeddings {
+		assert.InDeltaSlice(t, []float32{0.6, 0.8}, embedding, 1e-6)
+	}
+}
+
+func TestEmbedder_Int32InputsAndCLSPooling(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	result := base.NewMockInferResult(ctrl)
+
+	client.EXPECT().Infer(gomock.Any(), "encoder", "", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, inputs []base.InferInput, _ []base.InferOutput, _ any) (base.InferResult, error) {
+			for _, input := range inputs {
+				assert.Equal(t, "INT32", input.GetDatatype())
+			}
+			data, err := converter.DeserializeInt32Tensor(inputs[0].GetRawData())
+			require.NoError(t, err)
+			assert.Equal(t, []int32{101, 2, 102}, data)
+			return result, nil
+		})
+	result.EXPECT().GetShape("logits").Return([]int64{1, 3, 1}, nil)
+	result.EXPECT().AsFloat64Slice("logits").Return([]float64{1, 2, 3}, nil)
+
+	embedder, err := NewEmbedder(wordEncoder{}, client, nil, Settings{
+		ModelName:      "encoder",
+		InputDatatype:  "INT32",
+		OutputDatatype: "FP64",
+		Pooling:        "CLS",
+		Protocol:       base.ProtocolHTTP,
+	})
+	require.NoError(t, err)
+
+	embeddings, err := embedder.Embed(context.Background(), []string{"ab"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1}}, embeddings)
+}
+
+func TestEmbedder_TruncatesToMaxLength(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	result := base.NewMockInferResult(ctrl)
+
+	client.EXPECT().Infer(gomock.Any(), "encoder", "", gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, inputs []base.InferInput, _ []base.InferOutput, _ any) (base.InferResult, error) {
+			assert.Equal(t, []int64{2, 4}, inputs[0].GetShape())
+			assert.Equal(t, []int64{101, 1, 2, 102, 101, 1, 102, 0}, inputData(t, inputs[0]))
+			assert.Equal(t, []int64{1, 1, 1, 1, 1, 1, 1, 0}, inputData(t, inputs[1]))
+			return result, nil
+		})
+	result.EXPECT().GetShape("logits").Return([]int64{2, 4, 1}, nil)
+	result.EXPECT().AsFloat32Slice("logits").Return([]float32{1, 2, 3, 4, 5, 6, 7, 100}, nil)
+
+	embedder, err := NewEmbedder(wordEncoder{}, client, nil, Settings{
+		ModelName:      "encoder",
+		OutputDatatype: "FP32",
+		Pooling:        postprocess.PoolingLastToken,
+		MaxLength:      4,
+		Protocol:       base.ProtocolHTTP,
+	})
+	require.NoError(t, err)
+
+	// The first text has 6 tokens, its last words are removed and [SEP] is kept.
+	embeddings, err := embedder.Embed(context.Background(), []string{"a bb ccc dddd", "a"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{4}, {7}}, embeddings)
+}
+
+func TestEmbedder_MaxLengthShorterThanSpecialTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+
+	embedder, err := NewEmbedder(wordEncoder{}, client, nil, Settings{ModelName: "encoder", MaxLength: 1, Protocol: base.ProtocolHTTP})
+	require.NoError(t, err)
+
+	_, err = embedder.Embed(context.Background(), []string{"a b"})
+	assert.EqualError(t, err, "embedding texts 0 to 0: text 0: max length 1 is shorter than the 2 special tokens")
+}
+
+func TestEmbedder_InferError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	client.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("model unavailable"))
+
+	embedder, err := NewEmbedder(wordEncoder{}, client, nil, Settings{ModelName: "encoder", Protocol: base.ProtocolHTTP})
+	require.NoError(t, err)
+
+	_, err = embedder.Embed(context.Background(), []string{"a", "b"})
+	assert.EqualError(t, err, "embedding texts 0 to 1: model unavailable")
+}
+
+func TestNewEmbedder_InvalidSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+
+	tests := []struct {
+		name     string
+		settings Settings
+		err      string
+	}{
+		{"missing model", Settings{Protocol: base.ProtocolHTTP}, "a model name is required"},
+		{"unknown protocol", Settings{ModelName: "encoder"}, "cannot determine the protocol of *base.MockClient, set Settings.Protocol"},
+		{"input datatype", Settings{ModelName: "encoder", InputDatatype: "FP32", Protocol: base.ProtocolHTTP}, "unsupported input datatype 'FP32', expected INT64 or INT32"},
+		{"pooling", Settings{ModelName: "encoder", Pooling: "sum", Protocol: base.ProtocolHTTP}, "unknown pooling strategy 'sum'"},
+		{"max length", Settings{ModelName: "encoder", MaxLength: -1, Protocol: base.ProtocolHTTP}, "max length can't be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEmbedder(wordEncoder{}, client, nil, tt.settings)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/models"
+	"github.com/Trendyol/go-triton-client/options"
+	"sync"
+	"time"
+)
+
+// Limit describes the limits applied to the inference calls of a single model.
+type Limit struct {
+	// RequestsPerSecond is the steady rate of the token bucket. Zero disables rate limiting.
+	RequestsPerSecond float64
+	// Burst is the capacity of the token bucket. Defaults to 1 when rate limiting is enabled.
+	Burst int
+	// MaxInFlight is the maximum number of concurrent calls. Zero disables the concurrency limit.
+	MaxInFlight int
+}
+
+// Settings configures the limiter.
+type Settings struct {
+	// Default is applied to models without an entry in Models.
+	Default Limit
+	// Models holds per-model limits keyed by model name.
+	Models map[string]Limit
+	// OnQueued is called with the time a call waited for its rate and concurrency slots before being sent.
+	OnQueued func(modelName string, queueTime time.Duration)
+}
+
+// Limiter is a base.Client that applies per-model rate and concurrency limits to inference calls.
+type Limiter interface {
+	base.Client
+	// InFlight returns the number of inference calls currently being sent for the given model.
+	InFlight(modelName string) int
+}
+
+// client decorates a base.Client. Only Infer is limited; health, metadata and repository
+// calls are passed through unchanged.
+type client struct {
+	base.Client
+	settings Settings
+	mu       sync.Mutex
+	limiters map[string]*modelLimiter
+}
+
+// NewLimiter wraps the given client with per-model rate and concurrency limits.
+func NewLimiter(next base.Client, settings Settings) Limiter {
+	return &client{
+		Client:   next,
+		settings: settings,
+		limiters: make(map[string]*modelLimiter),
+	}
+}
+
+func (c *client) InFlight(modelName string) int {
+	l := c.limiter(modelName)
+	if l.slots == nil {
+		return 0
+	}
+	return len(l.slots)
+}
+
+func (c *client) Infer(
+	ctx context.Context,
+	modelName string,
+	modelVersion string,
+	inputs []base.InferInput,
+	outputs []base.InferOutput,
+	options *options.InferOptions,
+) (base.InferResult, error) {
+	l := c.limiter(modelName)
+
+	start := time.Now()
+	if err := l.acquire(ctx); err != nil {
+		return nil, fmt.Errorf("waiting for limits of model '%s': %w", modelName, err)
+	}
+	defer l.release()
+
+	if c.settings.OnQueued != nil {
+		c.settings.OnQueued(modelName, time.Since(start))
+	}
+
+	return c.Client.Infer(ctx, modelName, modelVersion, inputs, outputs, options)
+}
+
+// GetModelConfigJSON passes the call through, keeping the JSON configuration of the decorated client.
+func (c *client) GetModelConfigJSON(ctx context.Context, modelName string, modelVersion string, options *options.Options) (string, error) {
+	return base.GetModelConfigJSON(ctx, c.Client, modelName, modelVersion, options)
+}
+
+// LoadModelWithRequest passes the load through, keeping the request loading of the decorated client.
+func (c *client) LoadModelWithRequest(ctx context.Context, modelName string, request models.LoadModelRequest, options *options.Options) error {
+	return base.LoadModelWithRequest(ctx, c.Client, modelName, request, options)
+}
+
+// limiter returns the limiter for the given model, creating it on first use.
+func (c *client) limiter(modelName string) *modelLimiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	l, ok := c.limiters[modelName]
+	if !ok {
+		limit, found := c.settings.Models[modelName]
+		if !found {
+			limit = c.settings.Default
+		}
+		l = newModelLimiter(limit)
+		c.limiters[modelName] = l
+	}
+	return l
+}
+
+// modelLimiter combines a token bucket and a semaphore for a single model.
+type modelLimiter struct {
+	bucket *tokenBucket
+	slots  chan struct{}
+}
+
+func newModelLimiter(limit Limit) *modelLimiter {
+	l := &modelLimiter{}
+	if limit.RequestsPerSecond > 0 {
+		l.bucket = newTokenBucket(limit.RequestsPerSecond, limit.Burst)
+	}
+	if limit.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, limit.MaxInFlight)
+	}
+	return l
+}
+
+// acquire waits for a rate token and a concurrency slot, or until the context is done.
+func (l *modelLimiter) acquire(ctx context.Context) error {
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx); err != nil {
+			return err
+		}
+	}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			// The call never runs, so its rate token is given back.
+			if l.bucket != nil {
+				l.bucket.cancel()
+			}
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// release frees the concurrency slot taken by acquire.
+func (l *modelLimiter) release() {
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// tokenBucket is a token bucket whose tokens may go negative to reserve future tokens.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst <= 0 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before using it.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if !b.last.IsZero() {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was not used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// wait blocks until a token is available or the context is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	delay := b.reserve()
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,163 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/options"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLimiter_MaxInFlight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+
+	mockClient := base.NewMockClient(ctrl)
+	mockClient.EXPECT().Infer(gomock.Any(), "model", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, modelName, modelVersion string, inputs []base.InferInput, outputs []base.InferOutput, options *options.InferOptions) (base.InferResult, error) {
+			started <- struct{}{}
+			<-release
+			return nil, nil
+		}).Times(2)
+
+	l := NewLimiter(mockClient, Settings{Models: map[string]Limit{"model": {MaxInFlight: 1}}})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := l.Infer(context.Background(), "model", "", nil, nil, &options.InferOptions{})
+			assert.NoError(t, err)
+		}()
+	}
+
+	<-started
+	select {
+	case <-started:
+		t.Fatal("Expected the second call to wait for a free slot")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Equal(t, 1, l.InFlight("model"))
+
+	release <- struct{}{}
+	<-started
+	release <- struct{}{}
+	wg.Wait()
+
+	assert.Equal(t, 0, l.InFlight("model"))
+}
+
+func TestLimiter_MaxInFlightContextCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	l := NewLimiter(mockClient, Settings{Default: Limit{MaxInFlight: 1}}).(*client)
+	l.limiter("model").slots <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := l.Infer(ctx, "model", "", nil, nil, &options.InferOptions{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestLimiter_RateLimitReportsQueueTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).Times(2)
+
+	var queueTimes []time.Duration
+	l := NewLimiter(mockClient, Settings{
+		Default: Limit{RequestsPerSecond: 20, Burst: 1},
+		OnQueued: func(modelName string, queueTime time.Duration) {
+			assert.Equal(t, "model", modelName)
+			queueTimes = append(queueTimes, queueTime)
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := l.Infer(context.Background(), "model", "", nil, nil, &options.InferOptions{})
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, queueTimes, 2)
+	assert.Less(t, queueTimes[0], 10*time.Millisecond)
+	assert.GreaterOrEqual(t, queueTimes[1], 30*time.Millisecond)
+}
+
+func TestLimiter_RateLimitContextCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	mockClient.EXPECT().Infer(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).Times(1)
+
+	l := NewLimiter(mockClient, Settings{Default: Limit{RequestsPerSecond: 1, Burst: 1}})
+
+	_, err := l.Infer(context.Background(), "model", "", nil, nil, &options.InferOptions{})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = l.Infer(ctx, "model", "", nil, nil, &options.InferOptions{})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestLimiter_PassesThroughOtherCalls(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := base.NewMockClient(ctrl)
+	mockClient.EXPECT().IsServerReady(gomock.Any(), gomock.Any()).Return(true, nil)
+
+	l := NewLimiter(mockClient, Settings{Default: Limit{MaxInFlight: 1}})
+
+	ready, err := l.IsServerReady(context.Background(), &options.Options{})
+	assert.NoError(t, err)
+	assert.True(t, ready)
+}
+
+func TestTokenBucket_Reserve(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newTokenBucket(10, 2)
+	b.now = func() time.Time { return now }
+
+	assert.Equal(t, time.Duration(0), b.reserve())
+	assert.Equal(t, time.Duration(0), b.reserve())
+	assert.Equal(t, 100*time.Millisecond, b.reserve())
+
+	b.cancel()
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), b.reserve())
+}
+
+func TestLimiter_ContextCanceledWaitingForSlotReturnsRateToken(t *testing.T) {
+	l := newModelLimiter(Limit{RequestsPerSecond: 0.001, Burst: 1, MaxInFlight: 1})
+	l.slots <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.acquire(ctx), context.DeadlineExceeded)
+
+	l.release()
+	// The bucket refills one token in 1000 seconds, so the call only gets through with the returned token.
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if assert.NoError(t, l.acquire(ctx)) {
+		l.release()
+	}
+}
@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"context"
+	"github.com/Trendyol/go-triton-client/base"
+	"log"
+	"log/slog"
+	"time"
+)
+
+// Settings configures the logging interceptor.
+type Settings struct {
+	// Level is the level of records for successful calls. Failed calls are always logged at slog.LevelError.
+	Level slog.Level
+	// LogPayloads adds the inference inputs and the call result to records.
+	// Tensor data is never logged unless it is set.
+	LogPayloads bool
+}
+
+// NewInterceptor returns an interceptor that emits one structured record per client call with
+// the operation, protocol, model, request ID, latency, status and, for inferences, the binary data sizes.
+func NewInterceptor(logger *slog.Logger, settings Settings) base.UnaryInterceptor {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(ctx context.Context, op base.Operation, req *base.Request, invoker base.Invoker) (any, error) {
+		start := time.Now()
+		result, err := invoker(ctx, req)
+		latency := time.Since(start)
+
+		level := settings.Level
+		message := "triton call succeeded"
+		if err != nil {
+			level = slog.LevelError
+			message = "triton call failed"
+		}
+		if !logger.Enabled(ctx, level) {
+			return result, err
+		}
+
+		attributes := []slog.Attr{
+			slog.String("operation", string(op)),
+			slog.Duration("latency", latency),
+		}
+		if req.Protocol != "" {
+			attributes = append(attributes, slog.String("protocol", string(req.Protocol)))
+		}
+		if req.ModelName != "" {
+			attributes = append(attributes, slog.String("model_name", req.ModelName))
+		}
+		if req.ModelVersion != "" {
+			attributes = append(attributes, slog.String("model_version", req.ModelVersion))
+		}
+
+		if op == base.OperationInfer {
+			if req.InferOptions != nil && req.InferOptions.RequestID != nil {
+				attributes = append(attributes, slog.String("request_id", *req.InferOptions.RequestID))
+			}
+			attributes = append(attributes, slog.Int("input_bytes", inputSize(req.Inputs)))
+			if sized, ok := result.(interface{ GetBufferSize() int }); ok && err == nil {
+				attributes = append(attributes, slog.Int("output_bytes", sized.GetBufferSize()))
+			}
+		}
+
+		if err != nil {
+			attributes = append(attributes, slog.String("status", "error"), slog.Any("error", err))
+		} else {
+			attributes = append(attributes, slog.String("status", "ok"))
+		}
+
+		if settings.LogPayloads {
+			if op == base.OperationInfer {
+				attributes = append(attributes, slog.Any("inputs", req.Inputs))
+			}
+			if err == nil {
+				attributes = append(attributes, slog.Any("result", result))
+			}
+		}
+
+		logger.LogAttrs(ctx, level, message, attributes...)
+		return result, err
+	}
+}
+
+// inputSize returns the total size in bytes of the binary input data.
+func inputSize(inputs []base.InferInput) int {
+	size := 0
+	for _, input := range inputs {
+		if input != nil {
+			size += len(input.GetRawData())
+		}
+	}
+	return size
+}
+
+// NewTextLogger returns a logger that writes text records at every level to the output of a standard library logger.
+func NewTextLogger(logger *log.Logger) *slog.Logger {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return slog.New(slog.NewTextHandler(logger.Writer(), &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
@@ -0,0 +1,134 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type sizedResult struct {
+	base.InferResult
+	Payload []byte
+}
+
+func (r *sizedResult) GetBufferSize() int {
+	return len(r.Payload)
+}
+
+func newJSONLogger(buffer *bytes.Buffer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: level}))
+}
+
+func decodeRecord(t *testing.T, buffer *bytes.Buffer) map[string]any {
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+	return record
+}
+
+func TestInterceptor_LogsInferWithoutPayload(t *testing.T) {
+	var buffer bytes.Buffer
+	interceptor := NewInterceptor(newJSONLogger(&buffer, slog.LevelDebug), Settings{Level: slog.LevelDebug})
+
+	input := &base.BaseInferInput{Name: "input_ids", RawData: []byte{1, 2, 3, 4, 5, 6, 7, 8}}
+	requestID := "request-1"
+	req := &base.Request{
+		Protocol:     base.ProtocolHTTP,
+		ModelName:    "model",
+		ModelVersion: "1",
+		InferOptions: &options.InferOptions{RequestID: &requestID},
+		Inputs:       []base.InferInput{&rawInput{input}},
+	}
+
+	_, err := interceptor(context.Background(), base.OperationInfer, req, func(ctx context.Context, req *base.Request) (any, error) {
+		return &sizedResult{Payload: []byte("secret tensor")}, nil
+	})
+	require.NoError(t, err)
+
+	record := decodeRecord(t, &buffer)
+	assert.Equal(t, "DEBUG", record["level"])
+	assert.Equal(t, "triton call succeeded", record["msg"])
+	assert.Equal(t, "Infer", record["operation"])
+	assert.Equal(t, "http", record["protocol"])
+	assert.Equal(t, "model", record["model_name"])
+	assert.Equal(t, "1", record["model_version"])
+	assert.Equal(t, "request-1", record["request_id"])
+	assert.Equal(t, float64(8), record["input_bytes"])
+	assert.Equal(t, float64(13), record["output_bytes"])
+	assert.Equal(t, "ok", record["status"])
+	assert.Contains(t, record, "latency")
+	assert.NotContains(t, record, "result")
+	assert.NotContains(t, record, "inputs")
+}
+
+func TestInterceptor_LogsPayloadsWhenAsked(t *testing.T) {
+	var buffer bytes.Buffer
+	interceptor := NewInterceptor(newJSONLogger(&buffer, slog.LevelInfo), Settings{LogPayloads: true})
+
+	_, err := interceptor(context.Background(), base.OperationGetServerMetadata, &base.Request{}, func(ctx context.Context, req *base.Request) (any, error) {
+		return map[string]string{"name": "triton"}, nil
+	})
+	require.NoError(t, err)
+
+	record := decodeRecord(t, &buffer)
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, map[string]any{"name": "triton"}, record["result"])
+}
+
+func TestInterceptor_LogsErrors(t *testing.T) {
+	var buffer bytes.Buffer
+	interceptor := NewInterceptor(newJSONLogger(&buffer, slog.LevelError), Settings{Level: slog.LevelDebug})
+
+	failure := errors.New("model not found")
+	_, err := interceptor(context.Background(), base.OperationIsModelReady, &base.Request{ModelName: "model"}, func(ctx context.Context, req *base.Request) (any, error) {
+		return false, failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	record := decodeRecord(t, &buffer)
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Equal(t, "triton call failed", record["msg"])
+	assert.Equal(t, "error", record["status"])
+	assert.Equal(t, "model not found", record["error"])
+}
+
+func TestInterceptor_SkipsDisabledLevels(t *testing.T) {
+	var buffer bytes.Buffer
+	interceptor := NewInterceptor(newJSONLogger(&buffer, slog.LevelInfo), Settings{Level: slog.LevelDebug})
+
+	_, err := interceptor(context.Background(), base.OperationIsServerLive, &base.Request{}, func(ctx context.Context, req *base.Request) (any, error) {
+		return true, nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, buffer.String())
+}
+
+func TestNewTextLogger(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := NewTextLogger(log.New(&buffer, "", 0))
+
+	logger.Debug("message", slog.String("key", "value"))
+
+	assert.True(t, strings.Contains(buffer.String(), "level=DEBUG msg=message key=value"))
+}
+
+// rawInput exposes the raw data of a BaseInferInput through the InferInput interface.
+type rawInput struct {
+	*base.BaseInferInput
+}
+
+func (i *rawInput) GetTensor() any {
+	return nil
+}
+
+func (i *rawInput) GetBinaryData() []byte {
+	return i.RawData
+}
@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	context "context"
 	http "net/http"
 	reflect "reflect"
 
@@ -56,46 +57,46 @@ func (mr *MockHttpClientMockRecorder) Do(request any) *gomock.Call {
 }
 
 // Get mocks base method.
-func (m *MockHttpClient) Get(baseURL, requestURI string, headers, queryParams map[string]string) (*http.Response, error) {
+func (m *MockHttpClient) Get(ctx context.Context, baseURL, requestURI string, headers, queryParams map[string]string) (*http.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", baseURL, requestURI, headers, queryParams)
+	ret := m.ctrl.Call(m, "Get", ctx, baseURL, requestURI, headers, queryParams)
 	ret0, _ := ret[0].(*http.Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockHttpClientMockRecorder) Get(baseURL, requestURI, headers, queryParams any) *gomock.Call {
+func (mr *MockHttpClientMockRecorder) Get(ctx, baseURL, requestURI, headers, queryParams any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHttpClient)(nil).Get), baseURL, requestURI, headers, queryParams)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHttpClient)(nil).Get), ctx, baseURL, requestURI, headers, queryParams)
 }
 
 // Post mocks base method.
-func (m *MockHttpClient) Post(baseURL, requestURI, requestBody string, headers, queryParams map[string]string) (*http.Response, error) {
+func (m *MockHttpClient) Post(ctx context.Context, baseURL, requestURI, requestBody string, headers, queryParams map[string]string) (*http.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Post", baseURL, requestURI, requestBody, headers, queryParams)
+	ret := m.ctrl.Call(m, "Post", ctx, baseURL, requestURI, requestBody, headers, queryParams)
 	ret0, _ := ret[0].(*http.Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Post indicates an expected call of Post.
-func (mr *MockHttpClientMockRecorder) Post(baseURL, requestURI, requestBody, headers, queryParams any) *gomock.Call {
+func (mr *MockHttpClientMockRecorder) Post(ctx, baseURL, requestURI, requestBody, headers, queryParams any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockHttpClient)(nil).Post), baseURL, requestURI, requestBody, headers, queryParams)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Post", reflect.TypeOf((*MockHttpClient)(nil).Post), ctx, baseURL, requestURI, requestBody, headers, queryParams)
 }
 
 // PostWithBytes mocks base method.
-func (m *MockHttpClient) PostWithBytes(baseURL, requestURI string, requestBody []byte, headers, queryParams map[string]string) (*http.Response, error) {
+func (m *MockHttpClient) PostWithBytes(ctx context.Context, baseURL, requestURI string, requestBody []byte, headers, queryParams map[string]string) (*http.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PostWithBytes", baseURL, requestURI, requestBody, headers, queryParams)
+	ret := m.ctrl.Call(m, "PostWithBytes", ctx, baseURL, requestURI, requestBody, headers, queryParams)
 	ret0, _ := ret[0].(*http.Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PostWithBytes indicates an expected call of PostWithBytes.
-func (mr *MockHttpClientMockRecorder) PostWithBytes(baseURL, requestURI, requestBody, headers, queryParams any) *gomock.Call {
+func (mr *MockHttpClientMockRecorder) PostWithBytes(ctx, baseURL, requestURI, requestBody, headers, queryParams any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostWithBytes", reflect.TypeOf((*MockHttpClient)(nil).PostWithBytes), baseURL, requestURI, requestBody, headers, queryParams)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostWithBytes", reflect.TypeOf((*MockHttpClient)(nil).PostWithBytes), ctx, baseURL, requestURI, requestBody, headers, queryParams)
 }
@@ -0,0 +1,127 @@
+package modelconfig
+
+import (
+	"fmt"
+	"github.com/Trendyol/go-triton-client/client/grpc/grpc_generated_v2"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Difference is a field whose value differs between two model configurations.
+type Difference struct {
+	// Path locates the field, e.g. "max_batch_size", "input[0].dims" or "parameters[\"mode\"].string_value".
+	Path string
+	// Old is the value in the first configuration in the protobuf text format, empty when it is not set.
+	Old string
+	// New is the value in the second configuration in the protobuf text format, empty when it is not set.
+	New string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %s -> %s", d.Path, valueOrUnset(d.Old), valueOrUnset(d.New))
+}
+
+// Diff returns the fields that differ between two model configurations, in declaration order.
+// Nested messages are compared field by field, and elements added to or removed from a list
+// are reported as the fields they set.
+func Diff(old, new *grpc_generated_v2.ModelConfig) []Difference {
+	var differences []Difference
+	diffMessage(&differences, "", old.ProtoReflect(), new.ProtoReflect())
+	return differences
+}
+
+func diffMessage(differences *[]Difference, prefix string, old, new protoreflect.Message) {
+	fields := old.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !old.Has(field) && !new.Has(field) {
+			continue
+		}
+		path := prefix + field.TextName()
+
+		switch {
+		case field.IsMap():
+			diffMap(differences, path, field, old.Get(field).Map(), new.Get(field).Map())
+		case field.IsList() && isMessage(field):
+			oldList, newList := old.Get(field).List(), new.Get(field).List()
+			for j := 0; j < max(oldList.Len(), newList.Len()); j++ {
+				diffMessage(differences, fmt.Sprintf("%s[%d].", path, j), listElement(oldList, j), listElement(newList, j))
+			}
+		case field.IsList():
+			oldValue, newValue := formatSetList(field, old), formatSetList(field, new)
+			if oldValue != newValue {
+				*differences = append(*differences, Difference{Path: path, Old: oldValue, New: newValue})
+			}
+		case isMessage(field):
+			diffMessage(differences, path+".", old.Get(field).Message(), new.Get(field).Message())
+		default:
+			oldValue, newValue := formatSetScalar(field, old), formatSetScalar(field, new)
+			if oldValue != newValue {
+				*differences = append(*differences, Difference{Path: path, Old: oldValue, New: newValue})
+			}
+		}
+	}
+}
+
+func diffMap(differences *[]Difference, path string, field protoreflect.FieldDescriptor, old, new protoreflect.Map) {
+	keys := sortedKeys(old)
+	for _, key := range sortedKeys(new) {
+		if !old.Has(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		keyPath := path + "[" + formatScalar(field.MapKey(), key.Value()) + "]"
+		if isMessage(field.MapValue()) {
+			diffMessage(differences, keyPath+".", mapElement(old, key), mapElement(new, key))
+			continue
+		}
+		var oldValue, newValue string
+		if old.Has(key) {
+			oldValue = formatScalar(field.MapValue(), old.Get(key))
+		}
+		if new.Has(key) {
+			newValue = formatScalar(field.MapValue(), new.Get(key))
+		}
+		if oldValue != newValue {
+			*differences = append(*differences, Difference{Path: keyPath, Old: oldValue, New: newValue})
+		}
+	}
+}
+
+// listElement returns the message at index i, or an empty message past the end of the list.
+func listElement(list protoreflect.List, i int) protoreflect.Message {
+	if i < list.Len() {
+		return list.Get(i).Message()
+	}
+	return list.NewElement().Message()
+}
+
+// mapElement returns the message stored under key, or an empty message when the key is absent.
+func mapElement(entries protoreflect.Map, key protoreflect.MapKey) protoreflect.Message {
+	if entries.Has(key) {
+		return entries.Get(key).Message()
+	}
+	return entries.NewValue().Message()
+}
+
+func formatSetList(field protoreflect.FieldDescriptor, m protoreflect.Message) string {
+	if !m.Has(field) {
+		return ""
+	}
+	return formatList(field, m.Get(field).List())
+}
+
+func formatSetScalar(field protoreflect.FieldDescriptor, m protoreflect.Message) string {
+	if !m.Has(field) {
+		return ""
+	}
+	return formatScalar(field, m.Get(field))
+}
+
+func valueOrUnset(value string) string {
+	if value == "" {
+		return "<unset>"
+	}
+	return value
+}
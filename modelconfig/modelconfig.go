@@ -0,0 +1,229 @@
+package modelconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/client/grpc/grpc_generated_v2"
+	"github.com/Trendyol/go-triton-client/models"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"strconv"
+)
+
+// ParsePbtxt parses a model configuration in the protobuf text format of config.pbtxt files.
+func ParsePbtxt(pbtxt []byte) (*grpc_generated_v2.ModelConfig, error) {
+	config := &grpc_generated_v2.ModelConfig{}
+	if err := prototext.Unmarshal(pbtxt, config); err != nil {
+		return nil, fmt.Errorf("parsing model configuration: %w", err)
+	}
+	return config, nil
+}
+
+// ParseJSON parses a model configuration in Triton's JSON format.
+func ParseJSON(data []byte) (*grpc_generated_v2.ModelConfig, error) {
+	config := &grpc_generated_v2.ModelConfig{}
+	if err := protojson.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parsing model configuration: %w", err)
+	}
+	return config, nil
+}
+
+// RenderPbtxt renders a model configuration in the protobuf text format, laid out like the configurations
+// of the Triton documentation. The output is stable, so it can be compared or committed.
+func RenderPbtxt(config *grpc_generated_v2.ModelConfig) []byte {
+	var buffer bytes.Buffer
+	writeMessage(&buffer, config.ProtoReflect(), 0)
+	return buffer.Bytes()
+}
+
+// RenderJSON renders a model configuration in Triton's JSON format, as expected by the config parameter of LoadModel.
+func RenderJSON(config *grpc_generated_v2.ModelConfig) (string, error) {
+	encoded, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	// protojson randomizes its whitespace, compacting keeps the output stable.
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, encoded); err != nil {
+		return "", err
+	}
+	return compacted.String(), nil
+}
+
+// ToModel converts a model configuration to the type returned by base.Client.GetModelConfig.
+func ToModel(config *grpc_generated_v2.ModelConfig) *models.ModelConfigResponse {
+	model := &models.ModelConfigResponse{
+		Name:                 config.Name,
+		Platform:             config.Platform,
+		Backend:              config.Backend,
+		DefaultModelFileName: config.DefaultModelFilename,
+	}
+
+	if config.VersionPolicy != nil && config.VersionPolicy.GetLatest() != nil {
+		model.VersionPolicy = models.ModelConfigVersionPolicy{
+			Latest: models.ModelConfigLatestVersionPolicy{
+				NumVersions: int(config.VersionPolicy.GetLatest().NumVersions),
+			},
+		}
+	}
+
+	for _, input := range config.Input {
+		inputDims := make([]int, len(input.Dims))
+		for i, dim := range input.Dims {
+			inputDims[i] = int(dim)
+		}
+		model.Input = append(model.Input, models.ModelConfigInput{
+			Name:             input.Name,
+			DataType:         input.DataType.String(),
+			Format:           input.Format.String(),
+			Dims:             inputDims,
+			IsShapeTensor:    input.IsShapeTensor,
+			AllowRaggedBatch: input.AllowRaggedBatch,
+			Optional:         input.Optional,
+		})
+	}
+
+	for _, output := range config.Output {
+		outputDims := make([]int, len(output.Dims))
+		for i, dim := range output.Dims {
+			outputDims[i] = int(dim)
+		}
+		model.Output = append(model.Output, models.ModelConfigOutput{
+			Name:          output.Name,
+			DataType:      output.DataType.String(),
+			Dims:          outputDims,
+			LabelFilename: output.LabelFilename,
+			IsShapeTensor: output.IsShapeTensor,
+		})
+	}
+
+	for _, group := range config.InstanceGroup {
+		gpus := make([]string, len(group.Gpus))
+		for i, gpu := range group.Gpus {
+			gpus[i] = fmt.Sprintf("%d", gpu)
+		}
+		model.InstanceGroup = append(model.InstanceGroup, models.ModelConfigInstanceGroup{
+			Name:       group.Name,
+			Kind:       group.Kind.String(),
+			Count:      int(group.Count),
+			GPUs:       gpus,
+			Passive:    group.Passive,
+			HostPolicy: group.HostPolicy,
+		})
+	}
+
+	if len(config.Parameters) > 0 {
+		model.Parameters = make(map[string]models.ModelConfigParameterValue, len(config.Parameters))
+		for key, parameter := range config.Parameters {
+			model.Parameters[key] = models.ModelConfigParameterValue{StringValue: parameter.StringValue}
+		}
+	}
+
+	return model
+}
+
+// FromModel converts the fields of a typed model configuration to its protobuf form,
+// e.g. to render a configuration built in Go as a LoadModel override.
+func FromModel(model *models.ModelConfigResponse) (*grpc_generated_v2.ModelConfig, error) {
+	config := &grpc_generated_v2.ModelConfig{
+		Name:                 model.Name,
+		Platform:             model.Platform,
+		Backend:              model.Backend,
+		DefaultModelFilename: model.DefaultModelFileName,
+	}
+
+	if model.VersionPolicy.Latest.NumVersions > 0 {
+		config.VersionPolicy = &grpc_generated_v2.ModelVersionPolicy{
+			PolicyChoice: &grpc_generated_v2.ModelVersionPolicy_Latest_{
+				Latest: &grpc_generated_v2.ModelVersionPolicy_Latest{NumVersions: uint32(model.VersionPolicy.Latest.NumVersions)},
+			},
+		}
+	}
+
+	for _, input := range model.Input {
+		dataType, err := enumValue(grpc_generated_v2.DataType_value, "data type", input.DataType)
+		if err != nil {
+			return nil, fmt.Errorf("input '%s': %w", input.Name, err)
+		}
+		format, err := enumValue(grpc_generated_v2.ModelInput_Format_value, "format", input.Format)
+		if err != nil {
+			return nil, fmt.Errorf("input '%s': %w", input.Name, err)
+		}
+		config.Input = append(config.Input, &grpc_generated_v2.ModelInput{
+			Name:             input.Name,
+			DataType:         grpc_generated_v2.DataType(dataType),
+			Format:           grpc_generated_v2.ModelInput_Format(format),
+			Dims:             toInt64(input.Dims),
+			IsShapeTensor:    input.IsShapeTensor,
+			AllowRaggedBatch: input.AllowRaggedBatch,
+			Optional:         input.Optional,
+		})
+	}
+
+	for _, output := range model.Output {
+		dataType, err := enumValue(grpc_generated_v2.DataType_value, "data type", output.DataType)
+		if err != nil {
+			return nil, fmt.Errorf("output '%s': %w", output.Name, err)
+		}
+		config.Output = append(config.Output, &grpc_generated_v2.ModelOutput{
+			Name:          output.Name,
+			DataType:      grpc_generated_v2.DataType(dataType),
+			Dims:          toInt64(output.Dims),
+			LabelFilename: output.LabelFilename,
+			IsShapeTensor: output.IsShapeTensor,
+		})
+	}
+
+	for _, group := range model.InstanceGroup {
+		kind, err := enumValue(grpc_generated_v2.ModelInstanceGroup_Kind_value, "kind", group.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("instance group '%s': %w", group.Name, err)
+		}
+		gpus := make([]int32, len(group.GPUs))
+		for i, gpu := range group.GPUs {
+			id, err := strconv.ParseInt(gpu, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("instance group '%s': invalid GPU '%s'", group.Name, gpu)
+			}
+			gpus[i] = int32(id)
+		}
+		config.InstanceGroup = append(config.InstanceGroup, &grpc_generated_v2.ModelInstanceGroup{
+			Name:       group.Name,
+			Kind:       grpc_generated_v2.ModelInstanceGroup_Kind(kind),
+			Count:      int32(group.Count),
+			Gpus:       gpus,
+			Passive:    group.Passive,
+			HostPolicy: group.HostPolicy,
+		})
+	}
+
+	if len(model.Parameters) > 0 {
+		config.Parameters = make(map[string]*grpc_generated_v2.ModelParameter, len(model.Parameters))
+		for key, parameter := range model.Parameters {
+			config.Parameters[key] = &grpc_generated_v2.ModelParameter{StringValue: parameter.StringValue}
+		}
+	}
+
+	return config, nil
+}
+
+// enumValue returns the number of an enum value name. An empty name is the zero value.
+func enumValue(values map[string]int32, kind string, name string) (int32, error) {
+	if name == "" {
+		return 0, nil
+	}
+	value, ok := values[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown %s '%s'", kind, name)
+	}
+	return value, nil
+}
+
+func toInt64(values []int) []int64 {
+	result := make([]int64, len(values))
+	for i, value := range values {
+		result[i] = int64(value)
+	}
+	return result
+}
@@ -0,0 +1,196 @@
+package modelconfig
+
+import (
+	"github.com/Trendyol/go-triton-client/client/grpc/grpc_generated_v2"
+	"github.com/Trendyol/go-triton-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+const testConfig = `
+name: "encoder"
+platform: "onnxruntime_onnx"
+max_batch_size: 8
+version_policy: { latest: { num_versions: 2 } }
+input [
+  {
+    name: "input_ids"
+    data_type: TYPE_INT64
+    dims: [ -1 ]
+  },
+  {
+    name: "attention_mask"
+    data_type: TYPE_INT64
+    dims: [ -1 ]
+  }
+]
+output [
+  {
+    name: "embeddings"
+    data_type: TYPE_FP32
+    dims: [ 768 ]
+  }
+]
+instance_group [ { kind: KIND_GPU, count: 2, gpus: [ 0, 1 ] } ]
+dynamic_batching { max_queue_delay_microseconds: 100 }
+parameters { key: "pooling" value: { string_value: "mean" } }
+parameters { key: "description" value: { string_value: "say \"hi\"\n" } }
+`
+
+const renderedConfig = `name: "encoder"
+platform: "onnxruntime_onnx"
+version_policy {
+  latest {
+    num_versions: 2
+  }
+}
+max_batch_size: 8
+input [
+  {
+    name: "input_ids"
+    data_type: TYPE_INT64
+    dims: [ -1 ]
+  },
+  {
+    name: "attention_mask"
+    data_type: TYPE_INT64
+    dims: [ -1 ]
+  }
+]
+output [
+  {
+    name: "embeddings"
+    data_type: TYPE_FP32
+    dims: [ 768 ]
+  }
+]
+dynamic_batching {
+  max_queue_delay_microseconds: 100
+}
+instance_group [
+  {
+    kind: KIND_GPU
+    count: 2
+    gpus: [ 0, 1 ]
+  }
+]
+parameters {
+  key: "description"
+  value {
+    string_value: "say \"hi\"\n"
+  }
+}
+parameters {
+  key: "pooling"
+  value {
+    string_value: "mean"
+  }
+}
+`
+
+func TestParsePbtxt(t *testing.T) {
+	config, err := ParsePbtxt([]byte(testConfig))
+	require.NoError(t, err)
+
+	assert.Equal(t, "encoder", config.Name)
+	assert.Equal(t, int32(8), config.MaxBatchSize)
+	assert.Equal(t, []int64{-1}, config.Input[1].Dims)
+	assert.Equal(t, grpc_generated_v2.DataType_TYPE_FP32, config.Output[0].DataType)
+	assert.Equal(t, "mean", config.Parameters["pooling"].StringValue)
+
+	_, err = ParsePbtxt([]byte(`max_batch_size: "eight"`))
+	assert.ErrorContains(t, err, "parsing model configuration")
+}
+
+func TestRenderPbtxt(t *testing.T) {
+	config, err := ParsePbtxt([]byte(testConfig))
+	require.NoError(t, err)
+
+	rendered := RenderPbtxt(config)
+	assert.Equal(t, renderedConfig, string(rendered))
+
+	reparsed, err := ParsePbtxt(rendered)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(config, reparsed))
+}
+
+func TestRenderJSON(t *testing.T) {
+	config, err := ParsePbtxt([]byte(testConfig))
+	require.NoError(t, err)
+
+	rendered, err := RenderJSON(config)
+	require.NoError(t, err)
+	assert.Contains(t, rendered, `"max_batch_size":8`)
+	assert.Contains(t, rendered, `"dims":["768"]`)
+	assert.Contains(t, rendered, `"parameters":{`)
+
+	reparsed, err := ParseJSON([]byte(rendered))
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(config, reparsed))
+}
+
+func TestToModelAndFromModel(t *testing.T) {
+	config, err := ParsePbtxt([]byte(testConfig))
+	require.NoError(t, err)
+
+	model := ToModel(config)
+	assert.Equal(t, "encoder", model.Name)
+	assert.Equal(t, 2, model.VersionPolicy.Latest.NumVersions)
+	assert.Equal(t, []int{-1}, model.Input[0].Dims)
+	assert.Equal(t, "TYPE_INT64", model.Input[0].DataType)
+	assert.Equal(t, []string{"0", "1"}, model.InstanceGroup[0].GPUs)
+	assert.Equal(t, "mean", model.Parameters["pooling"].StringValue)
+
+	converted, err := FromModel(model)
+	require.NoError(t, err)
+	assert.Equal(t, config.Input[0].Dims, converted.Input[0].Dims)
+	assert.Equal(t, config.InstanceGroup[0].Gpus, converted.InstanceGroup[0].Gpus)
+	assert.Equal(t, config.VersionPolicy.GetLatest().NumVersions, converted.VersionPolicy.GetLatest().NumVersions)
+	// The typed model does not carry the batching settings.
+	assert.Equal(t, []Difference{
+		{Path: "max_batch_size", Old: "8"},
+		{Path: "dynamic_batching.max_queue_delay_microseconds", Old: "100"},
+	}, Diff(config, converted))
+}
+
+func TestFromModel_Errors(t *testing.T) {
+	_, err := FromModel(&models.ModelConfigResponse{Input: []models.ModelConfigInput{{Name: "x", DataType: "FP32"}}})
+	assert.EqualError(t, err, "input 'x': unknown data type 'FP32'")
+
+	_, err = FromModel(&models.ModelConfigResponse{InstanceGroup: []models.ModelConfigInstanceGroup{{Name: "g", GPUs: []string{"gpu0"}}}})
+	assert.EqualError(t, err, "instance group 'g': invalid GPU 'gpu0'")
+}
+
+func TestDiff(t *testing.T) {
+	old, err := ParsePbtxt([]byte(testConfig))
+	require.NoError(t, err)
+	new := proto.Clone(old).(*grpc_generated_v2.ModelConfig)
+
+	new.MaxBatchSize = 16
+	new.Input[0].Dims = []int64{-1, 512}
+	new.Input = new.Input[:1]
+	new.Output = append(new.Output, &grpc_generated_v2.ModelOutput{Name: "pooled", DataType: grpc_generated_v2.DataType_TYPE_FP32})
+	delete(new.Parameters, "description")
+	new.Parameters["pooling"].StringValue = "cls"
+	new.SchedulingChoice = nil
+
+	differences := Diff(old, new)
+	assert.Equal(t, []Difference{
+		{Path: "max_batch_size", Old: "8", New: "16"},
+		{Path: "input[0].dims", Old: "[ -1 ]", New: "[ -1, 512 ]"},
+		{Path: "input[1].name", Old: `"attention_mask"`},
+		{Path: "input[1].data_type", Old: "TYPE_INT64"},
+		{Path: "input[1].dims", Old: "[ -1 ]"},
+		{Path: "output[1].name", New: `"pooled"`},
+		{Path: "output[1].data_type", New: "TYPE_FP32"},
+		{Path: "dynamic_batching.max_queue_delay_microseconds", Old: "100"},
+		{Path: `parameters["description"].string_value`, Old: `"say \"hi\"\n"`},
+		{Path: `parameters["pooling"].string_value`, Old: `"mean"`, New: `"cls"`},
+	}, differences)
+	assert.Equal(t, "max_batch_size: 8 -> 16", differences[0].String())
+	assert.Equal(t, `output[1].name: <unset> -> "pooled"`, differences[5].String())
+
+	assert.Empty(t, Diff(old, proto.Clone(old).(*grpc_generated_v2.ModelConfig)))
+}
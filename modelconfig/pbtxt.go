@@ -0,0 +1,201 @@
+package modelconfig
+
+import (
+	"bytes"
+	"fmt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const indentation = "  "
+
+// writeMessage writes the fields set on m in declaration order. Repeated messages are written
+// as "name [ { ... }, { ... } ]" and repeated scalars as "name: [ a, b ]", as in Triton's examples.
+func writeMessage(buffer *bytes.Buffer, m protoreflect.Message, depth int) {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !m.Has(field) {
+			continue
+		}
+		value := m.Get(field)
+		name := field.TextName()
+
+		switch {
+		case field.IsMap():
+			writeMap(buffer, field, value.Map(), depth)
+		case field.IsList() && isMessage(field):
+			list := value.List()
+			writeIndent(buffer, depth)
+			buffer.WriteString(name + " [\n")
+			for j := 0; j < list.Len(); j++ {
+				writeIndent(buffer, depth+1)
+				buffer.WriteString("{\n")
+				writeMessage(buffer, list.Get(j).Message(), depth+2)
+				writeIndent(buffer, depth+1)
+				buffer.WriteString("}")
+				if j < list.Len()-1 {
+					buffer.WriteString(",")
+				}
+				buffer.WriteString("\n")
+			}
+			writeIndent(buffer, depth)
+			buffer.WriteString("]\n")
+		case field.IsList():
+			writeIndent(buffer, depth)
+			buffer.WriteString(name + ": " + formatList(field, value.List()) + "\n")
+		case isMessage(field):
+			writeIndent(buffer, depth)
+			buffer.WriteString(name + " {\n")
+			writeMessage(buffer, value.Message(), depth+1)
+			writeIndent(buffer, depth)
+			buffer.WriteString("}\n")
+		default:
+			writeIndent(buffer, depth)
+			buffer.WriteString(name + ": " + formatScalar(field, value) + "\n")
+		}
+	}
+}
+
+// writeMap writes every entry of a map field as a "name { key: ... value ... }" block, sorted by key.
+func writeMap(buffer *bytes.Buffer, field protoreflect.FieldDescriptor, entries protoreflect.Map, depth int) {
+	for _, key := range sortedKeys(entries) {
+		writeIndent(buffer, depth)
+		buffer.WriteString(field.TextName() + " {\n")
+		writeIndent(buffer, depth+1)
+		buffer.WriteString("key: " + formatScalar(field.MapKey(), key.Value()) + "\n")
+		value := entries.Get(key)
+		writeIndent(buffer, depth+1)
+		if isMessage(field.MapValue()) {
+			buffer.WriteString("value {\n")
+			writeMessage(buffer, value.Message(), depth+2)
+			writeIndent(buffer, depth+1)
+			buffer.WriteString("}\n")
+		} else {
+			buffer.WriteString("value: " + formatScalar(field.MapValue(), value) + "\n")
+		}
+		writeIndent(buffer, depth)
+		buffer.WriteString("}\n")
+	}
+}
+
+func writeIndent(buffer *bytes.Buffer, depth int) {
+	buffer.WriteString(strings.Repeat(indentation, depth))
+}
+
+func isMessage(field protoreflect.FieldDescriptor) bool {
+	return field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind
+}
+
+func sortedKeys(entries protoreflect.Map) []protoreflect.MapKey {
+	keys := make([]protoreflect.MapKey, 0, entries.Len())
+	entries.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, key)
+		return true
+	})
+	sort.Slice(keys, func(i, j int) bool {
+		return lessKey(keys[i].Interface(), keys[j].Interface())
+	})
+	return keys
+}
+
+func lessKey(a, b any) bool {
+	switch a := a.(type) {
+	case string:
+		return a < b.(string)
+	case int32:
+		return a < b.(int32)
+	case int64:
+		return a < b.(int64)
+	case uint32:
+		return a < b.(uint32)
+	case uint64:
+		return a < b.(uint64)
+	case bool:
+		return !a && b.(bool)
+	default:
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	}
+}
+
+func formatList(field protoreflect.FieldDescriptor, list protoreflect.List) string {
+	if list.Len() == 0 {
+		return "[ ]"
+	}
+	values := make([]string, list.Len())
+	for i := range values {
+		values[i] = formatScalar(field, list.Get(i))
+	}
+	return "[ " + strings.Join(values, ", ") + " ]"
+}
+
+// formatScalar formats a scalar value in the protobuf text format.
+func formatScalar(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(value.Bool())
+	case protoreflect.EnumKind:
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			return string(enumValue.Name())
+		}
+		return strconv.FormatInt(int64(value.Enum()), 10)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(value.Int(), 10)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconv.FormatUint(value.Uint(), 10)
+	case protoreflect.FloatKind:
+		return formatFloat(value.Float(), 32)
+	case protoreflect.DoubleKind:
+		return formatFloat(value.Float(), 64)
+	case protoreflect.StringKind:
+		return quote([]byte(value.String()))
+	case protoreflect.BytesKind:
+		return quote(value.Bytes())
+	default:
+		return fmt.Sprint(value.Interface())
+	}
+}
+
+func formatFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, bitSize)
+}
+
+// quote returns s as a double quoted text format string, escaping the bytes that are not printable UTF-8.
+func quote(s []byte) string {
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for len(s) > 0 {
+		r, size := utf8.DecodeRune(s)
+		switch {
+		case r == '"' || r == '\\':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case r == '\n':
+			builder.WriteString(`\n`)
+		case r == '\r':
+			builder.WriteString(`\r`)
+		case r == '\t':
+			builder.WriteString(`\t`)
+		case r == utf8.RuneError && size == 1, r < 0x20, r == 0x7f:
+			fmt.Fprintf(&builder, `\%03o`, s[0])
+		default:
+			builder.Write(s[:size])
+		}
+		s = s[size:]
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
@@ -0,0 +1,15 @@
+package models
+
+// LoadModelRequest describes a model load. Each client encodes it as its protocol expects,
+// e.g. the files are sent base64 encoded over HTTP and as bytes over gRPC.
+type LoadModelRequest struct {
+	// Config overrides the model configuration, in Triton's JSON format.
+	Config string
+	// Files maps the "file:<version>/<name>" paths of the model files to their raw content.
+	Files map[string][]byte
+	// Versions loads only the given versions, by setting a specific version policy on Config. Requires Config.
+	Versions []int64
+	// Parameters are sent along as additional load parameters.
+	// Values must be a string, a bool, an int, int32 or int64, or a []byte.
+	Parameters map[string]any
+}
@@ -1,8 +1,22 @@
 package models
 
+// ModelState is the state of a model version in the model repository index.
+type ModelState string
+
+const (
+	// ModelStateReady is the state of a model version ready for inferencing.
+	ModelStateReady ModelState = "READY"
+	// ModelStateUnavailable is the state of a model version that is not loaded or failed to load.
+	ModelStateUnavailable ModelState = "UNAVAILABLE"
+	// ModelStateLoading is the state of a model version being loaded.
+	ModelStateLoading ModelState = "LOADING"
+	// ModelStateUnloading is the state of a model version being unloaded.
+	ModelStateUnloading ModelState = "UNLOADING"
+)
+
 type ModelRepositoryIndexResponse struct {
-	Name    string `json:"name"`
-	Version string `json:"version"`
-	State   string `json:"state"`
-	Reason  string `json:"reason"`
+	Name    string     `json:"name"`
+	Version string     `json:"version"`
+	State   ModelState `json:"state"`
+	Reason  string     `json:"reason"`
 }
@@ -3,4 +3,6 @@ package options
 type Options struct {
 	Headers     map[string]string
 	QueryParams map[string]string
+	// ReadyOnly limits GetModelRepositoryIndex to the models ready for inferencing.
+	ReadyOnly bool
 }
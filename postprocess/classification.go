@@ -0,0 +1,232 @@
+package postprocess
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/models"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Activation names the function turning the logits of a classifier into scores.
+type Activation string
+
+const (
+	// ActivationSoftmax turns the logits into probabilities summing to 1, for single-label classifiers.
+	ActivationSoftmax Activation = "softmax"
+	// ActivationSigmoid turns every logit into an independent probability, for multi-label classifiers.
+	ActivationSigmoid Activation = "sigmoid"
+	// ActivationNone keeps the logits as scores.
+	ActivationNone Activation = "none"
+)
+
+// Prediction is a class predicted for a batch item.
+type Prediction[T float32 | float64] struct {
+	// Index is the index of the class in the output of the model.
+	Index int
+	// Label is the label of the class, empty without labels.
+	Label string
+	// Score is the score of the class after the activation.
+	Score T
+}
+
+// ClassificationOptions configures Classify.
+type ClassificationOptions struct {
+	// Activation turns the logits into scores. Defaults to ActivationSoftmax.
+	Activation Activation
+	// Temperature divides the logits before the activation, softening the scores above 1 and sharpening them
+	// below. Defaults to 1.
+	Temperature float64
+	// TopK keeps the k highest scores of every batch item. Defaults to all the classes.
+	TopK int
+	// Threshold keeps the scores greater than or equal to it, when set.
+	Threshold *float64
+	// Labels maps the class indices to labels, such as the ones loaded by LoadLabels.
+	Labels []string
+}
+
+// Softmax returns the softmax of every row of logits.
+func Softmax[T float32 | float64](logits [][]T) [][]T {
+	scores := make([][]T, len(logits))
+	for i, row := range logits {
+		scores[i] = make([]T, len(row))
+		if len(row) == 0 {
+			continue
+		}
+		// Subtracting the maximum keeps the exponentials from overflowing.
+		maximum := row[0]
+		for _, logit := range row {
+			maximum = max(maximum, logit)
+		}
+		var sum float64
+		for j, logit := range row {
+			exp := math.Exp(float64(logit - maximum))
+			scores[i][j] = T(exp)
+			sum += exp
+		}
+		for j := range scores[i] {
+			scores[i][j] = T(float64(scores[i][j]) / sum)
+		}
+	}
+	return scores
+}
+
+// Sigmoid returns the sigmoid of every logit.
+func Sigmoid[T float32 | float64](logits [][]T) [][]T {
+	scores := make([][]T, len(logits))
+	for i, row := range logits {
+		scores[i] = make([]T, len(row))
+		for j, logit := range row {
+			scores[i][j] = T(1 / (1 + math.Exp(-float64(logit))))
+		}
+	}
+	return scores
+}
+
+// ScaleTemperature returns the logits divided by temperature.
+func ScaleTemperature[T float32 | float64](logits [][]T, temperature float64) ([][]T, error) {
+	if temperature <= 0 {
+		return nil, errors.New("temperature must be positive")
+	}
+
+	scaled := make([][]T, len(logits))
+	for i, row := range logits {
+		scaled[i] = make([]T, len(row))
+		for j, logit := range row {
+			scaled[i][j] = T(float64(logit) / temperature)
+		}
+	}
+	return scaled, nil
+}
+
+// TopKPredictions returns the k highest scores of every batch item, highest first, labeled with labels when given.
+func TopKPredictions[T float32 | float64](scores [][]T, k int, labels []string) ([][]Prediction[T], error) {
+	top, err := TopK(scores, k)
+	if err != nil {
+		return nil, err
+	}
+
+	predictions := make([][]Prediction[T], len(top))
+	for i, neighbors := range top {
+		predictions[i] = make([]Prediction[T], 0, len(neighbors))
+		for _, neighbor := range neighbors {
+			prediction, err := predict(neighbor.Index, neighbor.Score, labels)
+			if err != nil {
+				return nil, err
+			}
+			predictions[i] = append(predictions[i], prediction)
+		}
+	}
+	return predictions, nil
+}
+
+// ThresholdPredictions returns the scores greater than or equal to threshold of every batch item, highest first,
+// labeled with labels when given. Batch items without such score get no prediction.
+func ThresholdPredictions[T float32 | float64](scores [][]T, threshold T, labels []string) ([][]Prediction[T], error) {
+	all, err := TopKPredictions(scores, math.MaxInt, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	predictions := make([][]Prediction[T], len(all))
+	for i, row := range all {
+		predictions[i] = []Prediction[T]{}
+		for _, prediction := range row {
+			if prediction.Score >= threshold {
+				predictions[i] = append(predictions[i], prediction)
+			}
+		}
+	}
+	return predictions, nil
+}
+
+// Classify turns the logits of a classifier, indexed [batch item][class], into the predictions of every batch item,
+// highest score first.
+func Classify[T float32 | float64](logits [][]T, classificationOptions *ClassificationOptions) ([][]Prediction[T], error) {
+	if classificationOptions == nil {
+		classificationOptions = &ClassificationOptions{}
+	}
+
+	scores := logits
+	if classificationOptions.Temperature != 0 {
+		var err error
+		if scores, err = ScaleTemperature(logits, classificationOptions.Temperature); err != nil {
+			return nil, err
+		}
+	}
+	switch classificationOptions.Activation {
+	case ActivationSoftmax, "":
+		scores = Softmax(scores)
+	case ActivationSigmoid:
+		scores = Sigmoid(scores)
+	case ActivationNone:
+	default:
+		return nil, fmt.Errorf("unknown activation '%s'", classificationOptions.Activation)
+	}
+
+	var predictions [][]Prediction[T]
+	var err error
+	if classificationOptions.Threshold != nil {
+		predictions, err = ThresholdPredictions(scores, T(*classificationOptions.Threshold), classificationOptions.Labels)
+	} else {
+		predictions, err = TopKPredictions(scores, math.MaxInt, classificationOptions.Labels)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if classificationOptions.TopK > 0 {
+		for i, row := range predictions {
+			predictions[i] = row[:min(classificationOptions.TopK, len(row))]
+		}
+	}
+	return predictions, nil
+}
+
+func predict[T float32 | float64](index int, score T, labels []string) (Prediction[T], error) {
+	prediction := Prediction[T]{Index: index, Score: score}
+	if labels != nil {
+		if index >= len(labels) {
+			return Prediction[T]{}, fmt.Errorf("no label for class %d, got %d labels", index, len(labels))
+		}
+		prediction.Label = labels[index]
+	}
+	return prediction, nil
+}
+
+// LoadLabels reads a label file in the format of Triton, one label per line in the order of the classes.
+func LoadLabels(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var labels []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		labels = append(labels, strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading labels from '%s': %w", path, err)
+	}
+	return labels, nil
+}
+
+// LoadModelLabels reads the label file of the output named outputName of a model configuration, e.g. returned by
+// GetModelConfig. The label_filename of the output is relative to modelDirectory, the directory of the model in
+// the model repository.
+func LoadModelLabels(config *models.ModelConfigResponse, outputName string, modelDirectory string) ([]string, error) {
+	for _, output := range config.Output {
+		if output.Name != outputName {
+			continue
+		}
+		if output.LabelFilename == "" {
+			return nil, fmt.Errorf("output '%s' of model '%s' has no label_filename", outputName, config.Name)
+		}
+		return LoadLabels(filepath.Join(modelDirectory, output.LabelFilename))
+	}
+	return nil, fmt.Errorf("model '%s' has no output '%s'", config.Name, outputName)
+}
@@ -0,0 +1,147 @@
+package postprocess
+
+import (
+	"github.com/Trendyol/go-triton-client/models"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSoftmax(t *testing.T) {
+	result := Softmax([][]float64{{0, math.Log(3)}, {1000, 1000}, {}})
+	expected := [][]float64{{0.25, 0.75}, {0.5, 0.5}, {}}
+	if !almostEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	result32 := Softmax([][]float32{{1, 1, 1, 1}})
+	if !almostEqual(result32, [][]float32{{0.25, 0.25, 0.25, 0.25}}) {
+		t.Errorf("Expected uniform probabilities, got %v", result32)
+	}
+}
+
+func TestSigmoid(t *testing.T) {
+	result := Sigmoid([][]float32{{0, float32(math.Log(3)), -float32(math.Log(3))}})
+	expected := [][]float32{{0.5, 0.75, 0.25}}
+	if !almostEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestScaleTemperature(t *testing.T) {
+	result, err := ScaleTemperature([][]float64{{2, -4}}, 2)
+	if err != nil {
+		t.Fatalf("ScaleTemperature returned an error: %v", err)
+	}
+	if !reflect.DeepEqual(result, [][]float64{{1, -2}}) {
+		t.Errorf("Expected [[1 -2]], got %v", result)
+	}
+	if _, err := ScaleTemperature([][]float64{{1}}, 0); err == nil {
+		t.Errorf("Expected an error for temperature 0")
+	}
+}
+
+func TestTopKPredictions(t *testing.T) {
+	scores := [][]float64{{0.1, 0.7, 0.2}}
+	labels := []string{"negative", "positive", "neutral"}
+	expected := [][]Prediction[float64]{{
+		{Index: 1, Label: "positive", Score: 0.7},
+		{Index: 2, Label: "neutral", Score: 0.2},
+	}}
+	result, err := TopKPredictions(scores, 2, labels)
+	if err != nil {
+		t.Fatalf("TopKPredictions returned an error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	if _, err := TopKPredictions(scores, 2, labels[:1]); err == nil || err.Error() != "no label for class 1, got 1 labels" {
+		t.Errorf("Expected missing label error, got %v", err)
+	}
+}
+
+func TestThresholdPredictions(t *testing.T) {
+	scores := [][]float32{{0.9, 0.2, 0.6}, {0.1, 0.3, 0.2}}
+	expected := [][]Prediction[float32]{
+		{{Index: 0, Score: 0.9}, {Index: 2, Score: 0.6}},
+		{},
+	}
+	result, err := ThresholdPredictions(scores, 0.5, nil)
+	if err != nil {
+		t.Fatalf("ThresholdPredictions returned an error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	logits := [][]float64{{0, math.Log(3)}, {math.Log(4), 0}}
+	labels := []string{"ham", "spam"}
+
+	result, err := Classify(logits, &ClassificationOptions{TopK: 1, Labels: labels})
+	if err != nil {
+		t.Fatalf("Classify returned an error: %v", err)
+	}
+	if len(result) != 2 || len(result[0]) != 1 || result[0][0].Label != "spam" || math.Abs(result[0][0].Score-0.75) > 1e-9 ||
+		result[1][0].Label != "ham" || math.Abs(result[1][0].Score-0.8) > 1e-9 {
+		t.Errorf("Expected spam 0.75 and ham 0.8, got %v", result)
+	}
+
+	threshold := 0.5
+	result, err = Classify(logits, &ClassificationOptions{Activation: ActivationSigmoid, Temperature: 0.5, Threshold: &threshold})
+	if err != nil {
+		t.Fatalf("Classify returned an error: %v", err)
+	}
+	// sigmoid(2 * log(3)) = 0.9 and sigmoid(2 * log(4)) = 16 / 17, the zero logits score 0.5.
+	expected := [][]Prediction[float64]{
+		{{Index: 1, Score: 0.9}, {Index: 0, Score: 0.5}},
+		{{Index: 0, Score: 16.0 / 17.0}, {Index: 1, Score: 0.5}},
+	}
+	for i := range expected {
+		for j := range expected[i] {
+			if result[i][j].Index != expected[i][j].Index || math.Abs(result[i][j].Score-expected[i][j].Score) > 1e-9 {
+				t.Errorf("Expected %v, got %v", expected, result)
+			}
+		}
+	}
+
+	if _, err := Classify(logits, &ClassificationOptions{Activation: "relu"}); err == nil || err.Error() != "unknown activation 'relu'" {
+		t.Errorf("Expected unknown activation error, got %v", err)
+	}
+}
+
+func TestLoadModelLabels(t *testing.T) {
+	directory := t.TempDir()
+	if err := os.WriteFile(filepath.Join(directory, "labels.txt"), []byte("negative\r\npositive\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := &models.ModelConfigResponse{
+		Name: "sentiment",
+		Output: []models.ModelConfigOutput{
+			{Name: "embeddings"},
+			{Name: "logits", LabelFilename: "labels.txt"},
+		},
+	}
+
+	labels, err := LoadModelLabels(config, "logits", directory)
+	if err != nil {
+		t.Fatalf("LoadModelLabels returned an error: %v", err)
+	}
+	if !reflect.DeepEqual(labels, []string{"negative", "positive"}) {
+		t.Errorf("Expected [negative positive], got %v", labels)
+	}
+
+	if _, err := LoadModelLabels(config, "embeddings", directory); err == nil || err.Error() != "output 'embeddings' of model 'sentiment' has no label_filename" {
+		t.Errorf("Expected missing label_filename error, got %v", err)
+	}
+	if _, err := LoadModelLabels(config, "scores", directory); err == nil || err.Error() != "model 'sentiment' has no output 'scores'" {
+		t.Errorf("Expected missing output error, got %v", err)
+	}
+	if _, err := LoadLabels(filepath.Join(directory, "missing.txt")); err == nil {
+		t.Errorf("Expected an error for a missing file")
+	}
+}
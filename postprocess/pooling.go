@@ -0,0 +1,172 @@
+package postprocess
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PoolingStrategy names a way of pooling token embeddings, so that it can be read from a configuration.
+type PoolingStrategy string
+
+const (
+	// PoolingMean averages the token embeddings.
+	PoolingMean PoolingStrategy = "mean"
+	// PoolingWeightedMean averages the token embeddings weighted by their position, 1 for the first one.
+	PoolingWeightedMean PoolingStrategy = "weighted_mean"
+	// PoolingMax takes the maximum of every dimension over the tokens.
+	PoolingMax PoolingStrategy = "max"
+	// PoolingCLS takes the embedding of the first token.
+	PoolingCLS PoolingStrategy = "cls"
+	// PoolingLastToken takes the embedding of the last token.
+	PoolingLastToken PoolingStrategy = "last_token"
+)
+
+// ParsePoolingStrategy returns the strategy with the given name, ignoring case.
+func ParsePoolingStrategy(name string) (PoolingStrategy, error) {
+	strategy := PoolingStrategy(strings.ToLower(strings.TrimSpace(name)))
+	switch strategy {
+	case PoolingMean, PoolingWeightedMean, PoolingMax, PoolingCLS, PoolingLastToken:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("unknown pooling strategy '%s'", name)
+	}
+}
+
+// Pool pools token embeddings, indexed [batch item][token][dimension], into one embedding per batch item with
+// the given strategy. The padded positions, whose attention mask is 0, are ignored whether the sequences are
+// padded on the left or the right.
+func Pool[T float32 | float64](strategy PoolingStrategy, tokenEmbeddings [][][]T, attentionMask [][]int64) ([][]T, error) {
+	switch strategy {
+	case PoolingMean:
+		return performMeanPooling(tokenEmbeddings, attentionMask)
+	case PoolingWeightedMean:
+		return performWeightedMeanPooling(tokenEmbeddings, attentionMask)
+	case PoolingMax:
+		return performMaxPooling(tokenEmbeddings, attentionMask)
+	case PoolingCLS:
+		return performTokenPooling(tokenEmbeddings, attentionMask, firstToken)
+	case PoolingLastToken:
+		return performTokenPooling(tokenEmbeddings, attentionMask, lastToken)
+	default:
+		return nil, fmt.Errorf("unknown pooling strategy '%s'", strategy)
+	}
+}
+
+// validatePoolingInputs checks the inputs of a pooling and returns their dimensions.
+func validatePoolingInputs[T float32 | float64](tokenEmbeddings [][][]T, attentionMask [][]int64) (int, int, int, error) {
+	if len(tokenEmbeddings) == 0 || len(attentionMask) == 0 || len(tokenEmbeddings[0]) == 0 {
+		return 0, 0, 0, errors.New("empty input slices")
+	}
+
+	batchSize := len(tokenEmbeddings)
+	sequenceLength := len(tokenEmbeddings[0])
+	if err := validateDimensions(tokenEmbeddings, attentionMask, batchSize, sequenceLength); err != nil {
+		return 0, 0, 0, err
+	}
+	embeddingSize := len(tokenEmbeddings[0][0])
+	for i := range tokenEmbeddings {
+		for j := range tokenEmbeddings[i] {
+			if len(tokenEmbeddings[i][j]) != embeddingSize {
+				return 0, 0, 0, errors.New("mismatched embedding size in tokenEmbeddings")
+			}
+		}
+	}
+	return batchSize, sequenceLength, embeddingSize, nil
+}
+
+// performWeightedMeanPooling averages the token embeddings weighted by their position among the unmasked tokens,
+// counted from 1 at the first unmasked token, so left padding doesn't change the result.
+func performWeightedMeanPooling[T float32 | float64](tokenEmbeddings [][][]T, attentionMask [][]int64) ([][]T, error) {
+	batchSize, sequenceLength, embeddingSize, err := validatePoolingInputs(tokenEmbeddings, attentionMask)
+	if err != nil {
+		return nil, err
+	}
+
+	pooled := make([][]T, batchSize)
+	for i := 0; i < batchSize; i++ {
+		pooled[i] = make([]T, embeddingSize)
+		var sumWeights T
+		var position int64
+		for j := 0; j < sequenceLength; j++ {
+			position += attentionMask[i][j]
+			weight := T(position) * T(attentionMask[i][j])
+			sumWeights += weight
+			for k := 0; k < embeddingSize; k++ {
+				pooled[i][k] += tokenEmbeddings[i][j][k] * weight
+			}
+		}
+		sumWeights = max(sumWeights, T(1e-9))
+		for k := 0; k < embeddingSize; k++ {
+			pooled[i][k] /= sumWeights
+		}
+	}
+	return pooled, nil
+}
+
+// performMaxPooling takes the maximum of every dimension over the unmasked tokens.
+// A sequence without unmasked token pools to zeros.
+func performMaxPooling[T float32 | float64](tokenEmbeddings [][][]T, attentionMask [][]int64) ([][]T, error) {
+	batchSize, sequenceLength, embeddingSize, err := validatePoolingInputs(tokenEmbeddings, attentionMask)
+	if err != nil {
+		return nil, err
+	}
+
+	pooled := make([][]T, batchSize)
+	for i := 0; i < batchSize; i++ {
+		pooled[i] = make([]T, embeddingSize)
+		found := false
+		for j := 0; j < sequenceLength; j++ {
+			if attentionMask[i][j] == 0 {
+				continue
+			}
+			for k := 0; k < embeddingSize; k++ {
+				if !found || tokenEmbeddings[i][j][k] > pooled[i][k] {
+					pooled[i][k] = tokenEmbeddings[i][j][k]
+				}
+			}
+			found = true
+		}
+	}
+	return pooled, nil
+}
+
+// firstToken returns the position of the first unmasked token, which is the first position of right-padded
+// sequences, or -1 when all tokens are masked.
+func firstToken(attentionMask []int64) int {
+	for j, mask := range attentionMask {
+		if mask != 0 {
+			return j
+		}
+	}
+	return -1
+}
+
+// lastToken returns the position of the last unmasked token, which is the last position of left-padded
+// sequences, or -1 when all tokens are masked.
+func lastToken(attentionMask []int64) int {
+	for j := len(attentionMask) - 1; j >= 0; j-- {
+		if attentionMask[j] != 0 {
+			return j
+		}
+	}
+	return -1
+}
+
+// performTokenPooling takes the embedding of the token at the position returned by position.
+// A sequence without unmasked token pools to zeros.
+func performTokenPooling[T float32 | float64](tokenEmbeddings [][][]T, attentionMask [][]int64, position func(attentionMask []int64) int) ([][]T, error) {
+	batchSize, _, embeddingSize, err := validatePoolingInputs(tokenEmbeddings, attentionMask)
+	if err != nil {
+		return nil, err
+	}
+
+	pooled := make([][]T, batchSize)
+	for i := 0; i < batchSize; i++ {
+		pooled[i] = make([]T, embeddingSize)
+		if j := position(attentionMask[i]); j >= 0 {
+			copy(pooled[i], tokenEmbeddings[i][j])
+		}
+	}
+	return pooled, nil
+}
@@ -0,0 +1,172 @@
+package postprocess
+
+import (
+	"reflect"
+	"testing"
+)
+
+// paddedEmbeddings holds a right-padded and a left-padded sequence of three tokens.
+var paddedEmbeddings = [][][]float64{
+	{
+		{1.0, 6.0},
+		{4.0, 2.0},
+		{9.0, 9.0},
+	},
+	{
+		{9.0, 9.0},
+		{2.0, 8.0},
+		{5.0, 3.0},
+	},
+}
+
+var paddedMask = [][]int64{
+	{1, 1, 0},
+	{0, 1, 1},
+}
+
+func cls[T float32 | float64](tokenEmbeddings [][][]T, attentionMask [][]int64) ([][]T, error) {
+	return performTokenPooling(tokenEmbeddings, attentionMask, firstToken)
+}
+
+func last[T float32 | float64](tokenEmbeddings [][][]T, attentionMask [][]int64) ([][]T, error) {
+	return performTokenPooling(tokenEmbeddings, attentionMask, lastToken)
+}
+
+func TestPoolingStrategiesFloat64(t *testing.T) {
+	pm := NewPostprocessManager()
+	tests := []struct {
+		strategy PoolingStrategy
+		pool     func([][][]float64, [][]int64) ([][]float64, error)
+		expected [][]float64
+	}{
+		{PoolingMean, pm.MeanPoolingFloat64Slice3D, [][]float64{{2.5, 4.0}, {3.5, 5.5}}},
+		{PoolingWeightedMean, performWeightedMeanPooling[float64], [][]float64{{3.0, 10.0 / 3.0}, {4.0, 14.0 / 3.0}}},
+		{PoolingMax, performMaxPooling[float64], [][]float64{{4.0, 6.0}, {5.0, 8.0}}},
+		{PoolingCLS, cls[float64], [][]float64{{1.0, 6.0}, {2.0, 8.0}}},
+		{PoolingLastToken, last[float64], [][]float64{{4.0, 2.0}, {5.0, 3.0}}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			result, err := tt.pool(paddedEmbeddings, paddedMask)
+			if err != nil {
+				t.Fatalf("pooling returned an error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+
+			result, err = Pool(tt.strategy, paddedEmbeddings, paddedMask)
+			if err != nil {
+				t.Fatalf("Pool returned an error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Pool expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPoolingStrategiesFloat32(t *testing.T) {
+	pm := NewPostprocessManager()
+	tokenEmbeddings := pm.Float64ToFloat32Slice3D(paddedEmbeddings)
+	tests := []struct {
+		strategy PoolingStrategy
+		pool     func([][][]float32, [][]int64) ([][]float32, error)
+		expected [][]float32
+	}{
+		{PoolingWeightedMean, performWeightedMeanPooling[float32], [][]float32{{3.0, 10.0 / 3.0}, {4.0, 14.0 / 3.0}}},
+		{PoolingMax, performMaxPooling[float32], [][]float32{{4.0, 6.0}, {5.0, 8.0}}},
+		{PoolingCLS, cls[float32], [][]float32{{1.0, 6.0}, {2.0, 8.0}}},
+		{PoolingLastToken, last[float32], [][]float32{{4.0, 2.0}, {5.0, 3.0}}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			result, err := tt.pool(tokenEmbeddings, paddedMask)
+			if err != nil {
+				t.Fatalf("pooling returned an error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+
+			result, err = Pool(tt.strategy, tokenEmbeddings, paddedMask)
+			if err != nil {
+				t.Fatalf("Pool returned an error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Pool expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPoolingLeftPaddingDoesNotChangeResult(t *testing.T) {
+	for _, strategy := range []PoolingStrategy{PoolingMean, PoolingWeightedMean, PoolingMax, PoolingCLS, PoolingLastToken} {
+		unpadded, err := Pool(strategy, [][][]float64{paddedEmbeddings[1][1:]}, [][]int64{{1, 1}})
+		if err != nil {
+			t.Fatalf("%s pooling returned an error: %v", strategy, err)
+		}
+		leftPadded, err := Pool(strategy, paddedEmbeddings[1:], paddedMask[1:])
+		if err != nil {
+			t.Fatalf("%s pooling returned an error: %v", strategy, err)
+		}
+		if !reflect.DeepEqual(leftPadded, unpadded) {
+			t.Errorf("%s pooling of the left-padded sequence expected %v, got %v", strategy, unpadded, leftPadded)
+		}
+	}
+}
+
+func TestPoolingFullyMaskedSequence(t *testing.T) {
+	tokenEmbeddings := [][][]float64{
+		{
+			{-1.0, -2.0},
+			{-3.0, -4.0},
+		},
+	}
+	attentionMask := [][]int64{
+		{0, 0},
+	}
+	for _, strategy := range []PoolingStrategy{PoolingWeightedMean, PoolingMax, PoolingCLS, PoolingLastToken} {
+		result, err := Pool(strategy, tokenEmbeddings, attentionMask)
+		if err != nil {
+			t.Errorf("%s pooling returned an error: %v", strategy, err)
+		}
+		if !reflect.DeepEqual(result, [][]float64{{0, 0}}) {
+			t.Errorf("%s pooling expected [[0 0]], got %v", strategy, result)
+		}
+	}
+}
+
+func TestPoolingInvalidInputs(t *testing.T) {
+	if _, err := Pool("sum", paddedEmbeddings, paddedMask); err == nil || err.Error() != "unknown pooling strategy 'sum'" {
+		t.Errorf("Expected unknown pooling strategy error, got %v", err)
+	}
+	if _, err := performMaxPooling[float64]([][][]float64{}, [][]int64{}); err == nil || err.Error() != "empty input slices" {
+		t.Errorf("Expected empty input slices error, got %v", err)
+	}
+	if _, err := cls[float64](paddedEmbeddings, paddedMask[:1]); err == nil || err.Error() != "mismatched batch size between tokenEmbeddings and attentionMask" {
+		t.Errorf("Expected mismatched batch size error, got %v", err)
+	}
+	ragged := [][][]float64{{{1.0, 2.0}, {3.0}}}
+	if _, err := last[float64](ragged, [][]int64{{1, 1}}); err == nil || err.Error() != "mismatched embedding size in tokenEmbeddings" {
+		t.Errorf("Expected mismatched embedding size error, got %v", err)
+	}
+}
+
+func TestParsePoolingStrategy(t *testing.T) {
+	for name, expected := range map[string]PoolingStrategy{
+		"mean":          PoolingMean,
+		"Weighted_Mean": PoolingWeightedMean,
+		" max ":         PoolingMax,
+		"CLS":           PoolingCLS,
+		"last_token":    PoolingLastToken,
+	} {
+		strategy, err := ParsePoolingStrategy(name)
+		if err != nil || strategy != expected {
+			t.Errorf("ParsePoolingStrategy(%q) = %q, %v, expected %q", name, strategy, err, expected)
+		}
+	}
+	if _, err := ParsePoolingStrategy("sum"); err == nil {
+		t.Errorf("Expected an error for an unknown strategy")
+	}
+}
@@ -0,0 +1,177 @@
+package postprocess
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// SimilarityMetric names a way of scoring the similarity of two embeddings.
+type SimilarityMetric string
+
+const (
+	// SimilarityCosine scores the cosine of the angle between the embeddings.
+	SimilarityCosine SimilarityMetric = "cosine"
+	// SimilarityDot scores the dot product of the embeddings, which is their cosine when they are L2-normalized.
+	SimilarityDot SimilarityMetric = "dot"
+)
+
+// Neighbor is an embedding of the corpus close to a query.
+type Neighbor[T float32 | float64] struct {
+	// Index is the position of the embedding in the corpus.
+	Index int
+	// Score is the similarity of the embedding to the query.
+	Score T
+}
+
+// NormalizeL2 returns the vectors scaled to a Euclidean norm of 1. Zero vectors are returned as they are.
+func NormalizeL2[T float32 | float64](vectors [][]T) [][]T {
+	return normalize(vectors, func(vector []T) float64 {
+		var sum float64
+		for _, value := range vector {
+			sum += float64(value) * float64(value)
+		}
+		return math.Sqrt(sum)
+	})
+}
+
+// NormalizeL1 returns the vectors scaled to a sum of absolute values of 1. Zero vectors are returned as they are.
+func NormalizeL1[T float32 | float64](vectors [][]T) [][]T {
+	return normalize(vectors, func(vector []T) float64 {
+		var sum float64
+		for _, value := range vector {
+			sum += math.Abs(float64(value))
+		}
+		return sum
+	})
+}
+
+func normalize[T float32 | float64](vectors [][]T, norm func([]T) float64) [][]T {
+	normalized := make([][]T, len(vectors))
+	for i, vector := range vectors {
+		normalized[i] = make([]T, len(vector))
+		n := norm(vector)
+		if n == 0 {
+			copy(normalized[i], vector)
+			continue
+		}
+		for j, value := range vector {
+			normalized[i][j] = T(float64(value) / n)
+		}
+	}
+	return normalized
+}
+
+// DotProductMatrix returns the dot product of every embedding of a with every embedding of b,
+// indexed [index in a][index in b].
+func DotProductMatrix[T float32 | float64](a, b [][]T) ([][]T, error) {
+	if err := validateEmbeddings(a, b); err != nil {
+		return nil, err
+	}
+
+	scores := make([][]T, len(a))
+	for i, x := range a {
+		scores[i] = make([]T, len(b))
+		for j, y := range b {
+			scores[i][j] = dot(x, y)
+		}
+	}
+	return scores, nil
+}
+
+// CosineSimilarityMatrix returns the cosine similarity of every embedding of a with every embedding of b,
+// indexed [index in a][index in b]. The similarity with a zero vector is 0.
+func CosineSimilarityMatrix[T float32 | float64](a, b [][]T) ([][]T, error) {
+	if err := validateEmbeddings(a, b); err != nil {
+		return nil, err
+	}
+	return DotProductMatrix(NormalizeL2(a), NormalizeL2(b))
+}
+
+// SimilarityMatrix returns the similarity of every embedding of a with every embedding of b with the given metric.
+func SimilarityMatrix[T float32 | float64](a, b [][]T, metric SimilarityMetric) ([][]T, error) {
+	switch metric {
+	case SimilarityCosine:
+		return CosineSimilarityMatrix(a, b)
+	case SimilarityDot:
+		return DotProductMatrix(a, b)
+	default:
+		return nil, fmt.Errorf("unknown similarity metric '%s'", metric)
+	}
+}
+
+// TopK returns the k highest scores of every row of scores with their column, highest first.
+// The columns of equal scores are in increasing order.
+func TopK[T float32 | float64](scores [][]T, k int) ([][]Neighbor[T], error) {
+	if k <= 0 {
+		return nil, errors.New("k must be positive")
+	}
+
+	top := make([][]Neighbor[T], len(scores))
+	for i, row := range scores {
+		neighbors := make([]Neighbor[T], len(row))
+		for j, score := range row {
+			neighbors[j] = Neighbor[T]{Index: j, Score: score}
+		}
+		slices.SortStableFunc(neighbors, func(x, y Neighbor[T]) int {
+			return cmp.Compare(y.Score, x.Score)
+		})
+		top[i] = neighbors[:min(k, len(neighbors))]
+	}
+	return top, nil
+}
+
+// NearestNeighbors returns the k embeddings of corpus most similar to every query, most similar first.
+func NearestNeighbors[T float32 | float64](queries, corpus [][]T, k int, metric SimilarityMetric) ([][]Neighbor[T], error) {
+	scores, err := SimilarityMatrix(queries, corpus, metric)
+	if err != nil {
+		return nil, err
+	}
+	return TopK(scores, k)
+}
+
+// TruncateMatryoshka keeps the first dimension values of embeddings trained with Matryoshka representation
+// learning, and re-normalizes them to unit L2 norm when renormalize is set, as their prefix isn't normalized.
+func TruncateMatryoshka[T float32 | float64](embeddings [][]T, dimension int, renormalize bool) ([][]T, error) {
+	if dimension <= 0 {
+		return nil, errors.New("dimension must be positive")
+	}
+
+	truncated := make([][]T, len(embeddings))
+	for i, embedding := range embeddings {
+		if len(embedding) < dimension {
+			return nil, fmt.Errorf("embedding %d has %d dimensions, fewer than %d", i, len(embedding), dimension)
+		}
+		truncated[i] = slices.Clone(embedding[:dimension])
+	}
+	if renormalize {
+		return NormalizeL2(truncated), nil
+	}
+	return truncated, nil
+}
+
+// validateEmbeddings checks that the embeddings of a and b all have the same dimension.
+func validateEmbeddings[T float32 | float64](a, b [][]T) error {
+	dimension := -1
+	for _, embeddings := range [][][]T{a, b} {
+		for _, embedding := range embeddings {
+			if dimension < 0 {
+				dimension = len(embedding)
+			}
+			if len(embedding) != dimension {
+				return fmt.Errorf("mismatched embedding dimensions %d and %d", dimension, len(embedding))
+			}
+		}
+	}
+	return nil
+}
+
+func dot[T float32 | float64](x, y []T) T {
+	var sum T
+	for i := range x {
+		sum += x[i] * y[i]
+	}
+	return sum
+}
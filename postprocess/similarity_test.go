@@ -0,0 +1,230 @@
+package postprocess
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func almostEqual[T float32 | float64](a, b [][]T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if math.Abs(float64(a[i][j]-b[i][j])) > 1e-6 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestNormalizeL2(t *testing.T) {
+	input := [][]float64{{3, 4}, {0, 0}, {-2, 0}}
+	expected := [][]float64{{0.6, 0.8}, {0, 0}, {-1, 0}}
+	result := NormalizeL2(input)
+	if !almostEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if input[0][0] != 3 {
+		t.Errorf("NormalizeL2 modified its input: %v", input)
+	}
+
+	result32 := NormalizeL2([][]float32{{3, 4}})
+	if !almostEqual(result32, [][]float32{{0.6, 0.8}}) {
+		t.Errorf("Expected [[0.6 0.8]], got %v", result32)
+	}
+}
+
+func TestNormalizeL1(t *testing.T) {
+	result := NormalizeL1([][]float32{{1, -3}, {0, 0}})
+	expected := [][]float32{{0.25, -0.75}, {0, 0}}
+	if !almostEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestDotProductMatrix(t *testing.T) {
+	a := [][]float64{{1, 2}, {3, 4}}
+	b := [][]float64{{1, 0}, {0, 1}, {1, 1}}
+	expected := [][]float64{{1, 2, 3}, {3, 4, 7}}
+	result, err := DotProductMatrix(a, b)
+	if err != nil {
+		t.Fatalf("DotProductMatrix returned an error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	if _, err := DotProductMatrix(a, [][]float64{{1, 2, 3}}); err == nil || err.Error() != "mismatched embedding dimensions 2 and 3" {
+		t.Errorf("Expected mismatched embedding dimensions error, got %v", err)
+	}
+}
+
+func TestCosineSimilarityMatrix(t *testing.T) {
+	a := [][]float32{{2, 0}, {1, 1}}
+	b := [][]float32{{5, 0}, {0, 3}, {-1, -1}, {0, 0}}
+	expected := [][]float32{
+		{1, 0, -float32(math.Sqrt2) / 2, 0},
+		{float32(math.Sqrt2) / 2, float32(math.Sqrt2) / 2, -1, 0},
+	}
+	result, err := CosineSimilarityMatrix(a, b)
+	if err != nil {
+		t.Fatalf("CosineSimilarityMatrix returned an error: %v", err)
+	}
+	if !almostEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	if _, err := SimilarityMatrix(a, b, "euclidean"); err == nil || err.Error() != "unknown similarity metric 'euclidean'" {
+		t.Errorf("Expected unknown similarity metric error, got %v", err)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	scores := [][]float64{
+		{0.1, 0.9, 0.5, 0.9},
+		{0.3},
+	}
+	expected := [][]Neighbor[float64]{
+		{{Index: 1, Score: 0.9}, {Index: 3, Score: 0.9}, {Index: 2, Score: 0.5}},
+		{{Index: 0, Score: 0.3}},
+	}
+	result, err := TopK(scores, 3)
+	if err != nil {
+		t.Fatalf("TopK returned an error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	if _, err := TopK(scores, 0); err == nil {
+		t.Errorf("Expected an error for k = 0")
+	}
+}
+
+func TestNearestNeighbors(t *testing.T) {
+	queries := [][]float32{{1, 0}, {0, 1}}
+	corpus := [][]float32{{10, 1}, {1, 10}, {1, 1}}
+
+	result, err := NearestNeighbors(queries, corpus, 2, SimilarityCosine)
+	if err != nil {
+		t.Fatalf("NearestNeighbors returned an error: %v", err)
+	}
+	if result[0][0].Index != 0 || result[0][1].Index != 2 || result[1][0].Index != 1 || result[1][1].Index != 2 {
+		t.Errorf("Expected neighbors [0 2] and [1 2], got %v", result)
+	}
+
+	result, err = NearestNeighbors(queries, corpus, 1, SimilarityDot)
+	if err != nil {
+		t.Fatalf("NearestNeighbors returned an error: %v", err)
+	}
+	expected := [][]Neighbor[float32]{{{Index: 0, Score: 10}}, {{Index: 1, Score: 10}}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestTruncateMatryoshka(t *testing.T) {
+	embeddings := [][]float64{{3, 4, 12}, {0, 5, 1}}
+
+	result, err := TruncateMatryoshka(embeddings, 2, false)
+	if err != nil {
+		t.Fatalf("TruncateMatryoshka returned an error: %v", err)
+	}
+	if !reflect.DeepEqual(result, [][]float64{{3, 4}, {0, 5}}) {
+		t.Errorf("Expected [[3 4] [0 5]], got %v", result)
+	}
+
+	result, err = TruncateMatryoshka(embeddings, 2, true)
+	if err != nil {
+		t.Fatalf("TruncateMatryoshka returned an error: %v", err)
+	}
+	if !almostEqual(result, [][]float64{{0.6, 0.8}, {0, 1}}) {
+		t.Errorf("Expected [[0.6 0.8] [0 1]], got %v", result)
+	}
+
+	if _, err := TruncateMatryoshka(embeddings, 4, true); err == nil || err.Error() != "embedding 0 has 3 dimensions, fewer than 4" {
+		t.Errorf("Expected too few dimensions error, got %v", err)
+	}
+	if _, err := TruncateMatryoshka(embeddings, 0, true); err == nil {
+		t.Errorf("Expected an error for dimension 0")
+	}
+}
+
+func randomEmbeddings[T float32 | float64](count, dimension int) [][]T {
+	random := rand.New(rand.NewSource(1))
+	embeddings := make([][]T, count)
+	for i := range embeddings {
+		embeddings[i] = make([]T, dimension)
+		for j := range embeddings[i] {
+			embeddings[i][j] = T(random.NormFloat64())
+		}
+	}
+	return embeddings
+}
+
+func BenchmarkNormalizeL2(b *testing.B) {
+	b.Run("float32", func(b *testing.B) {
+		embeddings := randomEmbeddings[float32](64, 768)
+		for i := 0; i < b.N; i++ {
+			NormalizeL2(embeddings)
+		}
+	})
+	b.Run("float64", func(b *testing.B) {
+		embeddings := randomEmbeddings[float64](64, 768)
+		for i := 0; i < b.N; i++ {
+			NormalizeL2(embeddings)
+		}
+	})
+}
+
+func BenchmarkCosineSimilarityMatrix(b *testing.B) {
+	b.Run("float32", func(b *testing.B) {
+		queries, corpus := randomEmbeddings[float32](16, 768), randomEmbeddings[float32](1000, 768)
+		for i := 0; i < b.N; i++ {
+			_, _ = CosineSimilarityMatrix(queries, corpus)
+		}
+	})
+	b.Run("float64", func(b *testing.B) {
+		queries, corpus := randomEmbeddings[float64](16, 768), randomEmbeddings[float64](1000, 768)
+		for i := 0; i < b.N; i++ {
+			_, _ = CosineSimilarityMatrix(queries, corpus)
+		}
+	})
+}
+
+func BenchmarkNearestNeighbors(b *testing.B) {
+	b.Run("float32", func(b *testing.B) {
+		queries, corpus := randomEmbeddings[float32](16, 768), randomEmbeddings[float32](1000, 768)
+		for i := 0; i < b.N; i++ {
+			_, _ = NearestNeighbors(queries, corpus, 10, SimilarityDot)
+		}
+	})
+	b.Run("float64", func(b *testing.B) {
+		queries, corpus := randomEmbeddings[float64](16, 768), randomEmbeddings[float64](1000, 768)
+		for i := 0; i < b.N; i++ {
+			_, _ = NearestNeighbors(queries, corpus, 10, SimilarityDot)
+		}
+	})
+}
+
+func BenchmarkTruncateMatryoshka(b *testing.B) {
+	b.Run("float32", func(b *testing.B) {
+		embeddings := randomEmbeddings[float32](64, 768)
+		for i := 0; i < b.N; i++ {
+			_, _ = TruncateMatryoshka(embeddings, 256, true)
+		}
+	})
+	b.Run("float64", func(b *testing.B) {
+		embeddings := randomEmbeddings[float64](64, 768)
+		for i := 0; i < b.N; i++ {
+			_, _ = TruncateMatryoshka(embeddings, 256, true)
+		}
+	})
+}
@@ -0,0 +1,291 @@
+package readiness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/base"
+	tritongrpc "github.com/Trendyol/go-triton-client/client/grpc"
+	tritonhttp "github.com/Trendyol/go-triton-client/client/http"
+	"github.com/Trendyol/go-triton-client/options"
+	"strings"
+	"time"
+)
+
+// Settings configures the waits.
+type Settings struct {
+	// InitialBackoff is the delay before the second check. Defaults to 100 milliseconds.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between checks. Defaults to 5 seconds.
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after every check. Defaults to 2.
+	Multiplier float64
+	// Options are sent with the health, metadata and warm-up calls.
+	Options *options.Options
+	// OnProgress is called after every check and warm-up.
+	OnProgress func(progress Progress)
+	// WarmUp sends one inference with zero-valued inputs to every model once it is ready.
+	// The inputs are generated from the shapes and datatypes of the model metadata.
+	WarmUp bool
+	// DynamicDimension replaces the variable dimensions (-1) of the warm-up inputs. Defaults to 1.
+	DynamicDimension int64
+	// Protocol selects the kind of warm-up inputs. Defaults to the protocol of the client,
+	// and must be set to warm up models with clients not exposing it, such as decorated clients.
+	Protocol base.Protocol
+}
+
+// Progress describes the outcome of a check or of a warm-up.
+type Progress struct {
+	// ModelName is empty when waiting for the server.
+	ModelName string
+	// Attempt is the number of checks made so far, starting at 1.
+	Attempt int
+	// Ready reports whether the server or model was ready.
+	Ready bool
+	// WarmedUp is set when the progress reports a successful warm-up.
+	WarmedUp bool
+	// Err is the error of the check, if any.
+	Err error
+	// Elapsed is the time since the wait started.
+	Elapsed time.Duration
+}
+
+// WaitForServerReady polls the server with exponential backoff until it reports ready or ctx is done.
+func WaitForServerReady(ctx context.Context, client base.Client, settings Settings) error {
+	settings = withDefaults(settings)
+	start := time.Now()
+
+	var lastErr error
+	for attempt, delay := 1, settings.InitialBackoff; ; attempt++ {
+		ready, err := client.IsServerReady(ctx, settings.Options)
+		settings.report(Progress{Attempt: attempt, Ready: ready && err == nil, Err: err, Elapsed: time.Since(start)})
+		if err == nil && ready {
+			return nil
+		}
+		// Overwritten by every attempt, so that a server found not ready afterwards clears the earlier error.
+		lastErr = err
+
+		if err := sleep(ctx, delay); err != nil {
+			return waitError("server", lastErr, err)
+		}
+		delay = settings.next(delay)
+	}
+}
+
+// WaitForModelsReady polls the given models with exponential backoff until all of them report ready, then warms
+// them up when enabled. It returns an error when ctx is done first or when a warm-up fails.
+func WaitForModelsReady(ctx context.Context, client base.Client, settings Settings, models ...string) error {
+	return waitForModels(ctx, client, settings, "", models)
+}
+
+// WaitForModelVersionReady is WaitForModelsReady for a single version of a model.
+func WaitForModelVersionReady(ctx context.Context, client base.Client, settings Settings, model string, version string) error {
+	return waitForModels(ctx, client, settings, version, []string{model})
+}
+
+func waitForModels(ctx context.Context, client base.Client, settings Settings, version string, models []string) error {
+	settings = withDefaults(settings)
+	start := time.Now()
+
+	pending := append([]string(nil), models...)
+	for attempt, delay := 1, settings.InitialBackoff; len(pending) > 0; attempt++ {
+		var notReady []string
+		// The errors of the last attempt only, a model found not ready afterwards clears its earlier error.
+		var lastErrs []string
+		for _, model := range pending {
+			ready, err := client.IsModelReady(ctx, model, version, settings.Options)
+			settings.report(Progress{ModelName: model, Attempt: attempt, Ready: ready && err == nil, Err: err, Elapsed: time.Since(start)})
+			if err != nil || !ready {
+				notReady = append(notReady, model)
+				if err != nil {
+					lastErrs = append(lastErrs, fmt.Sprintf("model '%s': %v", model, err))
+				}
+			}
+		}
+		pending = notReady
+		if len(pending) == 0 {
+			break
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			var lastErr error
+			if len(lastErrs) > 0 {
+				lastErr = errors.New(strings.Join(lastErrs, "; "))
+			}
+			return waitError(fmt.Sprintf("models %v", pending), lastErr, err)
+		}
+		delay = settings.next(delay)
+	}
+
+	if !settings.WarmUp {
+		return nil
+	}
+	for _, model := range models {
+		if err := warmUp(ctx, client, settings, model, version); err != nil {
+			return fmt.Errorf("warming up model '%s': %w", model, err)
+		}
+		settings.report(Progress{ModelName: model, Ready: true, WarmedUp: true, Elapsed: time.Since(start)})
+	}
+	return nil
+}
+
+// warmUp sends an inference with zero-valued inputs shaped after the model metadata.
+func warmUp(ctx context.Context, client base.Client, settings Settings, model string, version string) error {
+	newInferInput, err := inferInputFactory(client, settings.Protocol)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := client.GetModelMetadata(ctx, model, version, settings.Options)
+	if err != nil {
+		return err
+	}
+
+	inputs := make([]base.InferInput, 0, len(metadata.Inputs))
+	for _, tensor := range metadata.Inputs {
+		shape := make([]int64, len(tensor.Shape))
+		for i, dim := range tensor.Shape {
+			shape[i] = int64(dim)
+			if dim < 0 {
+				shape[i] = settings.DynamicDimension
+			}
+		}
+
+		data, err := zeroData(tensor.Datatype, shape)
+		if err != nil {
+			return fmt.Errorf("input '%s': %w", tensor.Name, err)
+		}
+		input := newInferInput(tensor.Name, tensor.Datatype, shape)
+		if err := setRawData(input, data); err != nil {
+			return err
+		}
+		inputs = append(inputs, input)
+	}
+
+	var inferOptions *options.InferOptions
+	if settings.Options != nil {
+		inferOptions = &options.InferOptions{Headers: settings.Options.Headers, QueryParams: settings.Options.QueryParams}
+	}
+	_, err = client.Infer(ctx, model, version, inputs, nil, inferOptions)
+	return err
+}
+
+// inferInputFactory returns the InferInput constructor of the given protocol, or of the protocol of the client.
+func inferInputFactory(client base.Client, protocol base.Protocol) (func(name string, datatype string, shape []int64) base.InferInput, error) {
+	if protocol == "" {
+		p, ok := client.(interface{ Protocol() base.Protocol })
+		if !ok {
+			return nil, fmt.Errorf("cannot determine the protocol of %T, set Settings.Protocol", client)
+		}
+		protocol = p.Protocol()
+	}
+	switch protocol {
+	case base.ProtocolHTTP:
+		return func(name string, datatype string, shape []int64) base.InferInput {
+			return tritonhttp.NewInferInput(name, datatype, shape, nil)
+		}, nil
+	case base.ProtocolGRPC:
+		return func(name string, datatype string, shape []int64) base.InferInput {
+			return tritongrpc.NewInferInput(name, datatype, shape, nil)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown protocol '%s'", protocol)
+	}
+}
+
+// setRawData stores the binary data on inputs built on base.BaseInferInput.
+func setRawData(input base.InferInput, data []byte) error {
+	var target *base.BaseInferInput
+	switch i := input.(type) {
+	case *tritonhttp.InferInput:
+		target = i.BaseInferInput
+	case *tritongrpc.InferInput:
+		target = i.BaseInferInput
+	default:
+		return fmt.Errorf("cannot set the data of %T", input)
+	}
+	if target.Parameters == nil {
+		target.Parameters = make(map[string]any)
+	}
+	target.RawData = data
+	target.Parameters["binary_data_size"] = len(data)
+	return nil
+}
+
+// zeroData returns the binary encoding of a tensor of zeros, or of empty strings for BYTES.
+func zeroData(datatype string, shape []int64) ([]byte, error) {
+	elements := int64(1)
+	for _, dim := range shape {
+		elements *= dim
+	}
+
+	var size int64
+	switch datatype {
+	case "BOOL", "INT8", "UINT8":
+		size = 1
+	case "INT16", "UINT16", "FP16", "BF16":
+		size = 2
+	case "INT32", "UINT32", "FP32":
+		size = 4
+	case "INT64", "UINT64", "FP64":
+		size = 8
+	case "BYTES":
+		// Every element is encoded as a zero length prefix.
+		size = 4
+	default:
+		return nil, fmt.Errorf("unsupported datatype '%s'", datatype)
+	}
+	return make([]byte, elements*size), nil
+}
+
+func withDefaults(settings Settings) Settings {
+	if settings.InitialBackoff <= 0 {
+		settings.InitialBackoff = 100 * time.Millisecond
+	}
+	if settings.MaxBackoff <= 0 {
+		settings.MaxBackoff = 5 * time.Second
+	}
+	if settings.Multiplier < 1 {
+		settings.Multiplier = 2
+	}
+	if settings.Options == nil {
+		settings.Options = &options.Options{}
+	}
+	if settings.DynamicDimension <= 0 {
+		settings.DynamicDimension = 1
+	}
+	return settings
+}
+
+func (s Settings) next(delay time.Duration) time.Duration {
+	delay = time.Duration(float64(delay) * s.Multiplier)
+	if delay > s.MaxBackoff {
+		return s.MaxBackoff
+	}
+	return delay
+}
+
+func (s Settings) report(progress Progress) {
+	if s.OnProgress != nil {
+		s.OnProgress(progress)
+	}
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// waitError reports that the wait for target ended with ctxErr, including the last check error if any.
+func waitError(target string, lastErr, ctxErr error) error {
+	if lastErr != nil {
+		return fmt.Errorf("waiting for %s to be ready: %w (last error: %v)", target, ctxErr, lastErr)
+	}
+	return fmt.Errorf("waiting for %s to be ready: %w", target, ctxErr)
+}
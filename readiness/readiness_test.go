@@ -0,0 +1,208 @@
+package readiness
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/Trendyol/go-triton-client/base"
+	tritonhttp "github.com/Trendyol/go-triton-client/client/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var fastBackoff = Settings{InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+func TestWaitForServerReady(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	gomock.InOrder(
+		client.EXPECT().IsServerReady(gomock.Any(), gomock.Any()).Return(false, errors.New("connection refused")),
+		client.EXPECT().IsServerReady(gomock.Any(), gomock.Any()).Return(false, nil),
+		client.EXPECT().IsServerReady(gomock.Any(), gomock.Any()).Return(true, nil),
+	)
+
+	var progress []Progress
+	settings := fastBackoff
+	settings.OnProgress = func(p Progress) { progress = append(progress, p) }
+
+	require.NoError(t, WaitForServerReady(context.Background(), client, settings))
+	require.Len(t, progress, 3)
+	assert.EqualError(t, progress[0].Err, "connection refused")
+	assert.Equal(t, 2, progress[1].Attempt)
+	assert.False(t, progress[1].Ready)
+	assert.True(t, progress[2].Ready)
+	assert.Empty(t, progress[2].ModelName)
+}
+
+func TestWaitForServerReady_ContextDone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	client.EXPECT().IsServerReady(gomock.Any(), gomock.Any()).Return(false, errors.New("connection refused")).AnyTimes()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := WaitForServerReady(ctx, client, fastBackoff)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestWaitForModelsReady_ReportsPendingModels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	client.EXPECT().IsModelReady(gomock.Any(), "encoder", "", gomock.Any()).Return(true, nil)
+	client.EXPECT().IsModelReady(gomock.Any(), "ranker", "", gomock.Any()).Return(false, nil).AnyTimes()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := WaitForModelsReady(ctx, client, fastBackoff, "encoder", "ranker")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "models [ranker]")
+}
+
+func TestWaitForServerReady_ReportsErrorOfLastAttempt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	gomock.InOrder(
+		client.EXPECT().IsServerReady(gomock.Any(), gomock.Any()).Return(false, errors.New("connection refused")),
+		client.EXPECT().IsServerReady(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes(),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := WaitForServerReady(ctx, client, fastBackoff)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotContains(t, err.Error(), "connection refused")
+}
+
+func TestWaitForModelsReady_ReportsErrorsOfLastAttempt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	gomock.InOrder(
+		client.EXPECT().IsModelReady(gomock.Any(), "encoder", "", gomock.Any()).Return(false, errors.New("connection refused")),
+		client.EXPECT().IsModelReady(gomock.Any(), "encoder", "", gomock.Any()).Return(false, nil).AnyTimes(),
+	)
+	client.EXPECT().IsModelReady(gomock.Any(), "ranker", "", gomock.Any()).Return(false, errors.New("unavailable")).AnyTimes()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := WaitForModelsReady(ctx, client, fastBackoff, "encoder", "ranker")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "models [encoder ranker]")
+	assert.ErrorContains(t, err, "model 'ranker': unavailable")
+	assert.NotContains(t, err.Error(), "connection refused")
+}
+
+func TestWaitForModelVersionReady(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	gomock.InOrder(
+		client.EXPECT().IsModelReady(gomock.Any(), "encoder", "3", gomock.Any()).Return(false, nil),
+		client.EXPECT().IsModelReady(gomock.Any(), "encoder", "3", gomock.Any()).Return(true, nil),
+	)
+
+	require.NoError(t, WaitForModelVersionReady(context.Background(), client, fastBackoff, "encoder", "3"))
+}
+
+func TestWaitForModelsReady_WarmUp(t *testing.T) {
+	var mu sync.Mutex
+	readyChecks := 0
+	warmUps := map[string]map[string]any{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/ready"):
+			readyChecks++
+			if readyChecks <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case strings.HasSuffix(r.URL.Path, "/infer"):
+			model := strings.Split(r.URL.Path, "/")[3]
+			headerLength, _ := strconv.Atoi(r.Header.Get("Inference-Header-Content-Length"))
+			body, _ := io.ReadAll(r.Body)
+			var header map[string]any
+			require.NoError(t, json.Unmarshal(body[:headerLength], &header))
+			header["binary_size"] = len(body) - headerLength
+			warmUps[model] = header
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"model_name":"` + model + `","model_version":"1","outputs":[]}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"encoder","inputs":[
+				{"name":"input_ids","datatype":"INT64","shape":[-1,4]},
+				{"name":"text","datatype":"BYTES","shape":[2]}
+			]}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := tritonhttp.New(server.URL)
+	require.NoError(t, err)
+
+	var progress []Progress
+	settings := fastBackoff
+	settings.WarmUp = true
+	settings.DynamicDimension = 3
+	settings.OnProgress = func(p Progress) { progress = append(progress, p) }
+
+	require.NoError(t, WaitForModelsReady(context.Background(), client, settings, "encoder", "ranker"))
+
+	assert.Equal(t, 4, readyChecks)
+	require.Contains(t, warmUps, "encoder")
+	require.Contains(t, warmUps, "ranker")
+
+	inputs := warmUps["encoder"]["inputs"].([]any)
+	require.Len(t, inputs, 2)
+	assert.Equal(t, []any{float64(3), float64(4)}, inputs[0].(map[string]any)["shape"])
+	assert.Equal(t, 3*4*8+2*4, warmUps["encoder"]["binary_size"])
+
+	last := progress[len(progress)-1]
+	assert.Equal(t, "ranker", last.ModelName)
+	assert.True(t, last.WarmedUp)
+}
+
+func TestWaitForModelsReady_WarmUpNeedsProtocol(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	client.EXPECT().IsModelReady(gomock.Any(), "encoder", "", gomock.Any()).Return(true, nil)
+
+	settings := fastBackoff
+	settings.WarmUp = true
+	err := WaitForModelsReady(context.Background(), client, settings, "encoder")
+	assert.ErrorContains(t, err, "warming up model 'encoder'")
+	assert.ErrorContains(t, err, "set Settings.Protocol")
+}
+
+func TestZeroData(t *testing.T) {
+	data, err := zeroData("FP16", []int64{2, 3})
+	require.NoError(t, err)
+	assert.Len(t, data, 12)
+
+	_, err = zeroData("STRING", []int64{1})
+	assert.ErrorContains(t, err, "unsupported datatype 'STRING'")
+}
+
+func TestSettingsNext(t *testing.T) {
+	settings := withDefaults(Settings{InitialBackoff: time.Second, MaxBackoff: 3 * time.Second})
+
+	assert.Equal(t, 2*time.Second, settings.next(time.Second))
+	assert.Equal(t, 3*time.Second, settings.next(2*time.Second))
+}
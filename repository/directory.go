@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/modelconfig"
+	"github.com/Trendyol/go-triton-client/options"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConfigFileName is the name of the model configuration in a model directory.
+const ConfigFileName = "config.pbtxt"
+
+// DefaultMaxTotalSize is the default limit of the total size of the files of a model directory.
+// Files are sent base64 encoded over HTTP, which grows them by a third, and gRPC messages are limited to 2 GiB.
+const DefaultMaxTotalSize = 1 << 30
+
+// DirectorySettings configures how a model directory is read.
+type DirectorySettings struct {
+	// MaxFileSize is the maximum size in bytes of a single file. Zero disables the limit.
+	MaxFileSize int64
+	// MaxTotalSize is the maximum total size in bytes of the files. Defaults to DefaultMaxTotalSize.
+	MaxTotalSize int64
+	// IncludeHidden includes the files and directories whose name starts with a dot.
+	IncludeHidden bool
+}
+
+// ModelDirectory is the content of a local model directory, in the form expected by base.Client.LoadModel.
+type ModelDirectory struct {
+	// Config is the model configuration in Triton's JSON format.
+	Config string
+	// Files maps the "file:<path>" keys, such as "file:1/model.onnx", to the content of the files.
+	Files map[string][]byte
+}
+
+// ReadModelDirectory reads a model directory laid out as in a Triton model repository: a config.pbtxt next
+// to the versioned subdirectories holding the model files. The configuration is converted to JSON, and every
+// other file is added with a key made of "file:" and its path relative to the directory.
+func ReadModelDirectory(dir string, settings DirectorySettings) (*ModelDirectory, error) {
+	if settings.MaxTotalSize <= 0 {
+		settings.MaxTotalSize = DefaultMaxTotalSize
+	}
+
+	pbtxt, err := os.ReadFile(filepath.Join(dir, ConfigFileName))
+	if err != nil {
+		return nil, fmt.Errorf("reading model configuration: %w", err)
+	}
+	parsed, err := modelconfig.ParsePbtxt(pbtxt)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s: %w", ConfigFileName, err)
+	}
+	config, err := modelconfig.RenderJSON(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s: %w", ConfigFileName, err)
+	}
+
+	files := make(map[string][]byte)
+	var totalSize int64
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		if !settings.IncludeHidden && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		relative = filepath.ToSlash(relative)
+		if relative == ConfigFileName {
+			return nil
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		if settings.MaxFileSize > 0 && info.Size() > settings.MaxFileSize {
+			return fmt.Errorf("file '%s' is %d bytes, larger than the limit of %d bytes", relative, info.Size(), settings.MaxFileSize)
+		}
+		totalSize += info.Size()
+		if totalSize > settings.MaxTotalSize {
+			return fmt.Errorf("model files exceed the limit of %d bytes", settings.MaxTotalSize)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files["file:"+relative] = content
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ModelDirectory{Config: config, Files: files}, nil
+}
+
+// LoadModelFromDirectory reads the model directory and loads its content under the given model name.
+// The name of the configuration is replaced by modelName, so a directory can be loaded under another name.
+func LoadModelFromDirectory(ctx context.Context, client base.Client, modelName string, dir string, settings DirectorySettings, options *options.Options) error {
+	directory, err := ReadModelDirectory(dir, settings)
+	if err != nil {
+		return err
+	}
+	config, err := renameConfig(directory.Config, modelName)
+	if err != nil {
+		return err
+	}
+	return client.LoadModel(ctx, modelName, config, directory.Files, options)
+}
+
+// renameConfig sets the name of a JSON model configuration.
+func renameConfig(config string, modelName string) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(config), &fields); err != nil {
+		return "", err
+	}
+	name, err := json.Marshal(modelName)
+	if err != nil {
+		return "", err
+	}
+	fields["name"] = name
+	renamed, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(renamed), nil
+}
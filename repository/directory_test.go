@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	tritonhttp "github.com/Trendyol/go-triton-client/client/http"
+	"github.com/Trendyol/go-triton-client/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testConfig = `
+name: "encoder"
+platform: "onnxruntime_onnx"
+max_batch_size: 8
+input [
+  {
+    name: "input_ids"
+    data_type: TYPE_INT64
+    dims: [ -1 ]
+  }
+]
+output [
+  {
+    name: "embeddings"
+    data_type: TYPE_FP32
+    dims: [ 768 ]
+  }
+]
+`
+
+func writeModelDirectory(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for path, content := range files {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestReadModelDirectory(t *testing.T) {
+	dir := writeModelDirectory(t, map[string]string{
+		"config.pbtxt":        testConfig,
+		"labels.txt":          "positive\nnegative\n",
+		"1/model.onnx":        "onnx-v1",
+		"2/model.onnx":        "onnx-v2",
+		"2/assets/vocab.txt":  "[CLS]\n[SEP]\n",
+		".git/HEAD":           "ref: refs/heads/main",
+		"1/.model.onnx.swp":   "swap",
+		"3/.ignored/data.bin": "ignored",
+	})
+
+	directory, err := ReadModelDirectory(dir, DirectorySettings{})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]byte{
+		"file:labels.txt":         []byte("positive\nnegative\n"),
+		"file:1/model.onnx":       []byte("onnx-v1"),
+		"file:2/model.onnx":       []byte("onnx-v2"),
+		"file:2/assets/vocab.txt": []byte("[CLS]\n[SEP]\n"),
+	}, directory.Files)
+
+	var config map[string]any
+	require.NoError(t, json.Unmarshal([]byte(directory.Config), &config))
+	assert.Equal(t, "encoder", config["name"])
+	assert.Equal(t, "onnxruntime_onnx", config["platform"])
+	assert.Equal(t, float64(8), config["max_batch_size"])
+	input := config["input"].([]any)[0].(map[string]any)
+	assert.Equal(t, "TYPE_INT64", input["data_type"])
+	assert.Equal(t, []any{"-1"}, input["dims"])
+}
+
+func TestReadModelDirectory_IncludeHidden(t *testing.T) {
+	dir := writeModelDirectory(t, map[string]string{
+		"config.pbtxt":    testConfig,
+		"1/.metadata.txt": "metadata",
+	})
+
+	directory, err := ReadModelDirectory(dir, DirectorySettings{IncludeHidden: true})
+	require.NoError(t, err)
+	assert.Contains(t, directory.Files, "file:1/.metadata.txt")
+}
+
+func TestReadModelDirectory_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		settings DirectorySettings
+		expected string
+	}{
+		{
+			name:     "missing config",
+			files:    map[string]string{"1/model.onnx": "onnx"},
+			expected: "reading model configuration",
+		},
+		{
+			name:     "invalid config",
+			files:    map[string]string{"config.pbtxt": "max_batch_size: \"eight\""},
+			expected: "converting config.pbtxt",
+		},
+		{
+			name:     "file too large",
+			files:    map[string]string{"config.pbtxt": testConfig, "1/model.onnx": "0123456789"},
+			settings: DirectorySettings{MaxFileSize: 8},
+			expected: "file '1/model.onnx' is 10 bytes, larger than the limit of 8 bytes",
+		},
+		{
+			name:     "directory too large",
+			files:    map[string]string{"config.pbtxt": testConfig, "1/model.onnx": "01234", "2/model.onnx": "56789"},
+			settings: DirectorySettings{MaxTotalSize: 8},
+			expected: "model files exceed the limit of 8 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadModelDirectory(writeModelDirectory(t, tt.files), tt.settings)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestLoadModelFromDirectory(t *testing.T) {
+	var path string
+	var request struct {
+		Parameters map[string]string `json:"parameters"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dir := writeModelDirectory(t, map[string]string{
+		"config.pbtxt": testConfig,
+		"1/model.onnx": "onnx-v1",
+	})
+
+	client, err := tritonhttp.New(server.URL)
+	require.NoError(t, err)
+	require.NoError(t, LoadModelFromDirectory(context.Background(), client, "encoder-canary", dir, DirectorySettings{}, &options.Options{}))
+
+	assert.Equal(t, "/v2/repository/models/encoder-canary/load", path)
+	assert.Len(t, request.Parameters, 2)
+	assert.Contains(t, request.Parameters["config"], `"platform":"onnxruntime_onnx"`)
+	assert.Contains(t, request.Parameters["config"], `"name":"encoder-canary"`)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("onnx-v1")), request.Parameters["file:1/model.onnx"])
+}
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/options"
+	"github.com/Trendyol/go-triton-client/readiness"
+	"math"
+	"time"
+)
+
+// ReloadStep identifies a step of a rolling reload.
+type ReloadStep string
+
+const (
+	// ReloadStepLoad loads the new configuration and files.
+	ReloadStepLoad ReloadStep = "load"
+	// ReloadStepWaitReady waits for the new version to be ready.
+	ReloadStepWaitReady ReloadStep = "wait_ready"
+	// ReloadStepCanary compares the outputs of the new and the previous version.
+	ReloadStepCanary ReloadStep = "canary"
+	// ReloadStepRollback reloads the previous configuration after a failure.
+	ReloadStepRollback ReloadStep = "rollback"
+	// ReloadStepUnload unloads a model that was not ready before a failed reload.
+	ReloadStepUnload ReloadStep = "unload"
+)
+
+// ReloadProgress describes the outcome of a step of a rolling reload.
+type ReloadProgress struct {
+	ModelName string
+	Step      ReloadStep
+	// Err is the error of the step, if any.
+	Err error
+	// Elapsed is the time since the reload started.
+	Elapsed time.Duration
+}
+
+// Canary configures the comparison of the new version with the previous one on the same inputs.
+type Canary struct {
+	// PreviousVersion is the version served before the reload. It must still be served after the load,
+	// e.g. with a version policy keeping the two latest versions.
+	PreviousVersion string
+	Inputs          []base.InferInput
+	// Outputs are the compared outputs. At least one is required.
+	Outputs []base.InferOutput
+	// Tolerance is the maximum absolute difference between the elements of numeric outputs.
+	// BYTES outputs must be equal.
+	Tolerance float64
+	Options   *options.InferOptions
+}
+
+// ReloadSettings configures a rolling reload.
+type ReloadSettings struct {
+	// Config is the configuration override in Triton's JSON format. Empty reloads the configuration of the repository.
+	Config string
+	// Files are the "file:<path>" model files sent with the configuration.
+	Files map[string][]byte
+	// Version is the version waited for and compared. Empty waits for the model, and infers with the latest version.
+	Version string
+	// Readiness configures the wait for the new version.
+	Readiness readiness.Settings
+	// ReadyTimeout bounds the wait for the new version. Zero waits until ctx is done.
+	ReadyTimeout time.Duration
+	// Canary compares the outputs of the new version with the previous version when set.
+	Canary *Canary
+	// RollbackConfig is loaded when the reload fails and the model was ready before it. Empty reloads the
+	// configuration served before the reload, read unchanged with base.GetModelConfigJSON, so it must be set
+	// with clients not implementing base.ModelConfigJSONGetter.
+	RollbackConfig string
+	// RollbackFiles are the "file:<path>" model files sent with the rollback, such as the files of an override
+	// loaded before the reload, which the server doesn't return. Empty rolls back to the files of the repository.
+	RollbackFiles map[string][]byte
+	// Options are sent with the load, unload and health calls.
+	Options *options.Options
+	// OnProgress is called after every step.
+	OnProgress func(progress ReloadProgress)
+}
+
+// RollingReload loads a new configuration or version of a model, waits for it to be ready and optionally compares
+// its outputs with the previous version. When a step fails, a model that was ready before is rolled back to
+// RollbackConfig, and a model that was not is unloaded. The returned error reports the failed step.
+// The reload doesn't start when the readiness or the configuration of the model can't be read.
+func RollingReload(ctx context.Context, client base.Client, modelName string, settings ReloadSettings) error {
+	if settings.Options == nil {
+		settings.Options = &options.Options{}
+	}
+	if settings.Readiness.Options == nil {
+		settings.Readiness.Options = settings.Options
+	}
+	start := time.Now()
+	report := func(step ReloadStep, err error) {
+		if settings.OnProgress != nil {
+			settings.OnProgress(ReloadProgress{ModelName: modelName, Step: step, Err: err, Elapsed: time.Since(start)})
+		}
+	}
+
+	wasReady, err := client.IsModelReady(ctx, modelName, "", settings.Options)
+	if err != nil {
+		return fmt.Errorf("checking the readiness of model '%s': %w", modelName, err)
+	}
+	rollbackConfig := settings.RollbackConfig
+	if wasReady && rollbackConfig == "" {
+		// Without it, the rollback would load the content of the repository, which may be the failing one.
+		if rollbackConfig, err = base.GetModelConfigJSON(ctx, client, modelName, "", settings.Options); err != nil {
+			return fmt.Errorf("reading the configuration of model '%s': %w", modelName, err)
+		}
+	}
+
+	step, err := reload(ctx, client, modelName, settings, report)
+	if err == nil {
+		return nil
+	}
+	err = fmt.Errorf("reloading model '%s': %s: %w", modelName, step, err)
+
+	// The caller may have given up, the model is still restored.
+	ctx = context.WithoutCancel(ctx)
+	if wasReady {
+		rollbackErr := client.LoadModel(ctx, modelName, rollbackConfig, settings.RollbackFiles, settings.Options)
+		report(ReloadStepRollback, rollbackErr)
+		if rollbackErr != nil {
+			return errors.Join(err, fmt.Errorf("rolling back model '%s': %w", modelName, rollbackErr))
+		}
+		return err
+	}
+	unloadErr := client.UnloadModel(ctx, modelName, false, settings.Options)
+	report(ReloadStepUnload, unloadErr)
+	if unloadErr != nil {
+		return errors.Join(err, fmt.Errorf("unloading model '%s': %w", modelName, unloadErr))
+	}
+	return err
+}
+
+// reload runs the load, wait and canary steps, and returns the step that failed.
+func reload(ctx context.Context, client base.Client, modelName string, settings ReloadSettings, report func(ReloadStep, error)) (ReloadStep, error) {
+	err := client.LoadModel(ctx, modelName, settings.Config, settings.Files, settings.Options)
+	report(ReloadStepLoad, err)
+	if err != nil {
+		return ReloadStepLoad, err
+	}
+
+	waitCtx := ctx
+	if settings.ReadyTimeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, settings.ReadyTimeout)
+		defer cancel()
+	}
+	err = readiness.WaitForModelVersionReady(waitCtx, client, settings.Readiness, modelName, settings.Version)
+	report(ReloadStepWaitReady, err)
+	if err != nil {
+		return ReloadStepWaitReady, err
+	}
+
+	if settings.Canary == nil {
+		return "", nil
+	}
+	err = runCanary(ctx, client, modelName, settings.Version, settings.Canary)
+	report(ReloadStepCanary, err)
+	if err != nil {
+		return ReloadStepCanary, err
+	}
+	return "", nil
+}
+
+// runCanary infers with the previous and the new version and compares the outputs.
+func runCanary(ctx context.Context, client base.Client, modelName string, version string, canary *Canary) error {
+	if len(canary.Outputs) == 0 {
+		return errors.New("the canary needs at least one output to compare")
+	}
+	previous, err := client.Infer(ctx, modelName, canary.PreviousVersion, canary.Inputs, canary.Outputs, canary.Options)
+	if err != nil {
+		return fmt.Errorf("inferring with the previous version: %w", err)
+	}
+	current, err := client.Infer(ctx, modelName, version, canary.Inputs, canary.Outputs, canary.Options)
+	if err != nil {
+		return fmt.Errorf("inferring with the new version: %w", err)
+	}
+	for _, output := range canary.Outputs {
+		if err := compareOutput(previous, current, output.GetName(), canary.Tolerance); err != nil {
+			return fmt.Errorf("output '%s': %w", output.GetName(), err)
+		}
+	}
+	return nil
+}
+
+func compareOutput(previous, current base.InferResult, name string, tolerance float64) error {
+	previousShape, err := previous.GetShape(name)
+	if err != nil {
+		return err
+	}
+	currentShape, err := current.GetShape(name)
+	if err != nil {
+		return err
+	}
+	if fmt.Sprint(previousShape) != fmt.Sprint(currentShape) {
+		return fmt.Errorf("shape changed from %v to %v", previousShape, currentShape)
+	}
+
+	output, err := previous.GetOutput(name)
+	if err != nil {
+		return err
+	}
+	if output.GetDatatype() == "BYTES" {
+		previousValues, err := previous.AsBytesSlice(name)
+		if err != nil {
+			return err
+		}
+		currentValues, err := current.AsBytesSlice(name)
+		if err != nil {
+			return err
+		}
+		for i := range previousValues {
+			if i >= len(currentValues) || !bytes.Equal(previousValues[i], currentValues[i]) {
+				return fmt.Errorf("element %d differs", i)
+			}
+		}
+		return nil
+	}
+
+	previousValues, err := numericValues(previous, name, output.GetDatatype())
+	if err != nil {
+		return err
+	}
+	currentValues, err := numericValues(current, name, output.GetDatatype())
+	if err != nil {
+		return err
+	}
+	if len(previousValues) != len(currentValues) {
+		return fmt.Errorf("element count changed from %d to %d", len(previousValues), len(currentValues))
+	}
+	for i := range previousValues {
+		if difference := math.Abs(previousValues[i] - currentValues[i]); !(difference <= tolerance) {
+			return fmt.Errorf("element %d differs by %g, more than the tolerance of %g", i, difference, tolerance)
+		}
+	}
+	return nil
+}
+
+// numericValues returns the elements of a numeric output as float64.
+func numericValues(result base.InferResult, name string, datatype string) ([]float64, error) {
+	switch datatype {
+	case "FP16":
+		return result.AsFloat16Slice(name)
+	case "FP32":
+		return convert(result.AsFloat32Slice(name))
+	case "FP64":
+		return result.AsFloat64Slice(name)
+	case "INT8":
+		return convert(result.AsInt8Slice(name))
+	case "INT16":
+		return convert(result.AsInt16Slice(name))
+	case "INT32":
+		return convert(result.AsInt32Slice(name))
+	case "INT64":
+		return convert(result.AsInt64Slice(name))
+	case "UINT8":
+		return convert(result.AsUint8Slice(name))
+	case "UINT16":
+		return convert(result.AsUint16Slice(name))
+	case "UINT32":
+		return convert(result.AsUint32Slice(name))
+	case "UINT64":
+		return convert(result.AsUint64Slice(name))
+	case "BOOL":
+		values, err := result.AsBoolSlice(name)
+		if err != nil {
+			return nil, err
+		}
+		converted := make([]float64, len(values))
+		for i, value := range values {
+			if value {
+				converted[i] = 1
+			}
+		}
+		return converted, nil
+	default:
+		return nil, fmt.Errorf("unsupported datatype '%s'", datatype)
+	}
+}
+
+func convert[T int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64 | float32](values []T, err error) ([]float64, error) {
+	if err != nil {
+		return nil, err
+	}
+	converted := make([]float64, len(values))
+	for i, value := range values {
+		converted[i] = float64(value)
+	}
+	return converted, nil
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/Trendyol/go-triton-client/base"
+	tritonhttp "github.com/Trendyol/go-triton-client/client/http"
+	"github.com/Trendyol/go-triton-client/options"
+	"github.com/Trendyol/go-triton-client/readiness"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+var fastReadiness = readiness.Settings{InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+func newCanaryResult(ctrl *gomock.Controller, values []float32) *base.MockInferResult {
+	result := base.NewMockInferResult(ctrl)
+	result.EXPECT().GetShape("scores").Return([]int64{int64(len(values))}, nil).AnyTimes()
+	result.EXPECT().GetOutput("scores").Return(&base.BaseInferOutput{Name: "scores", Datatype: "FP32"}, nil).AnyTimes()
+	result.EXPECT().AsFloat32Slice("scores").Return(values, nil).AnyTimes()
+	return result
+}
+
+func newCanary() *Canary {
+	return &Canary{
+		PreviousVersion: "1",
+		Inputs:          []base.InferInput{tritonhttp.NewInferInput("input_ids", "INT64", []int64{1, 2}, nil)},
+		Outputs:         []base.InferOutput{tritonhttp.NewInferOutput("scores", nil)},
+		Tolerance:       0.01,
+	}
+}
+
+// configClient is a mock client returning the served configuration in JSON, as the HTTP and gRPC clients do.
+type configClient struct {
+	*base.MockClient
+	config string
+	err    error
+}
+
+func (c *configClient) GetModelConfigJSON(ctx context.Context, modelName string, modelVersion string, options *options.Options) (string, error) {
+	return c.config, c.err
+}
+
+func TestRollingReload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	gomock.InOrder(
+		client.EXPECT().IsModelReady(gomock.Any(), "ranker", "", gomock.Any()).Return(true, nil),
+		client.EXPECT().LoadModel(gomock.Any(), "ranker", `{"max_batch_size":16}`, gomock.Any(), gomock.Any()).Return(nil),
+		client.EXPECT().IsModelReady(gomock.Any(), "ranker", "2", gomock.Any()).Return(false, nil),
+		client.EXPECT().IsModelReady(gomock.Any(), "ranker", "2", gomock.Any()).Return(true, nil),
+		client.EXPECT().Infer(gomock.Any(), "ranker", "1", gomock.Any(), gomock.Any(), gomock.Any()).Return(newCanaryResult(ctrl, []float32{0.5, 0.25}), nil),
+		client.EXPECT().Infer(gomock.Any(), "ranker", "2", gomock.Any(), gomock.Any(), gomock.Any()).Return(newCanaryResult(ctrl, []float32{0.505, 0.25}), nil),
+	)
+
+	var steps []ReloadStep
+	err := RollingReload(context.Background(), &configClient{MockClient: client, config: `{"name":"ranker"}`}, "ranker", ReloadSettings{
+		Config:     `{"max_batch_size":16}`,
+		Version:    "2",
+		Readiness:  fastReadiness,
+		Canary:     newCanary(),
+		OnProgress: func(progress ReloadProgress) { steps = append(steps, progress.Step) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []ReloadStep{ReloadStepLoad, ReloadStepWaitReady, ReloadStepCanary}, steps)
+}
+
+func TestRollingReload_CanaryMismatchRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	gomock.InOrder(
+		client.EXPECT().IsModelReady(gomock.Any(), "ranker", "", gomock.Any()).Return(true, nil),
+		client.EXPECT().LoadModel(gomock.Any(), "ranker", "", gomock.Any(), gomock.Any()).Return(nil),
+		client.EXPECT().IsModelReady(gomock.Any(), "ranker", "2", gomock.Any()).Return(true, nil),
+		client.EXPECT().Infer(gomock.Any(), "ranker", "1", gomock.Any(), gomock.Any(), gomock.Any()).Return(newCanaryResult(ctrl, []float32{0.5, 0.25}), nil),
+		client.EXPECT().Infer(gomock.Any(), "ranker", "2", gomock.Any(), gomock.Any(), gomock.Any()).Return(newCanaryResult(ctrl, []float32{0.5, 0.75}), nil),
+		client.EXPECT().LoadModel(gomock.Any(), "ranker", `{"max_batch_size":8}`, nil, gomock.Any()).Return(nil),
+	)
+
+	var progress []ReloadProgress
+	err := RollingReload(context.Background(), client, "ranker", ReloadSettings{
+		Version:        "2",
+		Readiness:      fastReadiness,
+		Canary:         newCanary(),
+		RollbackConfig: `{"max_batch_size":8}`,
+		OnProgress:     func(p ReloadProgress) { progress = append(progress, p) },
+	})
+	assert.EqualError(t, err, "reloading model 'ranker': canary: output 'scores': element 1 differs by 0.5, more than the tolerance of 0.01")
+	require.Len(t, progress, 4)
+	assert.Error(t, progress[2].Err)
+	assert.Equal(t, ReloadStepRollback, progress[3].Step)
+	assert.NoError(t, progress[3].Err)
+}
+
+func TestRollingReload_CanaryMismatchRollsBackToServedConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	served := `{"name":"ranker","backend":"onnxruntime","max_batch_size":8,"dynamic_batching":{"preferred_batch_size":[4,8],"max_queue_delay_microseconds":"100"}}`
+	gomock.InOrder(
+		client.EXPECT().IsModelReady(gomock.Any(), "ranker", "", gomock.Any()).Return(true, nil),
+		client.EXPECT().LoadModel(gomock.Any(), "ranker", "", gomock.Any(), gomock.Any()).Return(nil),
+		client.EXPECT().IsModelReady(gomock.Any(), "ranker", "2", gomock.Any()).Return(true, nil),
+		client.EXPECT().Infer(gomock.Any(), "ranker", "1", gomock.Any(), gomock.Any(), gomock.Any()).Return(newCanaryResult(ctrl, []float32{0.5, 0.25}), nil),
+		client.EXPECT().Infer(gomock.Any(), "ranker", "2", gomock.Any(), gomock.Any(), gomock.Any()).Return(newCanaryResult(ctrl, []float32{0.5, 0.75}), nil),
+		client.EXPECT().LoadModel(gomock.Any(), "ranker", gomock.Any(), nil, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, modelName string, config string, files map[string][]byte, options *options.Options) error {
+				// The batching settings of the served configuration survive the rollback.
+				assert.JSONEq(t, served, config)
+				return nil
+			}),
+	)
+
+	err := RollingReload(context.Background(), &configClient{MockClient: client, config: served}, "ranker", ReloadSettings{
+		Version:   "2",
+		Readiness: fastReadiness,
+		Canary:    newCanary(),
+	})
+	assert.ErrorContains(t, err, "reloading model 'ranker': canary: output 'scores'")
+}
+
+func TestRollingReload_ReadinessCheckFailureAborts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	client.EXPECT().IsModelReady(gomock.Any(), "ranker", "", gomock.Any()).Return(false, errors.New("connection refused"))
+
+	err := RollingReload(context.Background(), client, "ranker", ReloadSettings{Config: `{"max_batch_size":16}`})
+	assert.EqualError(t, err, "checking the readiness of model 'ranker': connection refused")
+}
+
+func TestRollingReload_ConfigReadFailureAborts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	client.EXPECT().IsModelReady(gomock.Any(), "ranker", "", gomock.Any()).Return(true, nil)
+
+	err := RollingReload(context.Background(), &configClient{MockClient: client, err: errors.New("unavailable")}, "ranker", ReloadSettings{Config: `{"max_batch_size":16}`})
+	assert.EqualError(t, err, "reading the configuration of model 'ranker': unavailable")
+}
+
+func TestRollingReload_RequiresRollbackConfigWithoutJSONConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	client.EXPECT().IsModelReady(gomock.Any(), "ranker", "", gomock.Any()).Return(true, nil)
+
+	err := RollingReload(context.Background(), client, "ranker", ReloadSettings{Config: `{"max_batch_size":16}`})
+	assert.EqualError(t, err, "reading the configuration of model 'ranker': *base.MockClient can't return the configuration of model 'ranker' in JSON")
+}
+
+func TestRollingReload_NotReadyUnloads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	client.EXPECT().IsModelReady(gomock.Any(), "ranker", "", gomock.Any()).Return(false, nil)
+	client.EXPECT().LoadModel(gomock.Any(), "ranker", "", gomock.Any(), gomock.Any()).Return(nil)
+	client.EXPECT().IsModelReady(gomock.Any(), "ranker", "", gomock.Any()).Return(false, nil).AnyTimes()
+	client.EXPECT().UnloadModel(gomock.Any(), "ranker", false, gomock.Any()).Return(errors.New("unavailable"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := RollingReload(ctx, client, "ranker", ReloadSettings{Readiness: fastReadiness, ReadyTimeout: 20 * time.Millisecond})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "reloading model 'ranker': wait_ready: waiting for models [ranker] to be ready")
+	assert.ErrorContains(t, err, "unloading model 'ranker': unavailable")
+}
+
+func TestRollingReload_LoadFailureRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+	rollbackFiles := map[string][]byte{"file:1/model.onnx": []byte("previous")}
+	gomock.InOrder(
+		client.EXPECT().IsModelReady(gomock.Any(), "ranker", "", gomock.Any()).Return(true, nil),
+		client.EXPECT().LoadModel(gomock.Any(), "ranker", `{"backend":"missing"}`, gomock.Any(), gomock.Any()).Return(errors.New("failed to load")),
+		client.EXPECT().LoadModel(gomock.Any(), "ranker", `{"backend":"onnxruntime"}`, rollbackFiles, gomock.Any()).Return(nil),
+	)
+
+	err := RollingReload(context.Background(), client, "ranker", ReloadSettings{
+		Config:         `{"backend":"missing"}`,
+		RollbackConfig: `{"backend":"onnxruntime"}`,
+		RollbackFiles:  rollbackFiles,
+	})
+	assert.EqualError(t, err, "reloading model 'ranker': load: failed to load")
+}
+
+func TestCompareOutput_Shape(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	err := compareOutput(newCanaryResult(ctrl, []float32{1}), newCanaryResult(ctrl, []float32{1, 2}), "scores", 0)
+	assert.EqualError(t, err, "shape changed from [1] to [2]")
+}
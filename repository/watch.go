@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/models"
+	"github.com/Trendyol/go-triton-client/options"
+	"time"
+)
+
+// IndexChange is the kind of an IndexEvent.
+type IndexChange string
+
+const (
+	// IndexChangeAdded reports a model version that appeared in the index.
+	IndexChangeAdded IndexChange = "added"
+	// IndexChangeRemoved reports a model version that disappeared from the index.
+	IndexChangeRemoved IndexChange = "removed"
+	// IndexChangeState reports a model version whose state changed, e.g. that became unavailable.
+	IndexChangeState IndexChange = "state"
+	// IndexChangeReason reports a model version whose reason changed in the same state.
+	IndexChangeReason IndexChange = "reason"
+	// IndexChangeError reports a failed poll. The watch goes on with the next poll.
+	IndexChangeError IndexChange = "error"
+)
+
+// IndexEvent is a change of the model repository index.
+type IndexEvent struct {
+	Change IndexChange
+	// Previous is the entry before the change, zero for added models and errors.
+	Previous models.ModelRepositoryIndexResponse
+	// Current is the entry after the change, zero for removed models and errors.
+	Current models.ModelRepositoryIndexResponse
+	// Err is the error of the poll for IndexChangeError events.
+	Err error
+}
+
+// WatchSettings configures WatchIndex.
+type WatchSettings struct {
+	// Interval is the delay between polls. Defaults to 5 seconds.
+	Interval time.Duration
+	// Options are sent with every GetModelRepositoryIndex call.
+	Options *options.Options
+	// BufferSize is the capacity of the event channel.
+	BufferSize int
+}
+
+// WatchIndex polls the model repository index and sends its changes on the returned channel, which is closed when
+// ctx is done. The first poll reports every indexed model version as added. Events are sent in the order of the
+// index, and polling waits for the receiver, so slow receivers delay the next poll rather than lose events.
+func WatchIndex(ctx context.Context, client base.Client, settings WatchSettings) <-chan IndexEvent {
+	if settings.Interval <= 0 {
+		settings.Interval = 5 * time.Second
+	}
+	if settings.Options == nil {
+		settings.Options = &options.Options{}
+	}
+
+	events := make(chan IndexEvent, settings.BufferSize)
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(settings.Interval)
+		defer ticker.Stop()
+
+		var previous []models.ModelRepositoryIndexResponse
+		for {
+			index, err := client.GetModelRepositoryIndex(ctx, settings.Options)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if !send(ctx, events, IndexEvent{Change: IndexChangeError, Err: err}) {
+					return
+				}
+			} else {
+				for _, event := range diffIndex(previous, index) {
+					if !send(ctx, events, event) {
+						return
+					}
+				}
+				previous = index
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return events
+}
+
+// diffIndex returns the events turning the previous index into the current one.
+func diffIndex(previous, current []models.ModelRepositoryIndexResponse) []IndexEvent {
+	type key struct{ name, version string }
+	previousEntries := make(map[key]models.ModelRepositoryIndexResponse, len(previous))
+	for _, entry := range previous {
+		previousEntries[key{entry.Name, entry.Version}] = entry
+	}
+
+	var events []IndexEvent
+	seen := make(map[key]bool, len(current))
+	for _, entry := range current {
+		k := key{entry.Name, entry.Version}
+		seen[k] = true
+		old, ok := previousEntries[k]
+		switch {
+		case !ok:
+			events = append(events, IndexEvent{Change: IndexChangeAdded, Current: entry})
+		case old.State != entry.State:
+			events = append(events, IndexEvent{Change: IndexChangeState, Previous: old, Current: entry})
+		case old.Reason != entry.Reason:
+			events = append(events, IndexEvent{Change: IndexChangeReason, Previous: old, Current: entry})
+		}
+	}
+	for _, entry := range previous {
+		if !seen[key{entry.Name, entry.Version}] {
+			events = append(events, IndexEvent{Change: IndexChangeRemoved, Previous: entry})
+		}
+	}
+	return events
+}
+
+func send(ctx context.Context, events chan<- IndexEvent, event IndexEvent) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case events <- event:
+		return true
+	}
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+)
+
+func TestWatchIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := base.NewMockClient(ctrl)
+
+	encoder := models.ModelRepositoryIndexResponse{Name: "encoder", Version: "1", State: models.ModelStateReady}
+	ranker := models.ModelRepositoryIndexResponse{Name: "ranker", Version: "1", State: models.ModelStateLoading}
+	rankerReady := models.ModelRepositoryIndexResponse{Name: "ranker", Version: "1", State: models.ModelStateReady}
+	encoderFailed := models.ModelRepositoryIndexResponse{Name: "encoder", Version: "1", State: models.ModelStateUnavailable, Reason: "out of memory"}
+	encoderFailedAgain := models.ModelRepositoryIndexResponse{Name: "encoder", Version: "1", State: models.ModelStateUnavailable, Reason: "file not found"}
+
+	gomock.InOrder(
+		client.EXPECT().GetModelRepositoryIndex(gomock.Any(), gomock.Any()).Return([]models.ModelRepositoryIndexResponse{encoder, ranker}, nil),
+		client.EXPECT().GetModelRepositoryIndex(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused")),
+		client.EXPECT().GetModelRepositoryIndex(gomock.Any(), gomock.Any()).Return([]models.ModelRepositoryIndexResponse{encoderFailed, rankerReady}, nil),
+		client.EXPECT().GetModelRepositoryIndex(gomock.Any(), gomock.Any()).Return([]models.ModelRepositoryIndexResponse{encoderFailedAgain}, nil),
+		client.EXPECT().GetModelRepositoryIndex(gomock.Any(), gomock.Any()).Return([]models.ModelRepositoryIndexResponse{encoderFailedAgain}, nil).AnyTimes(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := WatchIndex(ctx, client, WatchSettings{Interval: time.Millisecond})
+
+	var received []IndexEvent
+	for len(received) < 7 {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-time.After(time.Second):
+			t.Fatalf("received %d events", len(received))
+		}
+	}
+
+	assert.Equal(t, []IndexEvent{
+		{Change: IndexChangeAdded, Current: encoder},
+		{Change: IndexChangeAdded, Current: ranker},
+		{Change: IndexChangeError, Err: errors.New("connection refused")},
+		{Change: IndexChangeState, Previous: encoder, Current: encoderFailed},
+		{Change: IndexChangeState, Previous: ranker, Current: rankerReady},
+		{Change: IndexChangeReason, Previous: encoderFailed, Current: encoderFailedAgain},
+		{Change: IndexChangeRemoved, Previous: rankerReady},
+	}, received)
+
+	cancel()
+	for range events {
+	}
+	_, open := <-events
+	require.False(t, open)
+}
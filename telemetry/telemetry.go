@@ -0,0 +1,250 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/base"
+	"github.com/Trendyol/go-triton-client/options"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+	"strings"
+	"time"
+)
+
+// instrumentationName is the name of the tracer and meter created by this package.
+const instrumentationName = "github.com/Trendyol/go-triton-client/telemetry"
+
+const (
+	AttributeOperation      = attribute.Key("triton.operation")
+	AttributeProtocol       = attribute.Key("triton.protocol")
+	AttributeModelName      = attribute.Key("triton.model.name")
+	AttributeModelVersion   = attribute.Key("triton.model.version")
+	AttributeRequestID      = attribute.Key("triton.request.id")
+	AttributeInputNames     = attribute.Key("triton.input.names")
+	AttributeInputDatatypes = attribute.Key("triton.input.datatypes")
+	AttributeInputShapes    = attribute.Key("triton.input.shapes")
+	AttributeRequestSize    = attribute.Key("triton.request.size")
+	AttributeResponseSize   = attribute.Key("triton.response.size")
+	AttributeStatus         = attribute.Key("triton.status")
+)
+
+// Settings configures the OpenTelemetry instrumentation. Zero-valued fields fall back to the global providers.
+type Settings struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	Propagator     propagation.TextMapPropagator
+}
+
+// instrumentation holds the tracer and instruments used by the interceptor.
+type instrumentation struct {
+	tracer       trace.Tracer
+	propagator   propagation.TextMapPropagator
+	duration     metric.Float64Histogram
+	requestSize  metric.Int64Histogram
+	responseSize metric.Int64Histogram
+}
+
+// NewInterceptor returns an interceptor that records a span and metrics for every client call,
+// and propagates the trace context to the server through HTTP headers or gRPC metadata.
+func NewInterceptor(settings Settings) (base.UnaryInterceptor, error) {
+	if settings.TracerProvider == nil {
+		settings.TracerProvider = otel.GetTracerProvider()
+	}
+	if settings.MeterProvider == nil {
+		settings.MeterProvider = otel.GetMeterProvider()
+	}
+	if settings.Propagator == nil {
+		settings.Propagator = otel.GetTextMapPropagator()
+	}
+
+	meter := settings.MeterProvider.Meter(instrumentationName)
+
+	duration, err := meter.Float64Histogram(
+		"triton.client.duration",
+		metric.WithDescription("Duration of Triton client calls."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	requestSize, err := meter.Int64Histogram(
+		"triton.client.request.size",
+		metric.WithDescription("Size of the binary input data sent with inference requests."),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	responseSize, err := meter.Int64Histogram(
+		"triton.client.response.size",
+		metric.WithDescription("Size of the binary output data received with inference responses."),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &instrumentation{
+		tracer:       settings.TracerProvider.Tracer(instrumentationName),
+		propagator:   settings.Propagator,
+		duration:     duration,
+		requestSize:  requestSize,
+		responseSize: responseSize,
+	}
+	return i.intercept, nil
+}
+
+func (i *instrumentation) intercept(ctx context.Context, op base.Operation, req *base.Request, invoker base.Invoker) (any, error) {
+	attributes := []attribute.KeyValue{
+		AttributeOperation.String(string(op)),
+		AttributeProtocol.String(string(req.Protocol)),
+	}
+	if req.ModelName != "" {
+		attributes = append(attributes, AttributeModelName.String(req.ModelName))
+	}
+	if req.ModelVersion != "" {
+		attributes = append(attributes, AttributeModelVersion.String(req.ModelVersion))
+	}
+
+	ctx, span := i.tracer.Start(ctx, "Triton "+string(op),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+	defer span.End()
+
+	var requestSize int64
+	if op == base.OperationInfer {
+		requestSize = inputSize(req.Inputs)
+		span.SetAttributes(inputAttributes(req)...)
+		span.SetAttributes(AttributeRequestSize.Int64(requestSize))
+	}
+
+	ctx = i.inject(ctx, op, req)
+
+	start := time.Now()
+	result, err := invoker(ctx, req)
+	elapsed := time.Since(start)
+
+	status := "ok"
+	if err != nil {
+		status = "error"
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	metricAttributes := metric.WithAttributes(append(attributes, AttributeStatus.String(status))...)
+	i.duration.Record(ctx, elapsed.Seconds(), metricAttributes)
+
+	if op == base.OperationInfer {
+		i.requestSize.Record(ctx, requestSize, metricAttributes)
+		if sized, ok := result.(interface{ GetBufferSize() int }); ok && err == nil {
+			responseSize := int64(sized.GetBufferSize())
+			span.SetAttributes(AttributeResponseSize.Int64(responseSize))
+			i.responseSize.Record(ctx, responseSize, metricAttributes)
+		}
+	}
+
+	return result, err
+}
+
+// inject propagates the trace context of ctx to the server. HTTP requests carry it in their
+// headers and gRPC requests in their outgoing metadata; both are used when the protocol is unknown.
+func (i *instrumentation) inject(ctx context.Context, op base.Operation, req *base.Request) context.Context {
+	carrier := propagation.MapCarrier{}
+	i.propagator.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return ctx
+	}
+
+	if req.Protocol != base.ProtocolGRPC {
+		if op == base.OperationInfer {
+			injected := options.InferOptions{}
+			if req.InferOptions != nil {
+				injected = *req.InferOptions
+			}
+			injected.Headers = mergeHeaders(injected.Headers, carrier)
+			req.InferOptions = &injected
+		} else {
+			injected := options.Options{}
+			if req.Options != nil {
+				injected = *req.Options
+			}
+			injected.Headers = mergeHeaders(injected.Headers, carrier)
+			req.Options = &injected
+		}
+	}
+
+	if req.Protocol != base.ProtocolHTTP {
+		pairs := make([]string, 0, len(carrier)*2)
+		for key, value := range carrier {
+			pairs = append(pairs, key, value)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, pairs...)
+	}
+
+	return ctx
+}
+
+// mergeHeaders returns a copy of headers with the propagated fields added, leaving the caller's map untouched.
+func mergeHeaders(headers map[string]string, carrier propagation.MapCarrier) map[string]string {
+	merged := make(map[string]string, len(headers)+len(carrier))
+	for key, value := range headers {
+		merged[key] = value
+	}
+	for key, value := range carrier {
+		merged[key] = value
+	}
+	return merged
+}
+
+// inputAttributes describes the input tensors and request ID of an inference request.
+func inputAttributes(req *base.Request) []attribute.KeyValue {
+	names := make([]string, len(req.Inputs))
+	datatypes := make([]string, len(req.Inputs))
+	shapes := make([]string, len(req.Inputs))
+	for idx, input := range req.Inputs {
+		if input == nil {
+			continue
+		}
+		names[idx] = input.GetName()
+		datatypes[idx] = input.GetDatatype()
+		shapes[idx] = formatShape(input.GetShape())
+	}
+
+	attributes := []attribute.KeyValue{
+		AttributeInputNames.StringSlice(names),
+		AttributeInputDatatypes.StringSlice(datatypes),
+		AttributeInputShapes.StringSlice(shapes),
+	}
+	if req.InferOptions != nil && req.InferOptions.RequestID != nil {
+		attributes = append(attributes, AttributeRequestID.String(*req.InferOptions.RequestID))
+	}
+	return attributes
+}
+
+// inputSize returns the total size in bytes of the binary input data.
+func inputSize(inputs []base.InferInput) int64 {
+	var size int64
+	for _, input := range inputs {
+		if input != nil {
+			size += int64(len(input.GetRawData()))
+		}
+	}
+	return size
+}
+
+// formatShape formats a tensor shape as "[1,128]".
+func formatShape(shape []int64) string {
+	dims := make([]string, len(shape))
+	for i, dim := range shape {
+		dims[i] = fmt.Sprint(dim)
+	}
+	return "[" + strings.Join(dims, ",") + "]"
+}
@@ -0,0 +1,210 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"github.com/Trendyol/go-triton-client/base"
+	triton "github.com/Trendyol/go-triton-client/client/http"
+	"github.com/Trendyol/go-triton-client/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testTelemetry struct {
+	exporter    *tracetest.InMemoryExporter
+	reader      *sdkmetric.ManualReader
+	tracer      trace.Tracer
+	interceptor base.UnaryInterceptor
+}
+
+func newTestTelemetry(t *testing.T) *testTelemetry {
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	interceptor, err := NewInterceptor(Settings{
+		TracerProvider: tracerProvider,
+		MeterProvider:  meterProvider,
+		Propagator:     propagation.TraceContext{},
+	})
+	require.NoError(t, err)
+
+	return &testTelemetry{
+		exporter:    exporter,
+		reader:      reader,
+		tracer:      tracerProvider.Tracer("test"),
+		interceptor: interceptor,
+	}
+}
+
+func (tt *testTelemetry) metrics(t *testing.T) map[string]metricdata.Aggregation {
+	var data metricdata.ResourceMetrics
+	require.NoError(t, tt.reader.Collect(context.Background(), &data))
+
+	result := make(map[string]metricdata.Aggregation)
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			result[m.Name] = m.Data
+		}
+	}
+	return result
+}
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	result := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes {
+		result[kv.Key] = kv.Value
+	}
+	return result
+}
+
+func TestInterceptor_HTTPInferPropagatesTraceContext(t *testing.T) {
+	tt := newTestTelemetry(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"model_name":"model","model_version":"1","outputs":[]}`))
+	}))
+	defer server.Close()
+
+	client, err := triton.NewClient(strings.TrimPrefix(server.URL, "http://"), false, 1000, 1000, false, false, nil, nil, tt.interceptor)
+	require.NoError(t, err)
+
+	input := triton.NewInferInput("input_ids", "INT64", []int64{1, 2}, nil)
+	require.NoError(t, input.SetData([]int64{1, 2}, true))
+
+	requestID := "request-1"
+	ctx, parent := tt.tracer.Start(context.Background(), "parent")
+	_, err = client.Infer(ctx, "model", "1", []base.InferInput{input}, nil, &options.InferOptions{RequestID: &requestID})
+	parent.End()
+	require.NoError(t, err)
+
+	spans := tt.exporter.GetSpans()
+	require.Len(t, spans, 2)
+	span := spans[0]
+
+	assert.Equal(t, "Triton Infer", span.Name)
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+	assert.Equal(t, parent.SpanContext().TraceID(), span.SpanContext.TraceID())
+	assert.Equal(t, "00-"+span.SpanContext.TraceID().String()+"-"+span.SpanContext.SpanID().String()+"-01", traceparent)
+
+	attributes := spanAttributes(span)
+	assert.Equal(t, "http", attributes[AttributeProtocol].AsString())
+	assert.Equal(t, "model", attributes[AttributeModelName].AsString())
+	assert.Equal(t, "1", attributes[AttributeModelVersion].AsString())
+	assert.Equal(t, "request-1", attributes[AttributeRequestID].AsString())
+	assert.Equal(t, []string{"input_ids"}, attributes[AttributeInputNames].AsStringSlice())
+	assert.Equal(t, []string{"INT64"}, attributes[AttributeInputDatatypes].AsStringSlice())
+	assert.Equal(t, []string{"[1,2]"}, attributes[AttributeInputShapes].AsStringSlice())
+	assert.Equal(t, int64(16), attributes[AttributeRequestSize].AsInt64())
+
+	metrics := tt.metrics(t)
+	duration := metrics["triton.client.duration"].(metricdata.Histogram[float64])
+	require.Len(t, duration.DataPoints, 1)
+	assert.Equal(t, uint64(1), duration.DataPoints[0].Count)
+	status, _ := duration.DataPoints[0].Attributes.Value(AttributeStatus)
+	assert.Equal(t, "ok", status.AsString())
+
+	requestSize := metrics["triton.client.request.size"].(metricdata.Histogram[int64])
+	require.Len(t, requestSize.DataPoints, 1)
+	assert.Equal(t, int64(16), requestSize.DataPoints[0].Sum)
+
+	_, ok := metrics["triton.client.response.size"]
+	assert.True(t, ok)
+}
+
+func TestInterceptor_GRPCPropagatesTraceContextInMetadata(t *testing.T) {
+	tt := newTestTelemetry(t)
+
+	var md metadata.MD
+	invoker := func(ctx context.Context, req *base.Request) (any, error) {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		assert.Nil(t, req.Options.Headers)
+		return true, nil
+	}
+
+	ctx, parent := tt.tracer.Start(context.Background(), "parent")
+	defer parent.End()
+
+	_, err := tt.interceptor(ctx, base.OperationIsModelReady, &base.Request{
+		Protocol:  base.ProtocolGRPC,
+		ModelName: "model",
+		Options:   &options.Options{},
+	}, invoker)
+	require.NoError(t, err)
+
+	spans := tt.exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "Triton IsModelReady", spans[0].Name)
+	assert.Equal(t, []string{"00-" + spans[0].SpanContext.TraceID().String() + "-" + spans[0].SpanContext.SpanID().String() + "-01"}, md.Get("traceparent"))
+}
+
+func TestInterceptor_DoesNotModifyCallerHeaders(t *testing.T) {
+	tt := newTestTelemetry(t)
+
+	callerOptions := &options.Options{Headers: map[string]string{"X-Custom": "value"}}
+	var sent *options.Options
+	invoker := func(ctx context.Context, req *base.Request) (any, error) {
+		sent = req.Options
+		return true, nil
+	}
+
+	ctx, parent := tt.tracer.Start(context.Background(), "parent")
+	defer parent.End()
+
+	_, err := tt.interceptor(ctx, base.OperationIsServerReady, &base.Request{Protocol: base.ProtocolHTTP, Options: callerOptions}, invoker)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"X-Custom": "value"}, callerOptions.Headers)
+	assert.Equal(t, "value", sent.Headers["X-Custom"])
+	assert.NotEmpty(t, sent.Headers["traceparent"])
+}
+
+func TestInterceptor_RecordsErrors(t *testing.T) {
+	tt := newTestTelemetry(t)
+
+	failure := errors.New("model not found")
+	invoker := func(ctx context.Context, req *base.Request) (any, error) {
+		return nil, failure
+	}
+
+	_, err := tt.interceptor(context.Background(), base.OperationGetModelConfig, &base.Request{
+		Protocol:  base.ProtocolGRPC,
+		ModelName: "model",
+		Options:   &options.Options{},
+	}, invoker)
+	assert.ErrorIs(t, err, failure)
+
+	spans := tt.exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "model not found", spans[0].Status.Description)
+	require.Len(t, spans[0].Events, 1)
+	assert.Equal(t, "exception", spans[0].Events[0].Name)
+
+	duration := tt.metrics(t)["triton.client.duration"].(metricdata.Histogram[float64])
+	require.Len(t, duration.DataPoints, 1)
+	status, _ := duration.DataPoints[0].Attributes.Value(AttributeStatus)
+	assert.Equal(t, "error", status.AsString())
+}
+
+func TestFormatShape(t *testing.T) {
+	assert.Equal(t, "[]", formatShape(nil))
+	assert.Equal(t, "[1,-1,768]", formatShape([]int64{1, -1, 768}))
+}
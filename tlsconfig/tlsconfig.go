@@ -0,0 +1,263 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Settings describes the TLS configuration of a client. Files and PEM blocks may be combined:
+// the CA certificates of both are trusted, while a client certificate is read from either the files or the PEM blocks.
+type Settings struct {
+	// CertFile and KeyFile hold the PEM encoded client certificate and its private key, used for mutual TLS.
+	CertFile string
+	KeyFile  string
+	// CertPEM and KeyPEM hold the client certificate and key in memory. They are never reloaded.
+	CertPEM []byte
+	KeyPEM  []byte
+	// CAFile holds the PEM encoded certificates trusted to sign the server certificate.
+	CAFile string
+	// CAPEM holds additional trusted certificates in memory.
+	CAPEM []byte
+	// ServerName overrides the name expected in the server certificate and sent as SNI.
+	ServerName string
+	// InsecureSkipVerify disables the verification of the server certificate. Only use it for testing.
+	InsecureSkipVerify bool
+	// MinVersion is the minimum accepted TLS version. Defaults to TLS 1.2.
+	MinVersion uint16
+	// ReloadInterval enables the hot reload of CertFile, KeyFile and CAFile: on new connections, the files
+	// are checked for changes at most once per interval and reloaded when modified. Zero disables reloading.
+	ReloadInterval time.Duration
+	// OnReloadError is called when changed files cannot be reloaded. The previous certificates stay in use.
+	OnReloadError func(err error)
+}
+
+// NewTLSConfig builds a tls.Config for the HTTP and gRPC clients from the settings.
+// The files are read once and an error is returned if they are missing or invalid.
+//
+// When ReloadInterval is set and a CAFile is given, the server certificate is verified by the config itself
+// against the current CA certificates, so a server name is required: the SNI of the connection is used, or
+// ServerName when connecting to an IP address.
+func NewTLSConfig(settings Settings) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         settings.ServerName,
+		InsecureSkipVerify: settings.InsecureSkipVerify,
+		MinVersion:         settings.MinVersion,
+	}
+	if config.MinVersion == 0 {
+		config.MinVersion = tls.VersionTLS12
+	}
+
+	if err := configureClientCertificate(config, settings); err != nil {
+		return nil, err
+	}
+	if err := configureRootCAs(config, settings); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func configureClientCertificate(config *tls.Config, settings Settings) error {
+	if (settings.CertFile == "") != (settings.KeyFile == "") {
+		return errors.New("client certificate and key files should be given together")
+	}
+	if (len(settings.CertPEM) == 0) != (len(settings.KeyPEM) == 0) {
+		return errors.New("client certificate and key PEM blocks should be given together")
+	}
+	if settings.CertFile != "" && len(settings.CertPEM) != 0 {
+		return errors.New("client certificate should be given either as files or as PEM blocks")
+	}
+
+	if len(settings.CertPEM) != 0 {
+		certificate, err := tls.X509KeyPair(settings.CertPEM, settings.KeyPEM)
+		if err != nil {
+			return fmt.Errorf("parsing client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+		return nil
+	}
+
+	if settings.CertFile == "" {
+		return nil
+	}
+
+	certificates, err := newReloader(settings, []string{settings.CertFile, settings.KeyFile}, func() (*tls.Certificate, error) {
+		certificate, err := tls.LoadX509KeyPair(settings.CertFile, settings.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		return &certificate, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	config.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		return certificates.get(), nil
+	}
+	return nil
+}
+
+func configureRootCAs(config *tls.Config, settings Settings) error {
+	if settings.CAFile == "" && len(settings.CAPEM) == 0 {
+		return nil
+	}
+
+	loadPool := func() (*x509.CertPool, error) {
+		pool := x509.NewCertPool()
+		if len(settings.CAPEM) != 0 && !pool.AppendCertsFromPEM(settings.CAPEM) {
+			return nil, errors.New("no CA certificate found in the PEM block")
+		}
+		if settings.CAFile != "" {
+			pem, err := os.ReadFile(settings.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("loading CA certificates: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no CA certificate found in '%s'", settings.CAFile)
+			}
+		}
+		return pool, nil
+	}
+
+	if settings.ReloadInterval <= 0 || settings.CAFile == "" {
+		pool, err := loadPool()
+		if err != nil {
+			return err
+		}
+		config.RootCAs = pool
+		return nil
+	}
+
+	pools, err := newReloader(settings, []string{settings.CAFile}, loadPool)
+	if err != nil {
+		return err
+	}
+	if settings.InsecureSkipVerify {
+		return nil
+	}
+
+	// The client hello has no hook to swap RootCAs, so the standard verification is replaced
+	// by an equivalent one against the current pool.
+	config.InsecureSkipVerify = true
+	config.VerifyConnection = func(state tls.ConnectionState) error {
+		serverName := state.ServerName
+		if serverName == "" {
+			serverName = settings.ServerName
+		}
+		if serverName == "" {
+			return errors.New("server name is required to verify the server certificate, set ServerName when connecting to an IP address")
+		}
+		if len(state.PeerCertificates) == 0 {
+			return errors.New("server did not present a certificate")
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, certificate := range state.PeerCertificates[1:] {
+			intermediates.AddCert(certificate)
+		}
+		_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+			Roots:         pools.get(),
+			DNSName:       serverName,
+			Intermediates: intermediates,
+		})
+		return err
+	}
+	return nil
+}
+
+// reloader holds a value loaded from files and reloads it when the files change.
+type reloader[T any] struct {
+	mu        sync.Mutex
+	paths     []string
+	load      func() (T, error)
+	interval  time.Duration
+	onError   func(err error)
+	now       func() time.Time
+	value     T
+	modTimes  []time.Time
+	checkedAt time.Time
+}
+
+func newReloader[T any](settings Settings, paths []string, load func() (T, error)) (*reloader[T], error) {
+	r := &reloader[T]{
+		paths:    paths,
+		load:     load,
+		interval: settings.ReloadInterval,
+		onError:  settings.OnReloadError,
+		now:      time.Now,
+	}
+
+	modTimes, err := r.stat()
+	if err != nil {
+		return nil, err
+	}
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+	r.value = value
+	r.modTimes = modTimes
+	r.checkedAt = r.now()
+	return r, nil
+}
+
+// get returns the current value, reloading it first if the files changed since the last check.
+func (r *reloader[T]) get() T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.interval <= 0 || r.now().Sub(r.checkedAt) < r.interval {
+		return r.value
+	}
+	r.checkedAt = r.now()
+
+	modTimes, err := r.stat()
+	if err != nil {
+		r.reportError(err)
+		return r.value
+	}
+	if !r.changed(modTimes) {
+		return r.value
+	}
+
+	value, err := r.load()
+	if err != nil {
+		r.reportError(err)
+		return r.value
+	}
+	r.value = value
+	r.modTimes = modTimes
+	return r.value
+}
+
+func (r *reloader[T]) stat() ([]time.Time, error) {
+	modTimes := make([]time.Time, len(r.paths))
+	for i, path := range r.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func (r *reloader[T]) changed(modTimes []time.Time) bool {
+	for i, modTime := range modTimes {
+		if !modTime.Equal(r.modTimes[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *reloader[T]) reportError(err error) {
+	if r.onError != nil {
+		r.onError(err)
+	}
+}
@@ -0,0 +1,345 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/Trendyol/go-triton-client/base"
+	triton "github.com/Trendyol/go-triton-client/client/grpc"
+	"github.com/Trendyol/go-triton-client/client/grpc/grpc_generated_v2"
+	tritonhttp "github.com/Trendyol/go-triton-client/client/http"
+	"github.com/Trendyol/go-triton-client/options"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// authority is a certificate authority generated for the tests.
+type authority struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	pem         []byte
+}
+
+func newAuthority(t *testing.T, name string) *authority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &authority{
+		certificate: certificate,
+		key:         key,
+		pem:         pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue returns the PEM encoded certificate and key of a leaf signed by the authority.
+func (a *authority) issue(t *testing.T, name string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		DNSNames:     []string{"triton.internal"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.certificate, &key.PublicKey, a.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// serverTLSConfig returns the config of a server presenting a certificate for triton.internal
+// and requiring client certificates signed by clientCA.
+func serverTLSConfig(t *testing.T, serverCA, clientCA *authority) *tls.Config {
+	certPEM, keyPEM := serverCA.issue(t, "triton", x509.ExtKeyUsageServerAuth)
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCA.certificate)
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+}
+
+// newMutualTLSServer starts an HTTPS server answering with the common name of the client certificate.
+func newMutualTLSServer(t *testing.T, serverCA, clientCA *authority) *httptest.Server {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Client", r.TLS.PeerCertificates[0].Subject.CommonName)
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = serverTLSConfig(t, serverCA, clientCA)
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// clientName returns the common name of the client certificate seen by the server, using a new connection.
+func clientName(t *testing.T, config *tls.Config, url string) (string, error) {
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: config, DisableKeepAlives: true}}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	return resp.Header.Get("X-Client"), nil
+}
+
+func TestNewTLSConfig_MutualTLSWithHTTPClient(t *testing.T) {
+	serverCA, clientCA := newAuthority(t, "server-ca"), newAuthority(t, "client-ca")
+	server := newMutualTLSServer(t, serverCA, clientCA)
+
+	dir := t.TempDir()
+	certPEM, keyPEM := clientCA.issue(t, "client", x509.ExtKeyUsageClientAuth)
+	writeFile(t, filepath.Join(dir, "client.crt"), certPEM, time.Now())
+	writeFile(t, filepath.Join(dir, "client.key"), keyPEM, time.Now())
+	writeFile(t, filepath.Join(dir, "ca.crt"), serverCA.pem, time.Now())
+
+	config, err := NewTLSConfig(Settings{
+		CertFile:   filepath.Join(dir, "client.crt"),
+		KeyFile:    filepath.Join(dir, "client.key"),
+		CAFile:     filepath.Join(dir, "ca.crt"),
+		ServerName: "triton.internal",
+	})
+	require.NoError(t, err)
+
+	client, err := tritonhttp.New(server.URL, base.WithTLSConfig(config))
+	require.NoError(t, err)
+	live, err := client.IsServerLive(context.Background(), &options.Options{})
+	require.NoError(t, err)
+	assert.True(t, live)
+
+	withoutCertificate, err := NewTLSConfig(Settings{CAPEM: serverCA.pem, ServerName: "triton.internal"})
+	require.NoError(t, err)
+	client, err = tritonhttp.New(server.URL, base.WithTLSConfig(withoutCertificate))
+	require.NoError(t, err)
+	_, err = client.IsServerLive(context.Background(), &options.Options{})
+	assert.Error(t, err)
+}
+
+func TestNewTLSConfig_CustomCAAndServerName(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	config, err := NewTLSConfig(Settings{CAPEM: caPEM, ServerName: "example.com"})
+	require.NoError(t, err)
+	client, err := tritonhttp.New(server.URL, base.WithTLSConfig(config))
+	require.NoError(t, err)
+	live, err := client.IsServerLive(context.Background(), &options.Options{})
+	require.NoError(t, err)
+	assert.True(t, live)
+
+	config, err = NewTLSConfig(Settings{CAPEM: caPEM, ServerName: "triton.internal"})
+	require.NoError(t, err)
+	client, err = tritonhttp.New(server.URL, base.WithTLSConfig(config))
+	require.NoError(t, err)
+	_, err = client.IsServerLive(context.Background(), &options.Options{})
+	assert.ErrorContains(t, err, "certificate is valid for")
+
+	config, err = NewTLSConfig(Settings{})
+	require.NoError(t, err)
+	client, err = tritonhttp.New(server.URL, base.WithTLSConfig(config))
+	require.NoError(t, err)
+	_, err = client.IsServerLive(context.Background(), &options.Options{})
+	assert.ErrorContains(t, err, "unknown authority")
+}
+
+type liveServer struct {
+	grpc_generated_v2.UnimplementedGRPCInferenceServiceServer
+}
+
+func (s *liveServer) ServerLive(ctx context.Context, _ *grpc_generated_v2.ServerLiveRequest) (*grpc_generated_v2.ServerLiveResponse, error) {
+	p, _ := peer.FromContext(ctx)
+	tlsInfo := p.AuthInfo.(credentials.TLSInfo)
+	return &grpc_generated_v2.ServerLiveResponse{Live: tlsInfo.State.PeerCertificates[0].Subject.CommonName == "client"}, nil
+}
+
+func TestNewTLSConfig_MutualTLSWithGRPCClient(t *testing.T) {
+	serverCA, clientCA := newAuthority(t, "server-ca"), newAuthority(t, "client-ca")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(serverTLSConfig(t, serverCA, clientCA))))
+	grpc_generated_v2.RegisterGRPCInferenceServiceServer(server, &liveServer{})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	certPEM, keyPEM := clientCA.issue(t, "client", x509.ExtKeyUsageClientAuth)
+	config, err := NewTLSConfig(Settings{
+		CertPEM:    certPEM,
+		KeyPEM:     keyPEM,
+		CAPEM:      serverCA.pem,
+		ServerName: "triton.internal",
+	})
+	require.NoError(t, err)
+
+	client, err := triton.New(listener.Addr().String(), base.WithTLSConfig(config), base.WithNetworkTimeout(5*time.Second))
+	require.NoError(t, err)
+	live, err := client.IsServerLive(context.Background(), &options.Options{})
+	require.NoError(t, err)
+	assert.True(t, live)
+}
+
+func TestNewTLSConfig_ReloadsClientCertificate(t *testing.T) {
+	serverCA, clientCA := newAuthority(t, "server-ca"), newAuthority(t, "client-ca")
+	server := newMutualTLSServer(t, serverCA, clientCA)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	certPEM, keyPEM := clientCA.issue(t, "client-a", x509.ExtKeyUsageClientAuth)
+	modTime := time.Now().Add(-time.Minute)
+	writeFile(t, certFile, certPEM, modTime)
+	writeFile(t, keyFile, keyPEM, modTime)
+
+	var reloadErrors []error
+	config, err := NewTLSConfig(Settings{
+		CertFile:       certFile,
+		KeyFile:        keyFile,
+		CAPEM:          serverCA.pem,
+		ServerName:     "triton.internal",
+		ReloadInterval: time.Nanosecond,
+		OnReloadError:  func(err error) { reloadErrors = append(reloadErrors, err) },
+	})
+	require.NoError(t, err)
+
+	name, err := clientName(t, config, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "client-a", name)
+
+	// A half-written pair is reported and the previous certificate stays in use.
+	certPEM, keyPEM = clientCA.issue(t, "client-b", x509.ExtKeyUsageClientAuth)
+	writeFile(t, certFile, certPEM, modTime.Add(time.Second))
+	name, err = clientName(t, config, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "client-a", name)
+	assert.Len(t, reloadErrors, 1)
+
+	writeFile(t, keyFile, keyPEM, modTime.Add(time.Second))
+	name, err = clientName(t, config, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "client-b", name)
+}
+
+func TestNewTLSConfig_ReloadsCA(t *testing.T) {
+	serverCA, clientCA, otherCA := newAuthority(t, "server-ca"), newAuthority(t, "client-ca"), newAuthority(t, "other-ca")
+	server := newMutualTLSServer(t, serverCA, clientCA)
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	modTime := time.Now().Add(-time.Minute)
+	writeFile(t, caFile, otherCA.pem, modTime)
+
+	certPEM, keyPEM := clientCA.issue(t, "client", x509.ExtKeyUsageClientAuth)
+	config, err := NewTLSConfig(Settings{
+		CertPEM:        certPEM,
+		KeyPEM:         keyPEM,
+		CAFile:         caFile,
+		ServerName:     "triton.internal",
+		ReloadInterval: time.Nanosecond,
+	})
+	require.NoError(t, err)
+
+	_, err = clientName(t, config, server.URL)
+	assert.ErrorContains(t, err, "unknown authority")
+
+	writeFile(t, caFile, serverCA.pem, modTime.Add(time.Second))
+	name, err := clientName(t, config, server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "client", name)
+
+	config, err = NewTLSConfig(Settings{
+		CertPEM:        certPEM,
+		KeyPEM:         keyPEM,
+		CAFile:         caFile,
+		ReloadInterval: time.Nanosecond,
+	})
+	require.NoError(t, err)
+	_, err = clientName(t, config, server.URL)
+	assert.ErrorContains(t, err, "server name is required")
+}
+
+func TestNewTLSConfig_InvalidSettings(t *testing.T) {
+	ca := newAuthority(t, "ca")
+	certPEM, keyPEM := ca.issue(t, "client", x509.ExtKeyUsageClientAuth)
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "client.crt"), certPEM, time.Now())
+
+	tests := []struct {
+		name     string
+		settings Settings
+		expected string
+	}{
+		{name: "cert file without key", settings: Settings{CertFile: filepath.Join(dir, "client.crt")}, expected: "should be given together"},
+		{name: "cert PEM without key", settings: Settings{CertPEM: certPEM}, expected: "should be given together"},
+		{name: "files and PEM", settings: Settings{CertFile: "a", KeyFile: "b", CertPEM: certPEM, KeyPEM: keyPEM}, expected: "either as files or as PEM blocks"},
+		{name: "mismatched PEM", settings: Settings{CertPEM: certPEM, KeyPEM: certPEM}, expected: "parsing client certificate"},
+		{name: "missing key file", settings: Settings{CertFile: filepath.Join(dir, "client.crt"), KeyFile: filepath.Join(dir, "missing.key")}, expected: "no such file"},
+		{name: "missing CA file", settings: Settings{CAFile: filepath.Join(dir, "missing.crt")}, expected: "loading CA certificates"},
+		{name: "invalid CA PEM", settings: Settings{CAPEM: []byte("invalid")}, expected: "no CA certificate found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTLSConfig(tt.settings)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestNewTLSConfig_Defaults(t *testing.T) {
+	config, err := NewTLSConfig(Settings{ServerName: "triton.internal", InsecureSkipVerify: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+	assert.Equal(t, "triton.internal", config.ServerName)
+	assert.True(t, config.InsecureSkipVerify)
+	assert.Nil(t, config.GetClientCertificate)
+	assert.Nil(t, config.RootCAs)
+}
@@ -0,0 +1,235 @@
+package tokenizer
+
+import (
+	"fmt"
+	"github.com/Trendyol/go-triton-client/tokenizer/models"
+	"github.com/Trendyol/go-triton-client/tokenizer/options"
+	"runtime"
+	"sync"
+)
+
+// encodeBatch encodes count items concurrently with encode, which also truncates them, then pads the encodings.
+func encodeBatch(count int, batchOptions *options.BatchEncodeOptions, encode func(i int, opts options.BatchEncodeOptions) (*models.EncodeResponse, error)) (*models.BatchEncodeResponse, error) {
+	opts, err := withBatchDefaults(batchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	encodings := make([]*models.EncodeResponse, count)
+	errs := make([]error, count)
+	parallel(count, opts.Concurrency, func(i int) {
+		encodings[i], errs[i] = encode(i, opts)
+	})
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+
+	return pad(encodings, opts), nil
+}
+
+// encodeTexts encodes single texts with encode, which must return the special tokens mask.
+func encodeTexts(texts []string, batchOptions *options.BatchEncodeOptions, encode func(text string) *models.EncodeResponse) (*models.BatchEncodeResponse, error) {
+	return encodeBatch(len(texts), batchOptions, func(i int, opts options.BatchEncodeOptions) (*models.EncodeResponse, error) {
+		if opts.Truncation == options.TruncationOnlySecond {
+			return nil, fmt.Errorf("truncating only the second sequence requires pairs")
+		}
+		encoding := encode(texts[i])
+		if opts.MaxLength > 0 {
+			if err := truncate(encoding, opts.MaxLength); err != nil {
+				return nil, err
+			}
+		}
+		return encoding, nil
+	})
+}
+
+// encodePairs encodes pairs of texts with encode, which must not add special tokens,
+// then truncates and merges every pair with the template of processor.
+func encodePairs(texts []string, pairs []string, batchOptions *options.BatchEncodeOptions, processor *postProcessor, encode func(text string) *models.EncodeResponse) (*models.BatchEncodeResponse, error) {
+	if len(texts) != len(pairs) {
+		return nil, fmt.Errorf("got %d texts and %d pairs", len(texts), len(pairs))
+	}
+	addSpecialTokens := batchOptions != nil && batchOptions.EncodeSpecialTokens
+	return encodeBatch(len(texts), batchOptions, func(i int, opts options.BatchEncodeOptions) (*models.EncodeResponse, error) {
+		return encodePair(encode(texts[i]), encode(pairs[i]), processor, addSpecialTokens, opts.MaxLength, opts.Truncation)
+	})
+}
+
+func withBatchDefaults(batchOptions *options.BatchEncodeOptions) (options.BatchEncodeOptions, error) {
+	var opts options.BatchEncodeOptions
+	if batchOptions != nil {
+		opts = *batchOptions
+	}
+	if opts.Truncation == "" {
+		opts.Truncation = options.TruncationLongestFirst
+	}
+	if opts.Padding == "" {
+		opts.Padding = options.PaddingLongest
+	}
+	if opts.PaddingSide == "" {
+		opts.PaddingSide = options.PaddingRight
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = runtime.GOMAXPROCS(0)
+	}
+
+	switch opts.Truncation {
+	case options.TruncationLongestFirst, options.TruncationOnlyFirst, options.TruncationOnlySecond:
+	default:
+		return opts, fmt.Errorf("unknown truncation strategy '%s'", opts.Truncation)
+	}
+	switch opts.Padding {
+	case options.PaddingLongest, options.PaddingNone:
+	case options.PaddingMaxLength:
+		if opts.MaxLength <= 0 {
+			return opts, fmt.Errorf("padding to the max length requires a max length")
+		}
+	default:
+		return opts, fmt.Errorf("unknown padding strategy '%s'", opts.Padding)
+	}
+	if opts.PaddingSide != options.PaddingRight && opts.PaddingSide != options.PaddingLeft {
+		return opts, fmt.Errorf("unknown padding side '%s'", opts.PaddingSide)
+	}
+	if opts.MaxLength < 0 || opts.PadToMultipleOf < 0 {
+		return opts, fmt.Errorf("max length and pad to multiple of can't be negative")
+	}
+	return opts, nil
+}
+
+// parallel calls fn for every index in [0, n) from at most concurrency goroutines.
+func parallel(n int, concurrency int, fn func(i int)) {
+	if concurrency > n {
+		concurrency = n
+	}
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+}
+
+// truncate removes the last non-special tokens of an encoding longer than maxLength,
+// keeping the special tokens added around the text.
+func truncate(encoding *models.EncodeResponse, maxLength int) error {
+	excess := len(encoding.IDs) - maxLength
+	if excess <= 0 {
+		return nil
+	}
+
+	specialTokens := 0
+	for _, special := range encoding.SpecialTokensMask {
+		specialTokens += int(special)
+	}
+	if specialTokens > maxLength {
+		return fmt.Errorf("max length %d is shorter than the %d special tokens", maxLength, specialTokens)
+	}
+
+	keepContent := len(encoding.IDs) - specialTokens - excess
+	indices := make([]int, 0, maxLength)
+	for i := range encoding.IDs {
+		if isSpecial(encoding, i) {
+			indices = append(indices, i)
+		} else if keepContent > 0 {
+			indices = append(indices, i)
+			keepContent--
+		}
+	}
+	keep(encoding, indices)
+	return nil
+}
+
+func isSpecial(encoding *models.EncodeResponse, i int) bool {
+	return i < len(encoding.SpecialTokensMask) && encoding.SpecialTokensMask[i] == 1
+}
+
+// keep reduces every attribute of an encoding to the tokens at the given indices.
+func keep(encoding *models.EncodeResponse, indices []int) {
+	encoding.IDs = pick(encoding.IDs, indices)
+	encoding.TypeIDs = pick(encoding.TypeIDs, indices)
+	encoding.SpecialTokensMask = pick(encoding.SpecialTokensMask, indices)
+	encoding.AttentionMask = pick(encoding.AttentionMask, indices)
+	encoding.Tokens = pick(encoding.Tokens, indices)
+	encoding.Offsets = pick(encoding.Offsets, indices)
+}
+
+func pick[T any](values []T, indices []int) []T {
+	if len(values) == 0 {
+		return values
+	}
+	picked := make([]T, len(indices))
+	for i, index := range indices {
+		picked[i] = values[index]
+	}
+	return picked
+}
+
+// pad builds the batch matrices, padding every encoding to the length selected by the padding strategy.
+func pad(encodings []*models.EncodeResponse, opts options.BatchEncodeOptions) *models.BatchEncodeResponse {
+	length := 0
+	switch opts.Padding {
+	case options.PaddingLongest:
+		for _, encoding := range encodings {
+			length = max(length, len(encoding.IDs))
+		}
+	case options.PaddingMaxLength:
+		length = opts.MaxLength
+	}
+	if opts.PadToMultipleOf > 0 && length%opts.PadToMultipleOf != 0 {
+		length += opts.PadToMultipleOf - length%opts.PadToMultipleOf
+	}
+
+	response := &models.BatchEncodeResponse{
+		IDs:               make([][]uint32, len(encodings)),
+		TypeIDs:           make([][]uint32, len(encodings)),
+		SpecialTokensMask: make([][]uint32, len(encodings)),
+		AttentionMask:     make([][]uint32, len(encodings)),
+	}
+	for i, encoding := range encodings {
+		padding := max(length-len(encoding.IDs), 0)
+		response.IDs[i] = padRow(encoding.IDs, padding, opts.PadID, opts.PaddingSide)
+		response.TypeIDs[i] = padRow(filled(encoding.TypeIDs, len(encoding.IDs), 0), padding, opts.PadTypeID, opts.PaddingSide)
+		response.SpecialTokensMask[i] = padRow(filled(encoding.SpecialTokensMask, len(encoding.IDs), 0), padding, 1, opts.PaddingSide)
+		response.AttentionMask[i] = padRow(filled(encoding.AttentionMask, len(encoding.IDs), 1), padding, 0, opts.PaddingSide)
+	}
+	return response
+}
+
+// filled returns values, or length copies of value when the attribute was not returned.
+func filled(values []uint32, length int, value uint32) []uint32 {
+	if len(values) == length {
+		return values
+	}
+	row := make([]uint32, length)
+	for i := range row {
+		row[i] = value
+	}
+	return row
+}
+
+func padRow(row []uint32, padding int, value uint32, side options.PaddingSide) []uint32 {
+	padded := make([]uint32, 0, len(row)+padding)
+	if side == options.PaddingLeft {
+		for i := 0; i < padding; i++ {
+			padded = append(padded, value)
+		}
+		return append(padded, row...)
+	}
+	padded = append(padded, row...)
+	for i := 0; i < padding; i++ {
+		padded = append(padded, value)
+	}
+	return padded
+}
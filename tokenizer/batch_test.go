@@ -0,0 +1,280 @@
+package tokenizer
+
+import (
+	"github.com/Trendyol/go-triton-client/tokenizer/models"
+	"github.com/Trendyol/go-triton-client/tokenizer/options"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWithBatchDefaults(t *testing.T) {
+	opts, err := withBatchDefaults(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Truncation != options.TruncationLongestFirst || opts.Padding != options.PaddingLongest ||
+		opts.PaddingSide != options.PaddingRight || opts.Concurrency <= 0 {
+		t.Errorf("got defaults %+v", opts)
+	}
+
+	tests := []struct {
+		name    string
+		options options.BatchEncodeOptions
+		err     string
+	}{
+		{"unknown truncation", options.BatchEncodeOptions{Truncation: "only_third"}, "unknown truncation strategy 'only_third'"},
+		{"unknown padding", options.BatchEncodeOptions{Padding: "shortest"}, "unknown padding strategy 'shortest'"},
+		{"max length padding without max length", options.BatchEncodeOptions{Padding: options.PaddingMaxLength}, "requires a max length"},
+		{"unknown padding side", options.BatchEncodeOptions{PaddingSide: "top"}, "unknown padding side 'top'"},
+		{"negative max length", options.BatchEncodeOptions{MaxLength: -1}, "can't be negative"},
+		{"negative pad to multiple of", options.BatchEncodeOptions{PadToMultipleOf: -8}, "can't be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := withBatchDefaults(&tt.options)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got error %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	// [CLS] brown fox jumps [SEP]
+	encoding := func() *models.EncodeResponse {
+		return &models.EncodeResponse{
+			IDs:               []uint32{101, 1, 2, 3, 102},
+			TypeIDs:           []uint32{0, 0, 0, 0, 0},
+			SpecialTokensMask: []uint32{1, 0, 0, 0, 1},
+			AttentionMask:     []uint32{1, 1, 1, 1, 1},
+			Tokens:            []string{"[CLS]", "brown", "fox", "jumps", "[SEP]"},
+			Offsets:           []models.Offset{{0, 0}, {0, 5}, {6, 9}, {10, 15}, {0, 0}},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		maxLength int
+		want      *models.EncodeResponse
+		err       string
+	}{
+		{
+			name:      "fits",
+			maxLength: 5,
+			want:      encoding(),
+		},
+		{
+			name:      "keeps the special tokens",
+			maxLength: 3,
+			want: &models.EncodeResponse{
+				IDs:               []uint32{101, 1, 102},
+				TypeIDs:           []uint32{0, 0, 0},
+				SpecialTokensMask: []uint32{1, 0, 1},
+				AttentionMask:     []uint32{1, 1, 1},
+				Tokens:            []string{"[CLS]", "brown", "[SEP]"},
+				Offsets:           []models.Offset{{0, 0}, {0, 5}, {0, 0}},
+			},
+		},
+		{
+			name:      "only the special tokens",
+			maxLength: 2,
+			want: &models.EncodeResponse{
+				IDs:               []uint32{101, 102},
+				TypeIDs:           []uint32{0, 0},
+				SpecialTokensMask: []uint32{1, 1},
+				AttentionMask:     []uint32{1, 1},
+				Tokens:            []string{"[CLS]", "[SEP]"},
+				Offsets:           []models.Offset{{0, 0}, {0, 0}},
+			},
+		},
+		{
+			name:      "shorter than the special tokens",
+			maxLength: 1,
+			err:       "max length 1 is shorter than the 2 special tokens",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := encoding()
+			err := truncate(got, tt.maxLength)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("got error %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPad(t *testing.T) {
+	encodings := func() []*models.EncodeResponse {
+		return []*models.EncodeResponse{
+			{IDs: []uint32{101, 1, 2, 102}, TypeIDs: []uint32{0, 0, 0, 0}, SpecialTokensMask: []uint32{1, 0, 0, 1}, AttentionMask: []uint32{1, 1, 1, 1}},
+			{IDs: []uint32{101, 3, 102}, TypeIDs: []uint32{0, 0, 0}, SpecialTokensMask: []uint32{1, 0, 1}, AttentionMask: []uint32{1, 1, 1}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		options options.BatchEncodeOptions
+		want    *models.BatchEncodeResponse
+	}{
+		{
+			name:    "longest",
+			options: options.BatchEncodeOptions{Padding: options.PaddingLongest, PaddingSide: options.PaddingRight},
+			want: &models.BatchEncodeResponse{
+				IDs:               [][]uint32{{101, 1, 2, 102}, {101, 3, 102, 0}},
+				TypeIDs:           [][]uint32{{0, 0, 0, 0}, {0, 0, 0, 0}},
+				SpecialTokensMask: [][]uint32{{1, 0, 0, 1}, {1, 0, 1, 1}},
+				AttentionMask:     [][]uint32{{1, 1, 1, 1}, {1, 1, 1, 0}},
+			},
+		},
+		{
+			name:    "max length",
+			options: options.BatchEncodeOptions{Padding: options.PaddingMaxLength, MaxLength: 6, PadID: 9, PadTypeID: 2, PaddingSide: options.PaddingRight},
+			want: &models.BatchEncodeResponse{
+				IDs:               [][]uint32{{101, 1, 2, 102, 9, 9}, {101, 3, 102, 9, 9, 9}},
+				TypeIDs:           [][]uint32{{0, 0, 0, 0, 2, 2}, {0, 0, 0, 2, 2, 2}},
+				SpecialTokensMask: [][]uint32{{1, 0, 0, 1, 1, 1}, {1, 0, 1, 1, 1, 1}},
+				AttentionMask:     [][]uint32{{1, 1, 1, 1, 0, 0}, {1, 1, 1, 0, 0, 0}},
+			},
+		},
+		{
+			name:    "longest rounded to a multiple",
+			options: options.BatchEncodeOptions{Padding: options.PaddingLongest, PadToMultipleOf: 3, PaddingSide: options.PaddingRight},
+			want: &models.BatchEncodeResponse{
+				IDs:               [][]uint32{{101, 1, 2, 102, 0, 0}, {101, 3, 102, 0, 0, 0}},
+				TypeIDs:           [][]uint32{{0, 0, 0, 0, 0, 0}, {0, 0, 0, 0, 0, 0}},
+				SpecialTokensMask: [][]uint32{{1, 0, 0, 1, 1, 1}, {1, 0, 1, 1, 1, 1}},
+				AttentionMask:     [][]uint32{{1, 1, 1, 1, 0, 0}, {1, 1, 1, 0, 0, 0}},
+			},
+		},
+		{
+			name:    "multiple already reached",
+			options: options.BatchEncodeOptions{Padding: options.PaddingLongest, PadToMultipleOf: 2, PaddingSide: options.PaddingRight},
+			want: &models.BatchEncodeResponse{
+				IDs:               [][]uint32{{101, 1, 2, 102}, {101, 3, 102, 0}},
+				TypeIDs:           [][]uint32{{0, 0, 0, 0}, {0, 0, 0, 0}},
+				SpecialTokensMask: [][]uint32{{1, 0, 0, 1}, {1, 0, 1, 1}},
+				AttentionMask:     [][]uint32{{1, 1, 1, 1}, {1, 1, 1, 0}},
+			},
+		},
+		{
+			name:    "left side",
+			options: options.BatchEncodeOptions{Padding: options.PaddingMaxLength, MaxLength: 5, PadID: 9, PadTypeID: 2, PaddingSide: options.PaddingLeft},
+			want: &models.BatchEncodeResponse{
+				IDs:               [][]uint32{{9, 101, 1, 2, 102}, {9, 9, 101, 3, 102}},
+				TypeIDs:           [][]uint32{{2, 0, 0, 0, 0}, {2, 2, 0, 0, 0}},
+				SpecialTokensMask: [][]uint32{{1, 1, 0, 0, 1}, {1, 1, 1, 0, 1}},
+				AttentionMask:     [][]uint32{{0, 1, 1, 1, 1}, {0, 0, 1, 1, 1}},
+			},
+		},
+		{
+			name:    "none",
+			options: options.BatchEncodeOptions{Padding: options.PaddingNone, PaddingSide: options.PaddingRight},
+			want: &models.BatchEncodeResponse{
+				IDs:               [][]uint32{{101, 1, 2, 102}, {101, 3, 102}},
+				TypeIDs:           [][]uint32{{0, 0, 0, 0}, {0, 0, 0}},
+				SpecialTokensMask: [][]uint32{{1, 0, 0, 1}, {1, 0, 1}},
+				AttentionMask:     [][]uint32{{1, 1, 1, 1}, {1, 1, 1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pad(encodings(), tt.options); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPadFillsMissingAttributes(t *testing.T) {
+	got := pad([]*models.EncodeResponse{{IDs: []uint32{5, 6}}}, options.BatchEncodeOptions{
+		Padding: options.PaddingMaxLength, MaxLength: 3, PaddingSide: options.PaddingRight,
+	})
+	want := &models.BatchEncodeResponse{
+		IDs:               [][]uint32{{5, 6, 0}},
+		TypeIDs:           [][]uint32{{0, 0, 0}},
+		SpecialTokensMask: [][]uint32{{0, 0, 1}},
+		AttentionMask:     [][]uint32{{1, 1, 0}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+// encodeOnly hides the BatchEncoder implementation of a tokenizer.
+type encodeOnly struct {
+	Tokenizer
+}
+
+func TestEncodeBatch(t *testing.T) {
+	tk := loadFixture(t, NewPureGoTokenizerFromBytes, "wordpiece.json")
+	texts := []string{"brown fox jumps over the lazy dog", "lazy dog"}
+
+	tests := []struct {
+		name    string
+		options options.BatchEncodeOptions
+		want    [][]uint32
+		err     string
+	}{
+		{
+			name:    "longest first",
+			options: options.BatchEncodeOptions{EncodeSpecialTokens: true, MaxLength: 5},
+			want:    [][]uint32{{101, 51775, 193284, 333915, 102}, {101, 221123, 22452, 102, 0}},
+		},
+		{
+			name:    "only first",
+			options: options.BatchEncodeOptions{EncodeSpecialTokens: true, MaxLength: 4, Truncation: options.TruncationOnlyFirst},
+			want:    [][]uint32{{101, 51775, 193284, 102}, {101, 221123, 22452, 102}},
+		},
+		{
+			name:    "only second",
+			options: options.BatchEncodeOptions{EncodeSpecialTokens: true, MaxLength: 4, Truncation: options.TruncationOnlySecond},
+			err:     "item 0: truncating only the second sequence requires pairs",
+		},
+		{
+			name:    "special tokens longer than the max length",
+			options: options.BatchEncodeOptions{EncodeSpecialTokens: true, MaxLength: 1},
+			err:     "item 0: max length 1 is shorter than the 2 special tokens",
+		},
+		{
+			name:    "without special tokens",
+			options: options.BatchEncodeOptions{MaxLength: 3, PaddingSide: options.PaddingLeft},
+			want:    [][]uint32{{51775, 193284, 333915}, {0, 221123, 22452}},
+		},
+		{
+			name:    "invalid options",
+			options: options.BatchEncodeOptions{Padding: options.PaddingMaxLength},
+			err:     "padding to the max length requires a max length",
+		},
+	}
+	for _, tt := range tests {
+		for name, tk := range map[string]Tokenizer{"batch encoder": tk, "encode only": encodeOnly{tk}} {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				got, err := EncodeBatch(tk, texts, &tt.options)
+				if tt.err != "" {
+					if err == nil || err.Error() != tt.err {
+						t.Errorf("got error %v, want %q", err, tt.err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(got.IDs, tt.want) {
+					t.Errorf("got %v, want %v", got.IDs, tt.want)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// bpe splits words into characters and merges the adjacent tokens by rank of their merge,
+// as GPT-2 and RoBERTa do.
+type bpe struct {
+	vocab        map[string]uint32
+	ranks        map[[2]string]int
+	unkToken     string
+	prefix       string
+	suffix       string
+	fuseUnk      bool
+	byteFallback bool
+	ignoreMerges bool
+}
+
+// parseMerges reads the merges of a BPE model, either "a b" strings or ["a", "b"] pairs, ranked by position.
+func parseMerges(merges []json.RawMessage) (map[[2]string]int, error) {
+	ranks := make(map[[2]string]int, len(merges))
+	for rank, raw := range merges {
+		var pair [2]string
+		var merge string
+		if err := json.Unmarshal(raw, &merge); err == nil {
+			first, second, ok := strings.Cut(merge, " ")
+			if !ok {
+				return nil, fmt.Errorf("invalid merge '%s'", merge)
+			}
+			pair = [2]string{first, second}
+		} else if err := json.Unmarshal(raw, &pair); err != nil {
+			return nil, fmt.Errorf("invalid merge %s", raw)
+		}
+		if _, ok := ranks[pair]; !ok {
+			ranks[pair] = rank
+		}
+	}
+	return ranks, nil
+}
+
+func (b *bpe) vocabulary() map[string]uint32 {
+	return b.vocab
+}
+
+// symbol is a token of a word being merged, made of the runes [start, end) of the word.
+type symbol struct {
+	value      string
+	start, end int
+}
+
+func (b *bpe) tokenize(word span) []token {
+	if b.ignoreMerges {
+		if id, ok := b.vocab[string(word.runes)]; ok {
+			return []token{{id: id, value: string(word.runes), offset: offsetOf(word, 0, len(word.runes))}}
+		}
+	}
+
+	symbols := make([]symbol, len(word.runes))
+	for i, r := range word.runes {
+		value := string(r)
+		if i > 0 {
+			value = b.prefix + value
+		}
+		if i == len(word.runes)-1 {
+			value += b.suffix
+		}
+		symbols[i] = symbol{value: value, start: i, end: i + 1}
+	}
+
+	for {
+		best := -1
+		bestRank := 0
+		for i := 0; i+1 < len(symbols); i++ {
+			rank, ok := b.ranks[[2]string{symbols[i].value, symbols[i+1].value}]
+			if ok && (best < 0 || rank < bestRank) {
+				best, bestRank = i, rank
+			}
+		}
+		if best < 0 {
+			break
+		}
+		merged := symbols[best].value + strings.TrimPrefix(symbols[best+1].value, b.prefix)
+		if _, ok := b.vocab[merged]; !ok {
+			break
+		}
+		symbols[best] = symbol{value: merged, start: symbols[best].start, end: symbols[best+1].end}
+		symbols = append(symbols[:best+1], symbols[best+2:]...)
+	}
+
+	tokens := make([]token, 0, len(symbols))
+	for _, s := range symbols {
+		offset := offsetOf(word, s.start, s.end)
+		if id, ok := b.vocab[s.value]; ok {
+			tokens = append(tokens, token{id: id, value: s.value, offset: offset})
+			continue
+		}
+		if b.byteFallback {
+			fallback := make([]token, 0, len(s.value))
+			for _, c := range []byte(s.value) {
+				value := fmt.Sprintf("<0x%02X>", c)
+				id, ok := b.vocab[value]
+				if !ok {
+					fallback = nil
+					break
+				}
+				fallback = append(fallback, token{id: id, value: value, offset: offset})
+			}
+			if fallback != nil {
+				tokens = append(tokens, fallback...)
+				continue
+			}
+		}
+		id, ok := b.vocab[b.unkToken]
+		if !ok {
+			// Without unknown token, the Rust library drops the characters out of the vocabulary.
+			continue
+		}
+		if last := len(tokens) - 1; b.fuseUnk && last >= 0 && tokens[last].value == b.unkToken {
+			tokens[last].offset[1] = offset[1]
+			continue
+		}
+		tokens = append(tokens, token{id: id, value: b.unkToken, offset: offset})
+	}
+	return tokens
+}
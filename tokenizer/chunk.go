@@ -0,0 +1,241 @@
+package tokenizer
+
+import (
+	"fmt"
+	"github.com/Trendyol/go-triton-client/tokenizer/models"
+	"github.com/Trendyol/go-triton-client/tokenizer/options"
+	"unicode/utf8"
+)
+
+// EncodeChunks encodes a document longer than the max length of a model into overlapping windows of
+// chunkOptions.MaxLength tokens, each with the special tokens of the tokenizer, ready to be inferred as a batch.
+// The truncation of the tokenizer.json file of the tokenizers of this package doesn't apply to the document,
+// other implementations of Tokenizer must encode it whole.
+func EncodeChunks(tk Tokenizer, text string, chunkOptions *options.ChunkOptions) (*models.ChunkResponse, error) {
+	if chunkOptions == nil || chunkOptions.MaxLength <= 0 {
+		return nil, fmt.Errorf("chunking requires a max length")
+	}
+	if chunkOptions.Stride < 0 {
+		return nil, fmt.Errorf("stride can't be negative")
+	}
+
+	encodeOptions := &options.EncodeOptions{
+		EncodeSpecialTokens:     chunkOptions.EncodeSpecialTokens,
+		ReturnTypeIDs:           true,
+		ReturnSpecialTokensMask: true,
+		ReturnAttentionMask:     true,
+		ReturnOffsets:           true,
+	}
+	var encoding *models.EncodeResponse
+	if t, ok := tk.(*tokenizer); ok {
+		var err error
+		if encoding, err = t.encodeDocument(text, encodeOptions); err != nil {
+			return nil, err
+		}
+	} else {
+		encoding = tk.Encode(text, encodeOptions)
+	}
+	// The padding of a tokenizer.json file is removed, the windows are padded together.
+	var unpadded []int
+	for i := range encoding.IDs {
+		if i >= len(encoding.AttentionMask) || encoding.AttentionMask[i] == 1 {
+			unpadded = append(unpadded, i)
+		}
+	}
+	keep(encoding, unpadded)
+
+	// The special tokens added around the text are repeated around every window.
+	prefix := 0
+	for prefix < len(encoding.IDs) && isSpecial(encoding, prefix) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(encoding.IDs)-prefix && isSpecial(encoding, len(encoding.IDs)-1-suffix) {
+		suffix++
+	}
+	content := len(encoding.IDs) - prefix - suffix
+
+	length := chunkOptions.MaxLength - prefix - suffix
+	if length <= 0 {
+		return nil, fmt.Errorf("max length %d is shorter than the %d special tokens", chunkOptions.MaxLength, prefix+suffix)
+	}
+	if chunkOptions.Stride >= length {
+		return nil, fmt.Errorf("stride %d must be shorter than the %d text tokens of a window", chunkOptions.Stride, length)
+	}
+
+	chars := newCharPositions(text)
+	response := &models.ChunkResponse{DocumentOffsets: pick(encoding.Offsets, indexRange(prefix, prefix+content))}
+	response.DocumentCharOffsets = chars.offsets(response.DocumentOffsets)
+	var windows []*models.EncodeResponse
+	for start := 0; ; start += length - chunkOptions.Stride {
+		end := min(start+length, content)
+		indices := append(indexRange(0, prefix), indexRange(prefix+start, prefix+end)...)
+		indices = append(indices, indexRange(prefix+content, len(encoding.IDs))...)
+
+		window := &models.EncodeResponse{}
+		*window = *encoding
+		keep(window, indices)
+		windows = append(windows, window)
+		response.Offsets = append(response.Offsets, window.Offsets)
+
+		chunkWindow := models.ChunkWindow{TokenStart: start, TokenEnd: end, Position: prefix}
+		if end > start {
+			chunkWindow.Offset = models.Offset{response.DocumentOffsets[start][0], response.DocumentOffsets[end-1][1]}
+			chunkWindow.CharOffset = chars.offset(chunkWindow.Offset)
+		}
+		response.Windows = append(response.Windows, chunkWindow)
+		if end == content {
+			break
+		}
+	}
+
+	padded := pad(windows, options.BatchEncodeOptions{
+		Padding:     options.PaddingLongest,
+		PadID:       chunkOptions.PadID,
+		PadTypeID:   chunkOptions.PadTypeID,
+		PaddingSide: options.PaddingRight,
+	})
+	response.IDs = padded.IDs
+	response.TypeIDs = padded.TypeIDs
+	response.SpecialTokensMask = padded.SpecialTokensMask
+	response.AttentionMask = padded.AttentionMask
+	for i, offsets := range response.Offsets {
+		for len(offsets) < len(response.IDs[i]) {
+			offsets = append(offsets, models.Offset{0, 0})
+		}
+		for j := range offsets {
+			if response.SpecialTokensMask[i][j] == 1 {
+				offsets[j] = models.Offset{0, 0}
+			}
+		}
+		response.Offsets[i] = offsets
+		response.CharOffsets = append(response.CharOffsets, chars.offsets(offsets))
+	}
+	return response, nil
+}
+
+// charPositions converts the byte offsets of a text into character offsets.
+type charPositions struct {
+	text string
+	// runes holds the number of characters starting before every byte position, len(text) included.
+	runes []uint
+}
+
+func newCharPositions(text string) charPositions {
+	runes := make([]uint, len(text)+1)
+	for i := 0; i < len(text); i++ {
+		runes[i+1] = runes[i]
+		if utf8.RuneStart(text[i]) {
+			runes[i+1]++
+		}
+	}
+	return charPositions{text: text, runes: runes}
+}
+
+// offset converts a byte offset. An offset inside a character, such as the offset of a byte-level token
+// holding part of its bytes, widens to the whole character.
+func (c charPositions) offset(offset models.Offset) models.Offset {
+	start, end := min(offset[0], uint(len(c.text))), min(offset[1], uint(len(c.text)))
+	charStart := c.runes[start]
+	if start < uint(len(c.text)) && !utf8.RuneStart(c.text[start]) {
+		charStart--
+	}
+	return models.Offset{charStart, c.runes[end]}
+}
+
+func (c charPositions) offsets(offsets []models.Offset) []models.Offset {
+	converted := make([]models.Offset, len(offsets))
+	for i, offset := range offsets {
+		converted[i] = c.offset(offset)
+	}
+	return converted
+}
+
+func indexRange(start, end int) []int {
+	indices := make([]int, 0, max(end-start, 0))
+	for i := start; i < end; i++ {
+		indices = append(indices, i)
+	}
+	return indices
+}
+
+// AggregateChunkEmbeddings pools the embeddings of the windows of a document, one per window in the order
+// of chunks.Windows, into the embedding of the document.
+func AggregateChunkEmbeddings[T float32 | float64](embeddings [][]T, chunks *models.ChunkResponse, aggregation options.ChunkAggregation) ([]T, error) {
+	if len(embeddings) == 0 || len(embeddings) != len(chunks.Windows) {
+		return nil, fmt.Errorf("got %d embeddings for %d windows", len(embeddings), len(chunks.Windows))
+	}
+	dimension := len(embeddings[0])
+	for _, embedding := range embeddings {
+		if len(embedding) != dimension {
+			return nil, fmt.Errorf("embeddings have different dimensions %d and %d", dimension, len(embedding))
+		}
+	}
+
+	pooled := make([]T, dimension)
+	switch aggregation {
+	case options.ChunkAggregationMax:
+		copy(pooled, embeddings[0])
+		for _, embedding := range embeddings[1:] {
+			for i, value := range embedding {
+				pooled[i] = max(pooled[i], value)
+			}
+		}
+	case options.ChunkAggregationMean, options.ChunkAggregationWeightedMean, "":
+		var total T
+		for w, embedding := range embeddings {
+			weight := T(1)
+			if aggregation == options.ChunkAggregationWeightedMean {
+				weight = T(chunks.Windows[w].TokenEnd - chunks.Windows[w].TokenStart)
+			}
+			for i, value := range embedding {
+				pooled[i] += weight * value
+			}
+			total += weight
+		}
+		if total > 0 {
+			for i := range pooled {
+				pooled[i] /= total
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown chunk aggregation '%s'", aggregation)
+	}
+	return pooled, nil
+}
+
+// MergeChunkPredictions merges per-token predictions of the windows, such as the logits of a token classifier,
+// indexed [window][position in the row][label], into one prediction per text token of the document, aligned with
+// chunks.DocumentOffsets. The tokens shared by several windows get the average of their predictions.
+func MergeChunkPredictions[T float32 | float64](predictions [][][]T, chunks *models.ChunkResponse) ([][]T, error) {
+	if len(predictions) != len(chunks.Windows) {
+		return nil, fmt.Errorf("got predictions for %d windows, want %d", len(predictions), len(chunks.Windows))
+	}
+
+	merged := make([][]T, len(chunks.DocumentOffsets))
+	counts := make([]int, len(chunks.DocumentOffsets))
+	for w, window := range chunks.Windows {
+		if len(predictions[w]) < window.Position+window.TokenEnd-window.TokenStart {
+			return nil, fmt.Errorf("window %d has %d predictions for %d tokens", w, len(predictions[w]), window.Position+window.TokenEnd-window.TokenStart)
+		}
+		for token := window.TokenStart; token < window.TokenEnd; token++ {
+			prediction := predictions[w][window.Position+token-window.TokenStart]
+			if merged[token] == nil {
+				merged[token] = make([]T, len(prediction))
+			}
+			if len(prediction) != len(merged[token]) {
+				return nil, fmt.Errorf("predictions have different sizes %d and %d", len(merged[token]), len(prediction))
+			}
+			for i, value := range prediction {
+				merged[token][i] += value
+			}
+			counts[token]++
+		}
+	}
+	for token, prediction := range merged {
+		for i := range prediction {
+			prediction[i] /= T(counts[token])
+		}
+	}
+	return merged, nil
+}
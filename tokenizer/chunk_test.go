@@ -0,0 +1,160 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"github.com/Trendyol/go-triton-client/tokenizer/models"
+	"github.com/Trendyol/go-triton-client/tokenizer/options"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeChunks(t *testing.T) {
+	tk := loadFixture(t, NewPureGoTokenizerFromBytes, "wordpiece.json")
+
+	text := "brown fox jumps over the lazy dog"
+	chunks, err := EncodeChunks(tk, text, &options.ChunkOptions{EncodeSpecialTokens: true, MaxLength: 5, Stride: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantIDs := [][]uint32{
+		{101, 51775, 193284, 333915, 102},
+		{101, 333915, 15444, 14985, 102},
+		{101, 14985, 221123, 22452, 102},
+	}
+	if !reflect.DeepEqual(chunks.IDs, wantIDs) {
+		t.Errorf("got IDs %v, want %v", chunks.IDs, wantIDs)
+	}
+	wantWindows := []models.ChunkWindow{
+		{TokenStart: 0, TokenEnd: 3, Position: 1, Offset: models.Offset{0, 15}, CharOffset: models.Offset{0, 15}},
+		{TokenStart: 2, TokenEnd: 5, Position: 1, Offset: models.Offset{10, 24}, CharOffset: models.Offset{10, 24}},
+		{TokenStart: 4, TokenEnd: 7, Position: 1, Offset: models.Offset{21, 33}, CharOffset: models.Offset{21, 33}},
+	}
+	if !reflect.DeepEqual(chunks.Windows, wantWindows) {
+		t.Errorf("got windows %v, want %v", chunks.Windows, wantWindows)
+	}
+	if got := text[chunks.Windows[1].Offset[0]:chunks.Windows[1].Offset[1]]; got != "jumps over the" {
+		t.Errorf("got window text %q", got)
+	}
+
+	// The predictions of a window are the index of the window, the shared tokens get the average.
+	var predictions [][][]float32
+	for w := range chunks.Windows {
+		rows := make([][]float32, len(chunks.IDs[w]))
+		for i := range rows {
+			rows[i] = []float32{float32(w)}
+		}
+		predictions = append(predictions, rows)
+	}
+	merged, err := MergeChunkPredictions(predictions, chunks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantMerged := [][]float32{{0}, {0}, {0.5}, {1}, {1.5}, {2}, {2}}
+	if !reflect.DeepEqual(merged, wantMerged) {
+		t.Errorf("got merged predictions %v, want %v", merged, wantMerged)
+	}
+
+	pooled, err := AggregateChunkEmbeddings([][]float64{{1, 4}, {3, 2}, {5, 0}}, chunks, options.ChunkAggregationMax)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{5, 4}; !reflect.DeepEqual(pooled, want) {
+		t.Errorf("got pooled embedding %v, want %v", pooled, want)
+	}
+}
+
+func TestEncodeChunksCharOffsets(t *testing.T) {
+	constructors := map[string]func([]byte) (Tokenizer, error){
+		"default": NewTokenizerFromBytes,
+		"pure Go": NewPureGoTokenizerFromBytes,
+	}
+	for name, newTokenizer := range constructors {
+		t.Run(name, func(t *testing.T) {
+			// WordPiece tokens hold whole characters.
+			tk := loadFixture(t, newTokenizer, "bert-base-uncased.json")
+			chunks, err := EncodeChunks(tk, "naïve fox über", &options.ChunkOptions{EncodeSpecialTokens: true, MaxLength: 4})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := []models.Offset{{0, 6}, {7, 10}, {11, 16}}; !reflect.DeepEqual(chunks.DocumentOffsets, want) {
+				t.Errorf("got document offsets %v, want %v", chunks.DocumentOffsets, want)
+			}
+			if want := []models.Offset{{0, 5}, {6, 9}, {10, 14}}; !reflect.DeepEqual(chunks.DocumentCharOffsets, want) {
+				t.Errorf("got document character offsets %v, want %v", chunks.DocumentCharOffsets, want)
+			}
+			wantWindows := []models.ChunkWindow{
+				{TokenStart: 0, TokenEnd: 2, Position: 1, Offset: models.Offset{0, 10}, CharOffset: models.Offset{0, 9}},
+				{TokenStart: 2, TokenEnd: 3, Position: 1, Offset: models.Offset{11, 16}, CharOffset: models.Offset{10, 14}},
+			}
+			if !reflect.DeepEqual(chunks.Windows, wantWindows) {
+				t.Errorf("got windows %v, want %v", chunks.Windows, wantWindows)
+			}
+			wantCharOffsets := [][]models.Offset{{{0, 0}, {0, 5}, {6, 9}, {0, 0}}, {{0, 0}, {10, 14}, {0, 0}, {0, 0}}}
+			if !reflect.DeepEqual(chunks.CharOffsets, wantCharOffsets) {
+				t.Errorf("got character offsets %v, want %v", chunks.CharOffsets, wantCharOffsets)
+			}
+
+			// Byte-level tokens split "ï" into two bytes, its token covers the whole character.
+			tk = loadFixture(t, newTokenizer, "roberta.json")
+			chunks, err = EncodeChunks(tk, "naïve", &options.ChunkOptions{EncodeSpecialTokens: true, MaxLength: 8})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := []models.Offset{{0, 2}, {2, 3}, {3, 4}, {4, 5}}; !reflect.DeepEqual(chunks.DocumentCharOffsets, want) {
+				t.Errorf("got document character offsets %v, want %v", chunks.DocumentCharOffsets, want)
+			}
+			if window := chunks.Windows[0]; window.Offset != (models.Offset{0, 6}) || window.CharOffset != (models.Offset{0, 5}) {
+				t.Errorf("got window offsets %v and %v, want [0 6] and [0 5]", window.Offset, window.CharOffset)
+			}
+		})
+	}
+}
+
+func TestEncodeChunksIgnoresTruncation(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "wordpiece.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config map[string]any
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	config["truncation"] = map[string]any{"direction": "Right", "max_length": 5, "strategy": "LongestFirst", "stride": 0}
+	if data, err = json.Marshal(config); err != nil {
+		t.Fatal(err)
+	}
+
+	constructors := map[string]func([]byte) (Tokenizer, error){
+		"default": NewTokenizerFromBytes,
+		"pure Go": NewPureGoTokenizerFromBytes,
+	}
+	for name, newTokenizer := range constructors {
+		t.Run(name, func(t *testing.T) {
+			tk, err := newTokenizer(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer Close(tk)
+
+			text := "brown fox jumps over the lazy dog"
+			if ids := tk.Encode(text, &options.EncodeOptions{EncodeSpecialTokens: true}).IDs; len(ids) != 5 {
+				t.Fatalf("got %d IDs, the truncation of the file should keep 5", len(ids))
+			}
+			chunks, err := EncodeChunks(tk, text, &options.ChunkOptions{EncodeSpecialTokens: true, MaxLength: 5, Stride: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(chunks.Windows) != 3 || len(chunks.DocumentOffsets) != 7 {
+				t.Errorf("got %d windows over %d tokens, want 3 windows over the 7 tokens of the document", len(chunks.Windows), len(chunks.DocumentOffsets))
+			}
+
+			Close(tk)
+			if _, err := EncodeChunks(tk, text, &options.ChunkOptions{MaxLength: 5}); err != ErrClosed {
+				t.Errorf("got %v, want ErrClosed", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// decoder joins decoded tokens back into text.
+type decoder interface {
+	decode(tokens []string) string
+}
+
+// parseDecoder reads the decoder of a tokenizer.json file. Without decoder, tokens are joined with spaces.
+func parseDecoder(data json.RawMessage) (decoder, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return spaceDecoder{}, nil
+	}
+
+	var config struct {
+		Type    string `json:"type"`
+		Prefix  string `json:"prefix"`
+		Cleanup *bool  `json:"cleanup"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	switch config.Type {
+	case "WordPiece":
+		return wordPieceDecoder{prefix: config.Prefix, cleanup: config.Cleanup == nil || *config.Cleanup}, nil
+	case "ByteLevel":
+		return byteLevelDecoder{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported decoder '%s'", config.Type)
+	}
+}
+
+type spaceDecoder struct{}
+
+func (spaceDecoder) decode(tokens []string) string {
+	return strings.Join(tokens, " ")
+}
+
+// wordPieceDecoder joins the subwords starting with prefix to the previous token, and the other tokens with spaces.
+type wordPieceDecoder struct {
+	prefix  string
+	cleanup bool
+}
+
+// cleanup removes the spaces the decoding adds before punctuation and contractions.
+var cleanup = strings.NewReplacer(
+	" .", ".",
+	" ?", "?",
+	" !", "!",
+	" ,", ",",
+	" ' ", "'",
+	" n't", "n't",
+	" 'm", "'m",
+	" do not", " don't",
+	" 's", "'s",
+	" 've", "'ve",
+	" 're", "'re",
+)
+
+func (d wordPieceDecoder) decode(tokens []string) string {
+	var decoded strings.Builder
+	for i, token := range tokens {
+		if i > 0 {
+			if strings.HasPrefix(token, d.prefix) {
+				token = strings.TrimPrefix(token, d.prefix)
+			} else {
+				token = " " + token
+			}
+		}
+		if d.cleanup {
+			token = cleanup.Replace(token)
+		}
+		decoded.WriteString(token)
+	}
+	return decoded.String()
+}
+
+// byteLevelDecoder maps the characters of the byte-level vocabulary back to bytes. Tokens with other
+// characters, such as some added tokens, are kept as they are.
+type byteLevelDecoder struct{}
+
+func (byteLevelDecoder) decode(tokens []string) string {
+	var decoded []byte
+	for _, token := range tokens {
+		decoded = append(decoded, fromByteLevel(token)...)
+	}
+	return strings.ToValidUTF8(string(decoded), "\uFFFD")
+}
+
+func fromByteLevel(token string) []byte {
+	decoded := make([]byte, 0, len(token))
+	for _, r := range token {
+		b, ok := byteLevelBytes[r]
+		if !ok {
+			return []byte(token)
+		}
+		decoded = append(decoded, b)
+	}
+	return decoded
+}
@@ -2,4 +2,7 @@ module github.com/Trendyol/go-triton-client/tokenizer
 
 go 1.23.0
 
-require github.com/daulet/tokenizers v0.9.0
+require (
+	github.com/daulet/tokenizers v0.9.0
+	golang.org/x/text v0.21.0
+)
@@ -0,0 +1,42 @@
+//go:build cgo
+
+package tokenizer
+
+import (
+	"flag"
+	"github.com/Trendyol/go-triton-client/tokenizer/options"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/golden.json with the encodings of the Rust library")
+
+// TestRustTokenizerMatchesGolden checks the golden encodings against the Rust library, which produces them with -update.
+func TestRustTokenizerMatchesGolden(t *testing.T) {
+	cases := readGoldenCases(t)
+	tokenizers := map[string]Tokenizer{}
+	for i, c := range cases {
+		tk, ok := tokenizers[c.Tokenizer]
+		if !ok {
+			tk = loadFixture(t, NewTokenizerFromBytes, c.Tokenizer)
+			tokenizers[c.Tokenizer] = tk
+		}
+
+		encoded := tk.Encode(c.Text, &options.EncodeOptions{EncodeSpecialTokens: c.AddSpecialTokens})
+		if *update {
+			cases[i].IDs = encoded.IDs
+			if c.Decoded != nil {
+				decoded := tk.Decode(encoded.IDs, nil).Decoded
+				cases[i].Decoded = &decoded
+			}
+		} else if !equalIDs(encoded.IDs, c.IDs) {
+			t.Errorf("%s: encoding %q: got %v, want %v", c.Tokenizer, c.Text, encoded.IDs, c.IDs)
+		}
+	}
+	if *update {
+		writeGoldenCases(t, cases)
+	}
+}
+
+func TestRustTokenizerEncodePairMatchesGolden(t *testing.T) {
+	testEncodePairMatchesGolden(t, NewTokenizerFromBytes)
+}
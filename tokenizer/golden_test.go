@@ -0,0 +1,186 @@
+package tokenizer
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Trendyol/go-triton-client/tokenizer/options"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// goldenCase is an encoding of the Rust library, which the pure Go tokenizer must reproduce.
+type goldenCase struct {
+	Tokenizer        string   `json:"tokenizer"`
+	Text             string   `json:"text"`
+	AddSpecialTokens bool     `json:"add_special_tokens"`
+	IDs              []uint32 `json:"ids"`
+	// Decoded is the decoding of the IDs without skipping the special tokens, when checked.
+	Decoded *string `json:"decoded,omitempty"`
+}
+
+const goldenPath = "testdata/golden.json"
+
+func readGoldenCases(t *testing.T) []goldenCase {
+	t.Helper()
+	data, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cases []goldenCase
+	if err := json.Unmarshal(data, &cases); err != nil {
+		t.Fatal(err)
+	}
+	return cases
+}
+
+// writeGoldenCases writes one case per line, so that changes of the encodings are easy to review.
+func writeGoldenCases(t *testing.T, cases []goldenCase) {
+	t.Helper()
+	var buffer bytes.Buffer
+	buffer.WriteString("[\n")
+	for i, c := range cases {
+		var line bytes.Buffer
+		encoder := json.NewEncoder(&line)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(c); err != nil {
+			t.Fatal(err)
+		}
+		buffer.WriteString("  ")
+		buffer.Write(bytes.TrimSpace(line.Bytes()))
+		if i < len(cases)-1 {
+			buffer.WriteString(",")
+		}
+		buffer.WriteString("\n")
+	}
+	buffer.WriteString("]\n")
+	if err := os.WriteFile(goldenPath, buffer.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func loadFixture(t *testing.T, newTokenizer func(data []byte) (Tokenizer, error), name string) Tokenizer {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk, err := newTokenizer(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { Close(tk) })
+	return tk
+}
+
+func TestPureGoTokenizerMatchesGolden(t *testing.T) {
+	tokenizers := map[string]Tokenizer{}
+	for _, c := range readGoldenCases(t) {
+		tk, ok := tokenizers[c.Tokenizer]
+		if !ok {
+			tk = loadFixture(t, NewPureGoTokenizerFromBytes, c.Tokenizer)
+			tokenizers[c.Tokenizer] = tk
+		}
+
+		encoded := tk.Encode(c.Text, &options.EncodeOptions{EncodeSpecialTokens: c.AddSpecialTokens})
+		if !equalIDs(encoded.IDs, c.IDs) {
+			t.Errorf("%s: encoding %q: got %v, want %v", c.Tokenizer, c.Text, encoded.IDs, c.IDs)
+		}
+		if c.Decoded != nil {
+			if decoded := tk.Decode(c.IDs, nil).Decoded; decoded != *c.Decoded {
+				t.Errorf("%s: decoding %v: got %q, want %q", c.Tokenizer, c.IDs, decoded, *c.Decoded)
+			}
+		}
+	}
+}
+
+func TestPureGoTokenizerEncodePair(t *testing.T) {
+	tk := loadFixture(t, NewPureGoTokenizerFromBytes, "wordpiece.json")
+
+	pair, err := EncodePair(tk, "brown fox", "lazy dog", &options.EncodeOptions{EncodeSpecialTokens: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint32{101, 51775, 193284, 102, 221123, 22452, 102}; !reflect.DeepEqual(pair.IDs, want) {
+		t.Errorf("got IDs %v, want %v", pair.IDs, want)
+	}
+	if want := []uint32{0, 0, 0, 0, 1, 1, 1}; !reflect.DeepEqual(pair.TypeIDs, want) {
+		t.Errorf("got type IDs %v, want %v", pair.TypeIDs, want)
+	}
+	if want := []int{-1, 0, 0, -1, 1, 1, -1}; !reflect.DeepEqual(pair.SequenceIDs, want) {
+		t.Errorf("got sequence IDs %v, want %v", pair.SequenceIDs, want)
+	}
+
+	batch, err := EncodePairBatch(tk, []string{"brown fox jumps", "dog"}, []string{"over the lazy dog", "fox"}, &options.BatchEncodeOptions{
+		EncodeSpecialTokens: true,
+		MaxLength:           7,
+		Truncation:          options.TruncationOnlySecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantIDs := [][]uint32{
+		{101, 51775, 193284, 333915, 102, 15444, 102},
+		{101, 22452, 102, 193284, 102, 0, 0},
+	}
+	if !reflect.DeepEqual(batch.IDs, wantIDs) {
+		t.Errorf("got %v, want %v", batch.IDs, wantIDs)
+	}
+}
+
+// pairGoldenCases are pair encodings of the post-processors of the Rust library: "[CLS] A [SEP] B [SEP]" with
+// the type IDs 0 and 1 for BERT, "<s> A </s></s> B </s>" with the type ID 0 for RoBERTa.
+var pairGoldenCases = []struct {
+	tokenizer string
+	text      string
+	pair      string
+	ids       []uint32
+	typeIDs   []uint32
+}{
+	{
+		tokenizer: "bert-base-uncased.json",
+		text:      "the brown fox",
+		pair:      "and the dog",
+		ids:       []uint32{101, 1996, 2829, 4419, 102, 1998, 1996, 3899, 102},
+		typeIDs:   []uint32{0, 0, 0, 0, 0, 1, 1, 1, 1},
+	},
+	{
+		tokenizer: "roberta.json",
+		text:      "the brown fox",
+		pair:      "and the dog",
+		ids:       []uint32{300, 83, 257, 220, 65, 81, 78, 86, 77, 220, 69, 78, 87, 301, 301, 64, 260, 258, 220, 276, 70, 301},
+		typeIDs:   []uint32{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+	},
+}
+
+func testEncodePairMatchesGolden(t *testing.T, newTokenizer func(data []byte) (Tokenizer, error)) {
+	for _, c := range pairGoldenCases {
+		tk := loadFixture(t, newTokenizer, c.tokenizer)
+		pair, err := EncodePair(tk, c.text, c.pair, &options.EncodeOptions{EncodeSpecialTokens: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(pair.IDs, c.ids) {
+			t.Errorf("%s: got IDs %v, want %v", c.tokenizer, pair.IDs, c.ids)
+		}
+		if !reflect.DeepEqual(pair.TypeIDs, c.typeIDs) {
+			t.Errorf("%s: got type IDs %v, want %v", c.tokenizer, pair.TypeIDs, c.typeIDs)
+		}
+	}
+}
+
+func TestPureGoTokenizerEncodePairMatchesGolden(t *testing.T) {
+	testEncodePairMatchesGolden(t, NewPureGoTokenizerFromBytes)
+}
+
+func TestPureGoTokenizerUnsupported(t *testing.T) {
+	_, err := NewPureGoTokenizerFromBytes([]byte(`{"model":{"type":"Unigram","vocab":[["a",0]]}}`))
+	if err == nil {
+		t.Error("loading an unsupported model should fail")
+	}
+}
+
+func equalIDs(got, want []uint32) bool {
+	return len(got) == 0 && len(want) == 0 || reflect.DeepEqual(got, want)
+}
@@ -0,0 +1,143 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/tokenizer/models"
+)
+
+// token is a token of the vocabulary found in a text.
+type token struct {
+	id     uint32
+	value  string
+	offset models.Offset
+}
+
+// model splits the words of a text into the tokens of its vocabulary.
+type model interface {
+	tokenize(word span) []token
+	vocabulary() map[string]uint32
+}
+
+// parseModel reads the model of a tokenizer.json file.
+func parseModel(data json.RawMessage) (model, error) {
+	var config struct {
+		Type                    string            `json:"type"`
+		Vocab                   map[string]uint32 `json:"vocab"`
+		UnkToken                *string           `json:"unk_token"`
+		ContinuingSubwordPrefix *string           `json:"continuing_subword_prefix"`
+		// WordPiece
+		MaxInputCharsPerWord int `json:"max_input_chars_per_word"`
+		// BPE
+		Merges          []json.RawMessage `json:"merges"`
+		EndOfWordSuffix *string           `json:"end_of_word_suffix"`
+		FuseUnk         bool              `json:"fuse_unk"`
+		ByteFallback    bool              `json:"byte_fallback"`
+		IgnoreMerges    bool              `json:"ignore_merges"`
+		Dropout         *float64          `json:"dropout"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	if len(config.Vocab) == 0 {
+		return nil, errors.New("the model has no vocabulary")
+	}
+
+	// Old files don't have the type of the model, which the Rust library infers from its fields.
+	if config.Type == "" {
+		switch {
+		case config.Merges != nil:
+			config.Type = "BPE"
+		case config.MaxInputCharsPerWord > 0:
+			config.Type = "WordPiece"
+		}
+	}
+
+	switch config.Type {
+	case "WordPiece":
+		wp := &wordPiece{vocab: config.Vocab, unkToken: "[UNK]", prefix: "##", maxInputChars: 100}
+		if config.UnkToken != nil {
+			wp.unkToken = *config.UnkToken
+		}
+		if config.ContinuingSubwordPrefix != nil {
+			wp.prefix = *config.ContinuingSubwordPrefix
+		}
+		if config.MaxInputCharsPerWord > 0 {
+			wp.maxInputChars = config.MaxInputCharsPerWord
+		}
+		return wp, nil
+	case "BPE":
+		if config.Dropout != nil && *config.Dropout > 0 {
+			return nil, errors.New("BPE dropout is not supported")
+		}
+		ranks, err := parseMerges(config.Merges)
+		if err != nil {
+			return nil, err
+		}
+		bp := &bpe{vocab: config.Vocab, ranks: ranks, fuseUnk: config.FuseUnk, byteFallback: config.ByteFallback, ignoreMerges: config.IgnoreMerges}
+		if config.UnkToken != nil {
+			bp.unkToken = *config.UnkToken
+		}
+		if config.ContinuingSubwordPrefix != nil {
+			bp.prefix = *config.ContinuingSubwordPrefix
+		}
+		if config.EndOfWordSuffix != nil {
+			bp.suffix = *config.EndOfWordSuffix
+		}
+		return bp, nil
+	default:
+		return nil, fmt.Errorf("unsupported model '%s'", config.Type)
+	}
+}
+
+// wordPiece splits words greedily into the longest tokens of the vocabulary, as BERT does.
+type wordPiece struct {
+	vocab         map[string]uint32
+	unkToken      string
+	prefix        string
+	maxInputChars int
+}
+
+func (wp *wordPiece) vocabulary() map[string]uint32 {
+	return wp.vocab
+}
+
+func (wp *wordPiece) tokenize(word span) []token {
+	// Without unknown token in the vocabulary, the Rust library fails and the word is dropped.
+	var unknown []token
+	if id, ok := wp.vocab[wp.unkToken]; ok {
+		unknown = []token{{id: id, value: wp.unkToken, offset: offsetOf(word, 0, len(word.runes))}}
+	}
+	if len(word.runes) > wp.maxInputChars {
+		return unknown
+	}
+
+	var tokens []token
+	for start := 0; start < len(word.runes); {
+		end := len(word.runes)
+		found := false
+		for ; end > start; end-- {
+			value := string(word.runes[start:end])
+			if start > 0 {
+				value = wp.prefix + value
+			}
+			if id, ok := wp.vocab[value]; ok {
+				tokens = append(tokens, token{id: id, value: value, offset: offsetOf(word, start, end)})
+				found = true
+				break
+			}
+		}
+		// A word with a part out of the vocabulary is unknown as a whole.
+		if !found {
+			return unknown
+		}
+		start = end
+	}
+	return tokens
+}
+
+// offsetOf returns the offset in the original text of the runes [start, end) of s.
+func offsetOf(s span, start, end int) models.Offset {
+	return models.Offset{s.offsets[start][0], s.offsets[end-1][1]}
+}
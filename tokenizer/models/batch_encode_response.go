@@ -0,0 +1,9 @@
+package models
+
+// BatchEncodeResponse holds one row per text. The rows have the same length unless padding is disabled.
+type BatchEncodeResponse struct {
+	IDs               [][]uint32 `json:"ids"`
+	TypeIDs           [][]uint32 `json:"type_ids"`
+	SpecialTokensMask [][]uint32 `json:"special_tokens_mask"`
+	AttentionMask     [][]uint32 `json:"attention_mask"`
+}
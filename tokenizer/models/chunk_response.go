@@ -0,0 +1,33 @@
+package models
+
+// ChunkResponse holds the overlapping windows of a document, one row per window, padded to the same length.
+type ChunkResponse struct {
+	IDs               [][]uint32 `json:"ids"`
+	TypeIDs           [][]uint32 `json:"type_ids"`
+	SpecialTokensMask [][]uint32 `json:"special_tokens_mask"`
+	AttentionMask     [][]uint32 `json:"attention_mask"`
+	// Offsets are the byte offsets of the tokens of every window in the document, {0, 0} for special and padding tokens.
+	Offsets [][]Offset `json:"offsets"`
+	// CharOffsets are Offsets counted in characters (runes) instead of bytes.
+	CharOffsets [][]Offset `json:"char_offsets"`
+	// Windows locate the windows in the document.
+	Windows []ChunkWindow `json:"windows"`
+	// DocumentOffsets are the byte offsets of the text tokens of the whole document, which ChunkWindow.TokenStart
+	// and ChunkWindow.TokenEnd index.
+	DocumentOffsets []Offset `json:"document_offsets"`
+	// DocumentCharOffsets are DocumentOffsets counted in characters (runes) instead of bytes.
+	DocumentCharOffsets []Offset `json:"document_char_offsets"`
+}
+
+// ChunkWindow is the part of the document covered by a window.
+type ChunkWindow struct {
+	// TokenStart and TokenEnd are the range of text tokens of the document in the window.
+	TokenStart int `json:"token_start"`
+	TokenEnd   int `json:"token_end"`
+	// Position is the index of the first text token in the row of the window, after the leading special tokens.
+	Position int `json:"position"`
+	// Offset is the byte range of the window in the document, which slices the document string.
+	Offset Offset `json:"offset"`
+	// CharOffset is the character (rune) range of the window in the document, as the offsets of the Python library.
+	CharOffset Offset `json:"char_offset"`
+}
@@ -7,6 +7,8 @@ type EncodeResponse struct {
 	AttentionMask     []uint32 `json:"attention_mask"`
 	Tokens            []string `json:"tokens"`
 	Offsets           []Offset `json:"offsets"`
+	// SequenceIDs holds the index of the sequence of every token of a pair encoding, -1 for special tokens.
+	SequenceIDs []int `json:"sequence_ids,omitempty"`
 }
 
 type Offset [2]uint
@@ -0,0 +1,9 @@
+//go:build !cgo
+
+package tokenizer
+
+// NewTokenizerFromBytes loads a tokenizer from the content of a Hugging Face tokenizer.json file.
+// Without cgo, the Rust library is not available and the tokenizer is the pure Go implementation.
+func NewTokenizerFromBytes(data []byte) (Tokenizer, error) {
+	return NewPureGoTokenizerFromBytes(data)
+}
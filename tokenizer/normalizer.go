@@ -0,0 +1,244 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+// span is a piece of text with the byte offsets of each of its runes in the original text,
+// which normalizers and pre-tokenizers keep aligned while they change the runes.
+type span struct {
+	runes   []rune
+	offsets [][2]uint
+}
+
+func newSpan(text string) span {
+	s := span{runes: make([]rune, 0, len(text)), offsets: make([][2]uint, 0, len(text))}
+	for i, r := range text {
+		s.runes = append(s.runes, r)
+		s.offsets = append(s.offsets, [2]uint{uint(i), uint(i + len(string(r)))})
+	}
+	return s
+}
+
+func (s span) slice(start, end int) span {
+	return span{runes: s.runes[start:end], offsets: s.offsets[start:end]}
+}
+
+// mapRunes replaces every rune by the runes returned by f, which keep the offsets of the replaced rune.
+func (s span) mapRunes(f func(r rune) []rune) span {
+	mapped := span{runes: make([]rune, 0, len(s.runes)), offsets: make([][2]uint, 0, len(s.runes))}
+	for i, r := range s.runes {
+		for _, replacement := range f(r) {
+			mapped.runes = append(mapped.runes, replacement)
+			mapped.offsets = append(mapped.offsets, s.offsets[i])
+		}
+	}
+	return mapped
+}
+
+// normalizer transforms a text before it is split into words, such as lowercasing it.
+type normalizer interface {
+	normalize(s span) span
+}
+
+// parseNormalizer reads the normalizer of a tokenizer.json file, nil when the file has none.
+func parseNormalizer(data json.RawMessage) (normalizer, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var config struct {
+		Type string `json:"type"`
+		// BertNormalizer
+		CleanText          bool  `json:"clean_text"`
+		HandleChineseChars bool  `json:"handle_chinese_chars"`
+		StripAccents       *bool `json:"strip_accents"`
+		Lowercase          bool  `json:"lowercase"`
+		// Strip
+		StripLeft  bool `json:"strip_left"`
+		StripRight bool `json:"strip_right"`
+		// Sequence
+		Normalizers []json.RawMessage `json:"normalizers"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	switch config.Type {
+	case "BertNormalizer":
+		// Accents are stripped when lowercasing unless strip_accents is set.
+		stripAccents := config.Lowercase
+		if config.StripAccents != nil {
+			stripAccents = *config.StripAccents
+		}
+		return bertNormalizer{
+			cleanText:          config.CleanText,
+			handleChineseChars: config.HandleChineseChars,
+			stripAccents:       stripAccents,
+			lowercase:          config.Lowercase,
+		}, nil
+	case "Lowercase":
+		return lowercaseNormalizer{}, nil
+	case "StripAccents":
+		return stripAccentsNormalizer{}, nil
+	case "Strip":
+		return stripNormalizer{left: config.StripLeft, right: config.StripRight}, nil
+	case "NFC":
+		return unicodeNormalizer{form: norm.NFC}, nil
+	case "NFD":
+		return unicodeNormalizer{form: norm.NFD}, nil
+	case "NFKC":
+		return unicodeNormalizer{form: norm.NFKC}, nil
+	case "NFKD":
+		return unicodeNormalizer{form: norm.NFKD}, nil
+	case "Sequence":
+		var normalizers sequenceNormalizer
+		for _, raw := range config.Normalizers {
+			nested, err := parseNormalizer(raw)
+			if err != nil {
+				return nil, err
+			}
+			if nested != nil {
+				normalizers = append(normalizers, nested)
+			}
+		}
+		return normalizers, nil
+	default:
+		return nil, fmt.Errorf("unsupported normalizer '%s'", config.Type)
+	}
+}
+
+// bertNormalizer is the normalizer of BERT models.
+type bertNormalizer struct {
+	cleanText          bool
+	handleChineseChars bool
+	stripAccents       bool
+	lowercase          bool
+}
+
+func (n bertNormalizer) normalize(s span) span {
+	if n.cleanText {
+		s = s.mapRunes(func(r rune) []rune {
+			switch {
+			case r == 0 || r == unicode.ReplacementChar || isControl(r):
+				return nil
+			case isWhitespace(r):
+				return []rune{' '}
+			default:
+				return []rune{r}
+			}
+		})
+	}
+	if n.handleChineseChars {
+		s = s.mapRunes(func(r rune) []rune {
+			if isChineseChar(r) {
+				return []rune{' ', r, ' '}
+			}
+			return []rune{r}
+		})
+	}
+	if n.stripAccents {
+		s = stripAccentsNormalizer{}.normalize(s)
+	}
+	if n.lowercase {
+		s = lowercaseNormalizer{}.normalize(s)
+	}
+	return s
+}
+
+type lowercaseNormalizer struct{}
+
+func (lowercaseNormalizer) normalize(s span) span {
+	return s.mapRunes(func(r rune) []rune {
+		return []rune(strings.ToLower(string(r)))
+	})
+}
+
+// stripAccentsNormalizer decomposes the text and removes the combining marks.
+type stripAccentsNormalizer struct{}
+
+func (stripAccentsNormalizer) normalize(s span) span {
+	return unicodeNormalizer{form: norm.NFD}.normalize(s).mapRunes(func(r rune) []rune {
+		if unicode.Is(unicode.Mn, r) {
+			return nil
+		}
+		return []rune{r}
+	})
+}
+
+type stripNormalizer struct {
+	left  bool
+	right bool
+}
+
+func (n stripNormalizer) normalize(s span) span {
+	start, end := 0, len(s.runes)
+	for n.left && start < end && unicode.IsSpace(s.runes[start]) {
+		start++
+	}
+	for n.right && end > start && unicode.IsSpace(s.runes[end-1]) {
+		end--
+	}
+	return s.slice(start, end)
+}
+
+// unicodeNormalizer applies a Unicode normalization form. The runes composed or decomposed together
+// share the offsets of the runes they come from.
+type unicodeNormalizer struct {
+	form norm.Form
+}
+
+func (n unicodeNormalizer) normalize(s span) span {
+	normalized := span{runes: make([]rune, 0, len(s.runes)), offsets: make([][2]uint, 0, len(s.runes))}
+	for start := 0; start < len(s.runes); {
+		end := start + 1
+		for end < len(s.runes) && !n.form.PropertiesString(string(s.runes[end])).BoundaryBefore() {
+			end++
+		}
+		offset := [2]uint{s.offsets[start][0], s.offsets[end-1][1]}
+		for _, r := range n.form.String(string(s.runes[start:end])) {
+			normalized.runes = append(normalized.runes, r)
+			normalized.offsets = append(normalized.offsets, offset)
+		}
+		start = end
+	}
+	return normalized
+}
+
+type sequenceNormalizer []normalizer
+
+func (n sequenceNormalizer) normalize(s span) span {
+	for _, nested := range n {
+		s = nested.normalize(s)
+	}
+	return s
+}
+
+// isWhitespace matches the whitespace of the Rust library, which includes tabs and new lines.
+func isWhitespace(r rune) bool {
+	return unicode.Is(unicode.White_Space, r)
+}
+
+func isControl(r rune) bool {
+	if r == '\t' || r == '\n' || r == '\r' {
+		return false
+	}
+	// The other categories, Cc, Cf, Co, Cs and the unassigned code points.
+	return !unicode.In(r, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Z)
+}
+
+// isChineseChar reports whether r is in the CJK Unified Ideographs blocks, as BERT defines Chinese characters.
+func isChineseChar(r rune) bool {
+	return (r >= 0x4E00 && r <= 0x9FFF) ||
+		(r >= 0x3400 && r <= 0x4DBF) ||
+		(r >= 0x20000 && r <= 0x2A6DF) ||
+		(r >= 0x2A700 && r <= 0x2B73F) ||
+		(r >= 0x2B740 && r <= 0x2B81F) ||
+		(r >= 0x2B920 && r <= 0x2CEAF) ||
+		(r >= 0xF900 && r <= 0xFAFF) ||
+		(r >= 0x2F800 && r <= 0x2FA1F)
+}
@@ -0,0 +1,51 @@
+package options
+
+// TruncationStrategy selects the tokens removed from sequences longer than the max length.
+type TruncationStrategy string
+
+const (
+	// TruncationLongestFirst removes tokens from the longest sequence first.
+	TruncationLongestFirst TruncationStrategy = "longest_first"
+	// TruncationOnlyFirst removes tokens from the first sequence only.
+	TruncationOnlyFirst TruncationStrategy = "only_first"
+	// TruncationOnlySecond removes tokens from the second sequence of pairs only.
+	TruncationOnlySecond TruncationStrategy = "only_second"
+)
+
+// PaddingStrategy selects the length the sequences of a batch are padded to.
+type PaddingStrategy string
+
+const (
+	// PaddingLongest pads to the longest sequence of the batch.
+	PaddingLongest PaddingStrategy = "longest"
+	// PaddingMaxLength pads to the max length.
+	PaddingMaxLength PaddingStrategy = "max_length"
+	// PaddingNone leaves the sequences unpadded.
+	PaddingNone PaddingStrategy = "none"
+)
+
+// PaddingSide selects the side the padding is added to.
+type PaddingSide string
+
+const (
+	PaddingRight PaddingSide = "right"
+	PaddingLeft  PaddingSide = "left"
+)
+
+type BatchEncodeOptions struct {
+	EncodeSpecialTokens bool
+	// MaxLength truncates the sequences to at most MaxLength tokens, special tokens included. Zero disables truncation.
+	MaxLength int
+	// Truncation defaults to TruncationLongestFirst.
+	Truncation TruncationStrategy
+	// Padding defaults to PaddingLongest.
+	Padding PaddingStrategy
+	// PadToMultipleOf rounds the padded length up to a multiple of its value.
+	PadToMultipleOf int
+	PadID           uint32
+	PadTypeID       uint32
+	// PaddingSide defaults to PaddingRight.
+	PaddingSide PaddingSide
+	// Concurrency is the number of texts encoded in parallel. Defaults to GOMAXPROCS.
+	Concurrency int
+}
@@ -0,0 +1,28 @@
+package options
+
+// ChunkOptions configures the sliding windows of EncodeChunks.
+type ChunkOptions struct {
+	// EncodeSpecialTokens adds the special tokens of the tokenizer around every window.
+	EncodeSpecialTokens bool
+	// MaxLength is the length of the windows, special tokens included. Required.
+	MaxLength int
+	// Stride is the number of tokens a window shares with the previous one. Must be shorter than the text
+	// tokens of a window.
+	Stride int
+	// PadID and PadTypeID pad the last window to the length of the others.
+	PadID     uint32
+	PadTypeID uint32
+}
+
+// ChunkAggregation selects how AggregateChunkEmbeddings pools the embeddings of the windows of a document.
+type ChunkAggregation string
+
+const (
+	// ChunkAggregationMean averages the window embeddings.
+	ChunkAggregationMean ChunkAggregation = "mean"
+	// ChunkAggregationWeightedMean averages the window embeddings weighted by their number of text tokens,
+	// so that a short last window counts less.
+	ChunkAggregationWeightedMean ChunkAggregation = "weighted_mean"
+	// ChunkAggregationMax takes the maximum of every dimension.
+	ChunkAggregationMax ChunkAggregation = "max"
+)
@@ -0,0 +1,11 @@
+package options
+
+// StreamDecodeOptions configures the incremental decoding of generated token IDs.
+type StreamDecodeOptions struct {
+	SkipSpecialTokens bool
+	// StopSequences end the generation when the decoded text contains one of them. The stop sequence
+	// and the text after it are not emitted.
+	StopSequences []string
+	// StopTokenIDs end the generation when one of them is added, such as the end of sequence token.
+	StopTokenIDs []uint32
+}
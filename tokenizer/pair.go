@@ -0,0 +1,265 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/tokenizer/models"
+	"github.com/Trendyol/go-triton-client/tokenizer/options"
+)
+
+// templatePiece is a special token or a sequence of a post-processor template.
+type templatePiece struct {
+	// sequence is 0 or 1 for the sequences, -1 for special tokens.
+	sequence      int
+	typeID        uint32
+	specialIDs    []uint32
+	specialTokens []string
+}
+
+// postProcessor adds the special tokens of a tokenizer around a sequence or a pair of sequences.
+type postProcessor struct {
+	single []templatePiece
+	pair   []templatePiece
+}
+
+// specialTokens returns the number of special tokens added by a template.
+func specialTokens(template []templatePiece) int {
+	count := 0
+	for _, piece := range template {
+		count += len(piece.specialIDs)
+	}
+	return count
+}
+
+// concatenation is the template of tokenizers without post-processor. The tokens of the
+// second sequence have the type ID 1, as in the Rust library.
+func concatenation() *postProcessor {
+	return &postProcessor{
+		single: []templatePiece{{sequence: 0}},
+		pair:   []templatePiece{{sequence: 0}, {sequence: 1, typeID: 1}},
+	}
+}
+
+// parsePostProcessor reads the templates of the post-processor of a tokenizer.json file.
+func parsePostProcessor(data []byte) (*postProcessor, error) {
+	var config struct {
+		PostProcessor json.RawMessage `json:"post_processor"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	processor, err := parseProcessor(config.PostProcessor)
+	if err != nil {
+		return nil, fmt.Errorf("parsing post-processor: %w", err)
+	}
+	if processor == nil {
+		return concatenation(), nil
+	}
+	return processor, nil
+}
+
+// parseProcessor returns the template of a post-processor, or nil when it adds no special tokens.
+func parseProcessor(data json.RawMessage) (*postProcessor, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var processor struct {
+		Type string `json:"type"`
+		// TemplateProcessing
+		Single        []map[string]templateEntry `json:"single"`
+		Pair          []map[string]templateEntry `json:"pair"`
+		SpecialTokens map[string]struct {
+			IDs    []uint32 `json:"ids"`
+			Tokens []string `json:"tokens"`
+		} `json:"special_tokens"`
+		// BertProcessing and RobertaProcessing
+		Sep [2]any `json:"sep"`
+		Cls [2]any `json:"cls"`
+		// Sequence
+		Processors []json.RawMessage `json:"processors"`
+	}
+	if err := json.Unmarshal(data, &processor); err != nil {
+		return nil, err
+	}
+
+	switch processor.Type {
+	case "TemplateProcessing":
+		template := func(entries []map[string]templateEntry) ([]templatePiece, error) {
+			var pieces []templatePiece
+			for _, entry := range entries {
+				if sequence, ok := entry["Sequence"]; ok {
+					index := 0
+					if sequence.ID == "B" {
+						index = 1
+					}
+					pieces = append(pieces, templatePiece{sequence: index, typeID: sequence.TypeID})
+				} else if special, ok := entry["SpecialToken"]; ok {
+					token, ok := processor.SpecialTokens[special.ID]
+					if !ok {
+						return nil, fmt.Errorf("unknown special token '%s' in the template", special.ID)
+					}
+					pieces = append(pieces, templatePiece{sequence: -1, typeID: special.TypeID, specialIDs: token.IDs, specialTokens: token.Tokens})
+				}
+			}
+			return pieces, nil
+		}
+		single, err := template(processor.Single)
+		if err != nil {
+			return nil, err
+		}
+		pair, err := template(processor.Pair)
+		if err != nil {
+			return nil, err
+		}
+		return &postProcessor{single: single, pair: pair}, nil
+	case "BertProcessing", "RobertaProcessing":
+		cls, err := specialPiece(processor.Cls, 0)
+		if err != nil {
+			return nil, err
+		}
+		sep, err := specialPiece(processor.Sep, 0)
+		if err != nil {
+			return nil, err
+		}
+		single := []templatePiece{cls, {sequence: 0}, sep}
+		if processor.Type == "RobertaProcessing" {
+			return &postProcessor{single: single, pair: []templatePiece{cls, {sequence: 0}, sep, sep, {sequence: 1}, sep}}, nil
+		}
+		secondSep := sep
+		secondSep.typeID = 1
+		return &postProcessor{single: single, pair: []templatePiece{cls, {sequence: 0}, sep, {sequence: 1, typeID: 1}, secondSep}}, nil
+	case "Sequence":
+		// The template is applied by one of the processors, the others such as ByteLevel only adjust offsets.
+		for _, raw := range processor.Processors {
+			nested, err := parseProcessor(raw)
+			if err != nil || nested != nil {
+				return nested, err
+			}
+		}
+		return nil, nil
+	case "ByteLevel":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported post-processor '%s'", processor.Type)
+	}
+}
+
+type templateEntry struct {
+	ID     string `json:"id"`
+	TypeID uint32 `json:"type_id"`
+}
+
+// specialPiece reads a ["token", id] pair of BertProcessing and RobertaProcessing.
+func specialPiece(value [2]any, typeID uint32) (templatePiece, error) {
+	token, ok := value[0].(string)
+	id, isNumber := value[1].(float64)
+	if !ok || !isNumber {
+		return templatePiece{}, fmt.Errorf("invalid special token %v", value)
+	}
+	return templatePiece{sequence: -1, typeID: typeID, specialIDs: []uint32{uint32(id)}, specialTokens: []string{token}}, nil
+}
+
+// encodePair truncates the encodings of two sequences to maxLength tokens, special tokens included,
+// and merges them with the template of the post-processor when addSpecialTokens is set.
+func encodePair(first, second *models.EncodeResponse, processor *postProcessor, addSpecialTokens bool, maxLength int, strategy options.TruncationStrategy) (*models.EncodeResponse, error) {
+	if !addSpecialTokens {
+		processor = concatenation()
+	}
+	if maxLength > 0 {
+		if specialTokens := specialTokens(processor.pair); maxLength < specialTokens {
+			return nil, fmt.Errorf("max length %d is shorter than the %d special tokens", maxLength, specialTokens)
+		}
+		if err := truncatePair(first, second, maxLength-specialTokens(processor.pair), strategy); err != nil {
+			return nil, err
+		}
+	}
+	return applyTemplate(processor.pair, first, second), nil
+}
+
+// applyTemplate merges sequences with the special tokens of template, and fills the sequence IDs.
+func applyTemplate(template []templatePiece, sequences ...*models.EncodeResponse) *models.EncodeResponse {
+	merged := &models.EncodeResponse{}
+	for _, piece := range template {
+		if piece.sequence < 0 {
+			for i, id := range piece.specialIDs {
+				merged.IDs = append(merged.IDs, id)
+				merged.TypeIDs = append(merged.TypeIDs, piece.typeID)
+				merged.SpecialTokensMask = append(merged.SpecialTokensMask, 1)
+				merged.AttentionMask = append(merged.AttentionMask, 1)
+				merged.Tokens = append(merged.Tokens, piece.specialTokens[i])
+				merged.Offsets = append(merged.Offsets, models.Offset{0, 0})
+				merged.SequenceIDs = append(merged.SequenceIDs, -1)
+			}
+			continue
+		}
+		if piece.sequence >= len(sequences) {
+			continue
+		}
+		sequence := sequences[piece.sequence]
+		for i, id := range sequence.IDs {
+			merged.IDs = append(merged.IDs, id)
+			merged.TypeIDs = append(merged.TypeIDs, piece.typeID)
+			merged.SpecialTokensMask = append(merged.SpecialTokensMask, 0)
+			merged.AttentionMask = append(merged.AttentionMask, 1)
+			if i < len(sequence.Tokens) {
+				merged.Tokens = append(merged.Tokens, sequence.Tokens[i])
+			}
+			if i < len(sequence.Offsets) {
+				merged.Offsets = append(merged.Offsets, sequence.Offsets[i])
+			}
+			merged.SequenceIDs = append(merged.SequenceIDs, piece.sequence)
+		}
+	}
+	return merged
+}
+
+// truncatePair truncates two sequences to at most budget tokens together, as the Rust library does.
+func truncatePair(first, second *models.EncodeResponse, budget int, strategy options.TruncationStrategy) error {
+	n1, n2 := len(first.IDs), len(second.IDs)
+	if n1+n2 <= budget {
+		return nil
+	}
+
+	switch strategy {
+	case options.TruncationOnlyFirst:
+		if n2 >= budget {
+			return fmt.Errorf("the second sequence has %d tokens, it can't fit the max length by truncating the first", n2)
+		}
+		n1 = budget - n2
+	case options.TruncationOnlySecond:
+		if n1 >= budget {
+			return fmt.Errorf("the first sequence has %d tokens, it can't fit the max length by truncating the second", n1)
+		}
+		n2 = budget - n1
+	default:
+		swap := n1 > n2
+		if swap {
+			n1, n2 = n2, n1
+		}
+		if n1 > budget {
+			n2 = n1
+		} else {
+			n2 = max(n1, budget-n1)
+		}
+		if n1+n2 > budget {
+			n1 = budget / 2
+			n2 = n1 + budget%2
+		}
+		if swap {
+			n1, n2 = n2, n1
+		}
+	}
+
+	keep(first, firstIndices(min(n1, len(first.IDs))))
+	keep(second, firstIndices(min(n2, len(second.IDs))))
+	return nil
+}
+
+func firstIndices(n int) []int {
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
@@ -0,0 +1,272 @@
+package tokenizer
+
+import (
+	"github.com/Trendyol/go-triton-client/tokenizer/models"
+	"github.com/Trendyol/go-triton-client/tokenizer/options"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const bertTemplate = `{
+	"type": "TemplateProcessing",
+	"single": [{"SpecialToken": {"id": "[CLS]", "type_id": 0}}, {"Sequence": {"id": "A", "type_id": 0}}, {"SpecialToken": {"id": "[SEP]", "type_id": 0}}],
+	"pair": [
+		{"SpecialToken": {"id": "[CLS]", "type_id": 0}}, {"Sequence": {"id": "A", "type_id": 0}}, {"SpecialToken": {"id": "[SEP]", "type_id": 0}},
+		{"Sequence": {"id": "B", "type_id": 1}}, {"SpecialToken": {"id": "[SEP]", "type_id": 1}}
+	],
+	"special_tokens": {"[CLS]": {"ids": [101], "tokens": ["[CLS]"]}, "[SEP]": {"ids": [102], "tokens": ["[SEP]"]}}
+}`
+
+func TestParsePostProcessor(t *testing.T) {
+	tests := []struct {
+		name          string
+		postProcessor string
+		singleIDs     []uint32
+		pairIDs       []uint32
+		pairTypeIDs   []uint32
+		err           string
+	}{
+		{
+			name:          "template",
+			postProcessor: bertTemplate,
+			singleIDs:     []uint32{101, 1, 2, 102},
+			pairIDs:       []uint32{101, 1, 2, 102, 3, 102},
+			pairTypeIDs:   []uint32{0, 0, 0, 0, 1, 1},
+		},
+		{
+			name:          "bert",
+			postProcessor: `{"type": "BertProcessing", "sep": ["[SEP]", 102], "cls": ["[CLS]", 101]}`,
+			singleIDs:     []uint32{101, 1, 2, 102},
+			pairIDs:       []uint32{101, 1, 2, 102, 3, 102},
+			pairTypeIDs:   []uint32{0, 0, 0, 0, 1, 1},
+		},
+		{
+			name:          "roberta",
+			postProcessor: `{"type": "RobertaProcessing", "sep": ["</s>", 2], "cls": ["<s>", 0], "trim_offsets": true}`,
+			singleIDs:     []uint32{0, 1, 2, 2},
+			pairIDs:       []uint32{0, 1, 2, 2, 2, 3, 2},
+			pairTypeIDs:   []uint32{0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:          "sequence",
+			postProcessor: `{"type": "Sequence", "processors": [{"type": "ByteLevel", "trim_offsets": false}, ` + bertTemplate + `]}`,
+			singleIDs:     []uint32{101, 1, 2, 102},
+			pairIDs:       []uint32{101, 1, 2, 102, 3, 102},
+			pairTypeIDs:   []uint32{0, 0, 0, 0, 1, 1},
+		},
+		{
+			name:          "byte level",
+			postProcessor: `{"type": "ByteLevel", "trim_offsets": false}`,
+			singleIDs:     []uint32{1, 2},
+			pairIDs:       []uint32{1, 2, 3},
+			pairTypeIDs:   []uint32{0, 0, 1},
+		},
+		{
+			name:          "none",
+			postProcessor: `null`,
+			singleIDs:     []uint32{1, 2},
+			pairIDs:       []uint32{1, 2, 3},
+			pairTypeIDs:   []uint32{0, 0, 1},
+		},
+		{
+			name:          "unsupported",
+			postProcessor: `{"type": "Unknown"}`,
+			err:           "unsupported post-processor 'Unknown'",
+		},
+		{
+			name:          "unknown special token",
+			postProcessor: `{"type": "TemplateProcessing", "single": [{"SpecialToken": {"id": "[CLS]", "type_id": 0}}], "special_tokens": {}}`,
+			err:           "unknown special token '[CLS]' in the template",
+		},
+		{
+			name:          "invalid special token",
+			postProcessor: `{"type": "BertProcessing", "sep": ["[SEP]", "102"], "cls": ["[CLS]", 101]}`,
+			err:           "invalid special token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, err := parsePostProcessor([]byte(`{"post_processor": ` + tt.postProcessor + `}`))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("got error %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			first := &models.EncodeResponse{IDs: []uint32{1, 2}}
+			second := &models.EncodeResponse{IDs: []uint32{3}}
+			if got := applyTemplate(processor.single, first).IDs; !reflect.DeepEqual(got, tt.singleIDs) {
+				t.Errorf("got single IDs %v, want %v", got, tt.singleIDs)
+			}
+			pair := applyTemplate(processor.pair, first, second)
+			if !reflect.DeepEqual(pair.IDs, tt.pairIDs) {
+				t.Errorf("got pair IDs %v, want %v", pair.IDs, tt.pairIDs)
+			}
+			if !reflect.DeepEqual(pair.TypeIDs, tt.pairTypeIDs) {
+				t.Errorf("got pair type IDs %v, want %v", pair.TypeIDs, tt.pairTypeIDs)
+			}
+		})
+	}
+}
+
+func TestTruncatePair(t *testing.T) {
+	sequence := func(n int) *models.EncodeResponse {
+		encoding := &models.EncodeResponse{}
+		for i := 0; i < n; i++ {
+			encoding.IDs = append(encoding.IDs, uint32(i))
+			encoding.Offsets = append(encoding.Offsets, models.Offset{uint(i), uint(i + 1)})
+		}
+		return encoding
+	}
+
+	tests := []struct {
+		name          string
+		first, second int
+		budget        int
+		strategy      options.TruncationStrategy
+		wantFirst     int
+		wantSecond    int
+		err           string
+	}{
+		{name: "fits", first: 3, second: 2, budget: 5, strategy: options.TruncationLongestFirst, wantFirst: 3, wantSecond: 2},
+		{name: "longest first from the first", first: 6, second: 2, budget: 5, strategy: options.TruncationLongestFirst, wantFirst: 3, wantSecond: 2},
+		{name: "longest first from the second", first: 2, second: 6, budget: 5, strategy: options.TruncationLongestFirst, wantFirst: 2, wantSecond: 3},
+		{name: "longest first from both", first: 5, second: 5, budget: 5, strategy: options.TruncationLongestFirst, wantFirst: 2, wantSecond: 3},
+		{name: "only first", first: 4, second: 3, budget: 5, strategy: options.TruncationOnlyFirst, wantFirst: 2, wantSecond: 3},
+		{
+			name: "only first can't fit", first: 4, second: 5, budget: 5, strategy: options.TruncationOnlyFirst,
+			err: "the second sequence has 5 tokens, it can't fit the max length by truncating the first",
+		},
+		{name: "only second", first: 3, second: 4, budget: 5, strategy: options.TruncationOnlySecond, wantFirst: 3, wantSecond: 2},
+		{
+			name: "only second can't fit", first: 6, second: 1, budget: 5, strategy: options.TruncationOnlySecond,
+			err: "the first sequence has 6 tokens, it can't fit the max length by truncating the second",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, second := sequence(tt.first), sequence(tt.second)
+			err := truncatePair(first, second, tt.budget, tt.strategy)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("got error %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := sequence(tt.wantFirst); !reflect.DeepEqual(first, want) {
+				t.Errorf("got first sequence %v, want %v", first, want)
+			}
+			if want := sequence(tt.wantSecond); !reflect.DeepEqual(second, want) {
+				t.Errorf("got second sequence %v, want %v", second, want)
+			}
+		})
+	}
+}
+
+func TestEncodePairBatch(t *testing.T) {
+	tk := loadFixture(t, NewPureGoTokenizerFromBytes, "wordpiece.json")
+	texts := []string{"brown fox jumps", "dog"}
+	pairs := []string{"over the lazy dog", "fox"}
+
+	tests := []struct {
+		name        string
+		options     options.BatchEncodeOptions
+		wantIDs     [][]uint32
+		wantTypeIDs [][]uint32
+		err         string
+	}{
+		{
+			name:    "longest first",
+			options: options.BatchEncodeOptions{EncodeSpecialTokens: true, MaxLength: 7},
+			wantIDs: [][]uint32{
+				{101, 51775, 193284, 102, 15444, 14985, 102},
+				{101, 22452, 102, 193284, 102, 0, 0},
+			},
+			wantTypeIDs: [][]uint32{{0, 0, 0, 0, 1, 1, 1}, {0, 0, 0, 1, 1, 0, 0}},
+		},
+		{
+			name:    "only first",
+			options: options.BatchEncodeOptions{EncodeSpecialTokens: true, MaxLength: 8, Truncation: options.TruncationOnlyFirst},
+			wantIDs: [][]uint32{
+				{101, 51775, 102, 15444, 14985, 221123, 22452, 102},
+				{101, 22452, 102, 193284, 102, 0, 0, 0},
+			},
+			wantTypeIDs: [][]uint32{{0, 0, 0, 1, 1, 1, 1, 1}, {0, 0, 0, 1, 1, 0, 0, 0}},
+		},
+		{
+			name:    "only second",
+			options: options.BatchEncodeOptions{EncodeSpecialTokens: true, MaxLength: 7, Truncation: options.TruncationOnlySecond},
+			wantIDs: [][]uint32{
+				{101, 51775, 193284, 333915, 102, 15444, 102},
+				{101, 22452, 102, 193284, 102, 0, 0},
+			},
+			wantTypeIDs: [][]uint32{{0, 0, 0, 0, 0, 1, 1}, {0, 0, 0, 1, 1, 0, 0}},
+		},
+		{
+			name:    "without special tokens",
+			options: options.BatchEncodeOptions{MaxLength: 4},
+			wantIDs: [][]uint32{
+				{51775, 193284, 15444, 14985},
+				{22452, 193284, 0, 0},
+			},
+			wantTypeIDs: [][]uint32{{0, 0, 1, 1}, {0, 1, 0, 0}},
+		},
+		{
+			name:    "only first can't fit",
+			options: options.BatchEncodeOptions{EncodeSpecialTokens: true, MaxLength: 6, Truncation: options.TruncationOnlyFirst},
+			err:     "item 0: the second sequence has 4 tokens, it can't fit the max length by truncating the first",
+		},
+		{
+			name:    "only second can't fit",
+			options: options.BatchEncodeOptions{EncodeSpecialTokens: true, MaxLength: 5, Truncation: options.TruncationOnlySecond},
+			err:     "item 0: the first sequence has 3 tokens, it can't fit the max length by truncating the second",
+		},
+		{
+			name:    "shorter than the special tokens",
+			options: options.BatchEncodeOptions{EncodeSpecialTokens: true, MaxLength: 2},
+			err:     "item 0: max length 2 is shorter than the 3 special tokens",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch, err := EncodePairBatch(tk, texts, pairs, &tt.options)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("got error %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(batch.IDs, tt.wantIDs) {
+				t.Errorf("got IDs %v, want %v", batch.IDs, tt.wantIDs)
+			}
+			if !reflect.DeepEqual(batch.TypeIDs, tt.wantTypeIDs) {
+				t.Errorf("got type IDs %v, want %v", batch.TypeIDs, tt.wantTypeIDs)
+			}
+		})
+	}
+
+	if _, err := EncodePairBatch(tk, texts, pairs[:1], nil); err == nil || err.Error() != "got 2 texts and 1 pairs" {
+		t.Errorf("got error %v for a missing pair", err)
+	}
+}
+
+func TestEncodePairRequiresPairEncoder(t *testing.T) {
+	tk := encodeOnly{loadFixture(t, NewPureGoTokenizerFromBytes, "wordpiece.json")}
+	if _, err := EncodePair(tk, "brown fox", "lazy dog", nil); err == nil || !strings.Contains(err.Error(), "can't encode pairs") {
+		t.Errorf("got error %v", err)
+	}
+	if _, err := EncodePairBatch(tk, []string{"brown fox"}, []string{"lazy dog"}, nil); err == nil || !strings.Contains(err.Error(), "can't encode pairs") {
+		t.Errorf("got error %v", err)
+	}
+}
@@ -0,0 +1,245 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode"
+)
+
+// preTokenizer splits a normalized text into the words encoded by the model.
+type preTokenizer interface {
+	split(s span) []span
+}
+
+// parsePreTokenizer reads the pre-tokenizer of a tokenizer.json file, nil when the file has none.
+func parsePreTokenizer(data json.RawMessage) (preTokenizer, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var config struct {
+		Type string `json:"type"`
+		// ByteLevel
+		AddPrefixSpace bool  `json:"add_prefix_space"`
+		UseRegex       *bool `json:"use_regex"`
+		// Sequence
+		PreTokenizers []json.RawMessage `json:"pretokenizers"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	switch config.Type {
+	case "BertPreTokenizer":
+		return bertPreTokenizer{}, nil
+	case "Whitespace":
+		return whitespacePreTokenizer{}, nil
+	case "WhitespaceSplit":
+		return whitespaceSplitPreTokenizer{}, nil
+	case "ByteLevel":
+		return byteLevelPreTokenizer{addPrefixSpace: config.AddPrefixSpace, useRegex: config.UseRegex == nil || *config.UseRegex}, nil
+	case "Sequence":
+		var preTokenizers sequencePreTokenizer
+		for _, raw := range config.PreTokenizers {
+			nested, err := parsePreTokenizer(raw)
+			if err != nil {
+				return nil, err
+			}
+			if nested != nil {
+				preTokenizers = append(preTokenizers, nested)
+			}
+		}
+		return preTokenizers, nil
+	default:
+		return nil, fmt.Errorf("unsupported pre-tokenizer '%s'", config.Type)
+	}
+}
+
+// splitRunes splits s into the pieces selected by next, which returns the end of the piece starting at start
+// and whether to keep it.
+func splitRunes(s span, next func(runes []rune, start int) (end int, keep bool)) []span {
+	var pieces []span
+	for start := 0; start < len(s.runes); {
+		end, keep := next(s.runes, start)
+		if keep {
+			pieces = append(pieces, s.slice(start, end))
+		}
+		start = end
+	}
+	return pieces
+}
+
+// runWhile returns the end of the run of runes matching match from start.
+func runWhile(runes []rune, start int, match func(r rune) bool) int {
+	end := start
+	for end < len(runes) && match(runes[end]) {
+		end++
+	}
+	return end
+}
+
+// bertPreTokenizer splits on whitespace and isolates every punctuation character.
+type bertPreTokenizer struct{}
+
+func (bertPreTokenizer) split(s span) []span {
+	return splitRunes(s, func(runes []rune, start int) (int, bool) {
+		switch r := runes[start]; {
+		case isWhitespace(r):
+			return runWhile(runes, start, isWhitespace), false
+		case isPunctuation(r):
+			return start + 1, true
+		default:
+			return runWhile(runes, start, func(r rune) bool { return !isWhitespace(r) && !isPunctuation(r) }), true
+		}
+	})
+}
+
+// isPunctuation matches the ASCII punctuation, which includes symbols such as $ and +, and the Unicode punctuation.
+func isPunctuation(r rune) bool {
+	return unicode.IsPunct(r) || r <= unicode.MaxASCII && unicode.IsSymbol(r)
+}
+
+// whitespacePreTokenizer splits into runs of word characters and runs of other non-whitespace characters.
+type whitespacePreTokenizer struct{}
+
+func (whitespacePreTokenizer) split(s span) []span {
+	return splitRunes(s, func(runes []rune, start int) (int, bool) {
+		switch r := runes[start]; {
+		case isWhitespace(r):
+			return runWhile(runes, start, isWhitespace), false
+		case isWordChar(r):
+			return runWhile(runes, start, isWordChar), true
+		default:
+			return runWhile(runes, start, func(r rune) bool { return !isWhitespace(r) && !isWordChar(r) }), true
+		}
+	})
+}
+
+func isWordChar(r rune) bool {
+	return unicode.In(r, unicode.L, unicode.M, unicode.Nd, unicode.Pc)
+}
+
+// whitespaceSplitPreTokenizer splits on whitespace.
+type whitespaceSplitPreTokenizer struct{}
+
+func (whitespaceSplitPreTokenizer) split(s span) []span {
+	return splitRunes(s, func(runes []rune, start int) (int, bool) {
+		if isWhitespace(runes[start]) {
+			return runWhile(runes, start, isWhitespace), false
+		}
+		return runWhile(runes, start, func(r rune) bool { return !isWhitespace(r) }), true
+	})
+}
+
+// byteLevelPreTokenizer splits like GPT-2 and replaces the bytes of the words by the printable characters
+// of the byte-level vocabulary, e.g. spaces by 'Ġ'.
+type byteLevelPreTokenizer struct {
+	addPrefixSpace bool
+	useRegex       bool
+}
+
+func (p byteLevelPreTokenizer) split(s span) []span {
+	if p.addPrefixSpace && len(s.runes) > 0 && s.runes[0] != ' ' {
+		s = span{
+			runes:   append([]rune{' '}, s.runes...),
+			offsets: append([][2]uint{s.offsets[0]}, s.offsets...),
+		}
+	}
+
+	words := []span{s}
+	if p.useRegex {
+		words = splitRunes(s, gpt2Word)
+	}
+	for i, word := range words {
+		words[i] = toByteLevel(word)
+	}
+	return words
+}
+
+// gpt2Word matches the words of the GPT-2 pattern
+// 's|'t|'re|'ve|'m|'ll|'d| ?\p{L}+| ?\p{N}+| ?[^\s\p{L}\p{N}]+|\s+(?!\S)|\s+
+// from start.
+func gpt2Word(runes []rune, start int) (int, bool) {
+	if runes[start] == '\'' {
+		for _, contraction := range []string{"s", "t", "re", "ve", "m", "ll", "d"} {
+			if hasPrefix(runes[start+1:], contraction) {
+				return start + 1 + len(contraction), true
+			}
+		}
+	}
+
+	letter := func(r rune) bool { return unicode.IsLetter(r) }
+	number := func(r rune) bool { return unicode.IsNumber(r) }
+	other := func(r rune) bool { return !isWhitespace(r) && !unicode.IsLetter(r) && !unicode.IsNumber(r) }
+	first := start
+	if runes[start] == ' ' && start+1 < len(runes) {
+		first = start + 1
+	}
+	for _, class := range []func(r rune) bool{letter, number, other} {
+		if class(runes[first]) {
+			return runWhile(runes, first, class), true
+		}
+	}
+
+	// Whitespace not followed by a word, except its last character that prefixes the next word.
+	end := runWhile(runes, start, isWhitespace)
+	if end < len(runes) && end-start > 1 {
+		end--
+	}
+	return end, true
+}
+
+func hasPrefix(runes []rune, prefix string) bool {
+	i := 0
+	for _, r := range prefix {
+		if i >= len(runes) || runes[i] != r {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// byteLevelChars maps the bytes to the printable characters of the byte-level vocabulary, the printable
+// Latin-1 characters as themselves and the others from U+0100.
+var byteLevelChars, byteLevelBytes = func() ([256]rune, map[rune]byte) {
+	var chars [256]rune
+	bytes := make(map[rune]byte, 256)
+	next := rune(256)
+	for b := 0; b < 256; b++ {
+		if (b >= '!' && b <= '~') || (b >= 0xA1 && b <= 0xAC) || (b >= 0xAE && b <= 0xFF) {
+			chars[b] = rune(b)
+		} else {
+			chars[b] = next
+			next++
+		}
+		bytes[chars[b]] = byte(b)
+	}
+	return chars, bytes
+}()
+
+// toByteLevel replaces every rune by the byte-level characters of its UTF-8 bytes.
+func toByteLevel(s span) span {
+	return s.mapRunes(func(r rune) []rune {
+		encoded := []byte(string(r))
+		chars := make([]rune, len(encoded))
+		for i, b := range encoded {
+			chars[i] = byteLevelChars[b]
+		}
+		return chars
+	})
+}
+
+type sequencePreTokenizer []preTokenizer
+
+func (p sequencePreTokenizer) split(s span) []span {
+	pieces := []span{s}
+	for _, nested := range p {
+		var split []span
+		for _, piece := range pieces {
+			split = append(split, nested.split(piece)...)
+		}
+		pieces = split
+	}
+	return pieces
+}
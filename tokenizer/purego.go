@@ -0,0 +1,357 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/tokenizer/models"
+	"github.com/Trendyol/go-triton-client/tokenizer/options"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// pureGoBackend encodes without cgo. It supports the WordPiece models of BERT and the byte-level BPE models
+// of GPT-2 and RoBERTa, with the normalizers, pre-tokenizers, post-processors and decoders they use.
+type pureGoBackend struct {
+	addedTokens  []addedToken
+	normalizer   normalizer
+	preTokenizer preTokenizer
+	model        model
+	processor    *postProcessor
+	decoder      decoder
+	truncation   *truncationConfig
+	padding      *paddingConfig
+	// tokens maps the IDs to the tokens of the vocabulary and the added tokens.
+	tokens  map[uint32]string
+	special map[uint32]bool
+}
+
+// addedToken is a token matched in the text before the model splits it, such as [CLS] or <|endoftext|>.
+type addedToken struct {
+	ID         uint32 `json:"id"`
+	Content    string `json:"content"`
+	SingleWord bool   `json:"single_word"`
+	LStrip     bool   `json:"lstrip"`
+	RStrip     bool   `json:"rstrip"`
+	Normalized bool   `json:"normalized"`
+	Special    bool   `json:"special"`
+}
+
+type truncationConfig struct {
+	MaxLength int    `json:"max_length"`
+	Direction string `json:"direction"`
+}
+
+type paddingConfig struct {
+	// Strategy is "BatchLongest" or {"Fixed": length}.
+	Strategy        json.RawMessage `json:"strategy"`
+	Direction       string          `json:"direction"`
+	PadToMultipleOf int             `json:"pad_to_multiple_of"`
+	PadID           uint32          `json:"pad_id"`
+	PadTypeID       uint32          `json:"pad_type_id"`
+	PadToken        string          `json:"pad_token"`
+}
+
+// NewPureGoTokenizerFromBytes loads a tokenizer from the content of a Hugging Face tokenizer.json file
+// without the Rust library, for builds without cgo. It supports WordPiece (BERT) and byte-level BPE
+// (GPT-2, RoBERTa) tokenizers and returns an error for the components it doesn't implement.
+func NewPureGoTokenizerFromBytes(data []byte) (Tokenizer, error) {
+	tk, err := newPureGoBackend(data)
+	if err != nil {
+		return nil, fmt.Errorf("loading tokenizer: %w", err)
+	}
+	return newTokenizer(tk, data), nil
+}
+
+func newPureGoBackend(data []byte) (*pureGoBackend, error) {
+	if err := validateTokenizerJSON(data); err != nil {
+		return nil, err
+	}
+	var config struct {
+		AddedTokens  []addedToken      `json:"added_tokens"`
+		Normalizer   json.RawMessage   `json:"normalizer"`
+		PreTokenizer json.RawMessage   `json:"pre_tokenizer"`
+		Model        json.RawMessage   `json:"model"`
+		Decoder      json.RawMessage   `json:"decoder"`
+		Truncation   *truncationConfig `json:"truncation"`
+		Padding      *paddingConfig    `json:"padding"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	var err error
+	b := &pureGoBackend{addedTokens: config.AddedTokens, truncation: config.Truncation, padding: config.Padding}
+	if b.normalizer, err = parseNormalizer(config.Normalizer); err != nil {
+		return nil, err
+	}
+	if b.preTokenizer, err = parsePreTokenizer(config.PreTokenizer); err != nil {
+		return nil, err
+	}
+	if b.model, err = parseModel(config.Model); err != nil {
+		return nil, err
+	}
+	if b.decoder, err = parseDecoder(config.Decoder); err != nil {
+		return nil, err
+	}
+	if b.processor, err = parsePostProcessor(data); err != nil {
+		return nil, err
+	}
+	if b.padding != nil {
+		if _, err := b.paddingLength(0); err != nil {
+			return nil, err
+		}
+	}
+
+	// Longer added tokens are matched first.
+	sort.SliceStable(b.addedTokens, func(i, j int) bool {
+		return len(b.addedTokens[i].Content) > len(b.addedTokens[j].Content)
+	})
+	b.tokens = make(map[uint32]string, len(b.model.vocabulary())+len(b.addedTokens))
+	b.special = make(map[uint32]bool)
+	for value, id := range b.model.vocabulary() {
+		b.tokens[id] = value
+	}
+	for _, added := range b.addedTokens {
+		b.tokens[added.ID] = added.Content
+		if added.Special {
+			b.special[added.ID] = true
+		}
+	}
+	return b, nil
+}
+
+func (b *pureGoBackend) encode(text string, encodeOptions *options.EncodeOptions) *models.EncodeResponse {
+	// The Rust library can't read invalid UTF-8 and returns no tokens.
+	if !utf8.ValidString(text) {
+		return &models.EncodeResponse{}
+	}
+
+	addSpecialTokens := encodeOptions != nil && (encodeOptions.EncodeSpecialTokens || encodeOptions.ReturnAllAttributes)
+	sequence := &models.EncodeResponse{}
+	for _, t := range b.tokenize(text) {
+		sequence.IDs = append(sequence.IDs, t.id)
+		sequence.Tokens = append(sequence.Tokens, t.value)
+		sequence.Offsets = append(sequence.Offsets, t.offset)
+		sequence.TypeIDs = append(sequence.TypeIDs, 0)
+		sequence.SpecialTokensMask = append(sequence.SpecialTokensMask, 0)
+		sequence.AttentionMask = append(sequence.AttentionMask, 1)
+	}
+
+	template := b.processor.single
+	if !addSpecialTokens {
+		template = concatenation().single
+	}
+	if b.truncation != nil && b.truncation.MaxLength > 0 {
+		b.truncate(sequence, max(b.truncation.MaxLength-specialTokens(template), 0))
+	}
+	encoded := applyTemplate(template, sequence)
+	encoded.SequenceIDs = nil
+	if b.padding != nil {
+		length, _ := b.paddingLength(len(encoded.IDs))
+		b.pad(encoded, length)
+	}
+	return selectAttributes(encoded, encodeOptions)
+}
+
+// tokenize splits text into the added tokens and the tokens of the model.
+func (b *pureGoBackend) tokenize(text string) []token {
+	var tokens []token
+	// The added tokens that aren't normalized are matched in the original text, the others after normalization.
+	for _, piece := range b.splitAdded(newSpan(text), false) {
+		if piece.added != nil {
+			tokens = append(tokens, *piece.added)
+			continue
+		}
+		normalized := piece.text
+		if b.normalizer != nil {
+			normalized = b.normalizer.normalize(normalized)
+		}
+		for _, normalizedPiece := range b.splitAdded(normalized, true) {
+			if normalizedPiece.added != nil {
+				tokens = append(tokens, *normalizedPiece.added)
+				continue
+			}
+			words := []span{normalizedPiece.text}
+			if b.preTokenizer != nil {
+				words = b.preTokenizer.split(normalizedPiece.text)
+			}
+			for _, word := range words {
+				if len(word.runes) > 0 {
+					tokens = append(tokens, b.model.tokenize(word)...)
+				}
+			}
+		}
+	}
+	return tokens
+}
+
+// piece is either an added token or a text between added tokens.
+type piece struct {
+	added *token
+	text  span
+}
+
+// splitAdded splits s on the added tokens with the given normalized flag, matching the longest token first.
+func (b *pureGoBackend) splitAdded(s span, normalized bool) []piece {
+	var pieces []piece
+	textStart := 0
+	for i := 0; i < len(s.runes); {
+		start, end, added, ok := b.matchAdded(s, textStart, i, normalized)
+		if !ok {
+			i++
+			continue
+		}
+		if start > textStart {
+			pieces = append(pieces, piece{text: s.slice(textStart, start)})
+		}
+		pieces = append(pieces, piece{added: &token{id: added.ID, value: added.Content, offset: offsetOf(s, start, end)}})
+		i, textStart = end, end
+	}
+	if textStart < len(s.runes) {
+		pieces = append(pieces, piece{text: s.slice(textStart, len(s.runes))})
+	}
+	return pieces
+}
+
+// matchAdded matches an added token at position i of s, returning the runes it covers with the whitespace it strips
+// back to textStart.
+func (b *pureGoBackend) matchAdded(s span, textStart, i int, normalized bool) (int, int, addedToken, bool) {
+	for _, added := range b.addedTokens {
+		if added.Normalized != normalized || added.Content == "" || !hasPrefix(s.runes[i:], added.Content) {
+			continue
+		}
+		start, end := i, i+len([]rune(added.Content))
+		if added.SingleWord && (start > 0 && isWordChar(s.runes[start-1]) || end < len(s.runes) && isWordChar(s.runes[end])) {
+			continue
+		}
+		if added.LStrip {
+			for start > textStart && unicode.IsSpace(s.runes[start-1]) {
+				start--
+			}
+		}
+		if added.RStrip {
+			for end < len(s.runes) && unicode.IsSpace(s.runes[end]) {
+				end++
+			}
+		}
+		return start, end, added, true
+	}
+	return 0, 0, addedToken{}, false
+}
+
+// truncate removes the tokens beyond maxLength, from the end or with the left direction from the start.
+func (b *pureGoBackend) truncate(encoding *models.EncodeResponse, maxLength int) {
+	if len(encoding.IDs) <= maxLength {
+		return
+	}
+	start := 0
+	if b.truncation.Direction == "Left" {
+		start = len(encoding.IDs) - maxLength
+	}
+	indices := make([]int, maxLength)
+	for i := range indices {
+		indices[i] = start + i
+	}
+	keep(encoding, indices)
+}
+
+// paddingLength returns the length an encoding of the given length is padded to.
+func (b *pureGoBackend) paddingLength(length int) (int, error) {
+	var fixed struct {
+		Fixed int `json:"Fixed"`
+	}
+	var strategy string
+	if err := json.Unmarshal(b.padding.Strategy, &strategy); err == nil && strategy == "BatchLongest" {
+		// A single encoding is the longest of its batch.
+	} else if err := json.Unmarshal(b.padding.Strategy, &fixed); err == nil && fixed.Fixed > 0 {
+		length = max(length, fixed.Fixed)
+	} else {
+		return 0, fmt.Errorf("unsupported padding strategy %s", b.padding.Strategy)
+	}
+	if multiple := b.padding.PadToMultipleOf; multiple > 0 && length%multiple != 0 {
+		length += multiple - length%multiple
+	}
+	return length, nil
+}
+
+// pad adds padding tokens up to length, which have an attention mask of 0.
+func (b *pureGoBackend) pad(encoding *models.EncodeResponse, length int) {
+	padding := length - len(encoding.IDs)
+	if padding <= 0 {
+		return
+	}
+	side := options.PaddingRight
+	if b.padding.Direction == "Left" {
+		side = options.PaddingLeft
+	}
+	encoding.IDs = padRow(encoding.IDs, padding, b.padding.PadID, side)
+	encoding.TypeIDs = padRow(encoding.TypeIDs, padding, b.padding.PadTypeID, side)
+	encoding.SpecialTokensMask = padRow(encoding.SpecialTokensMask, padding, 1, side)
+	encoding.AttentionMask = padRow(encoding.AttentionMask, padding, 0, side)
+	encoding.Tokens = padValues(encoding.Tokens, padding, b.padding.PadToken, side)
+	encoding.Offsets = padValues(encoding.Offsets, padding, models.Offset{0, 0}, side)
+}
+
+func padValues[T any](values []T, padding int, value T, side options.PaddingSide) []T {
+	pads := make([]T, padding)
+	for i := range pads {
+		pads[i] = value
+	}
+	if side == options.PaddingLeft {
+		return append(pads, values...)
+	}
+	return append(values, pads...)
+}
+
+// selectAttributes keeps the attributes requested by encodeOptions, as the Rust library returns them:
+// the IDs and tokens without options, the IDs and the requested attributes otherwise.
+func selectAttributes(encoding *models.EncodeResponse, encodeOptions *options.EncodeOptions) *models.EncodeResponse {
+	selected := &models.EncodeResponse{IDs: encoding.IDs}
+	if encodeOptions == nil {
+		selected.Tokens = encoding.Tokens
+		return selected
+	}
+	all := encodeOptions.ReturnAllAttributes
+	if all || encodeOptions.ReturnTypeIDs {
+		selected.TypeIDs = encoding.TypeIDs
+	}
+	if all || encodeOptions.ReturnSpecialTokensMask {
+		selected.SpecialTokensMask = encoding.SpecialTokensMask
+	}
+	if all || encodeOptions.ReturnAttentionMask {
+		selected.AttentionMask = encoding.AttentionMask
+	}
+	if all || encodeOptions.ReturnTokens {
+		selected.Tokens = encoding.Tokens
+	}
+	if all || encodeOptions.ReturnOffsets {
+		selected.Offsets = encoding.Offsets
+	}
+	return selected
+}
+
+func (b *pureGoBackend) decode(tokenIDs []uint32, skipSpecialTokens bool) string {
+	tokens := make([]string, 0, len(tokenIDs))
+	for _, id := range tokenIDs {
+		value, ok := b.tokens[id]
+		if !ok || skipSpecialTokens && b.special[id] {
+			continue
+		}
+		tokens = append(tokens, value)
+	}
+	return b.decoder.decode(tokens)
+}
+
+func (b *pureGoBackend) withoutTruncation() (backend, error) {
+	if b.truncation == nil {
+		return b, nil
+	}
+	untruncated := *b
+	untruncated.truncation = nil
+	return &untruncated, nil
+}
+
+func (b *pureGoBackend) close() error {
+	return nil
+}
@@ -0,0 +1,132 @@
+package tokenizer
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Trendyol/go-triton-client/tokenizer/models"
+	"github.com/Trendyol/go-triton-client/tokenizer/options"
+	"github.com/daulet/tokenizers"
+)
+
+/*
+// macOS (darwin)
+#cgo darwin,amd64 LDFLAGS: -L${SRCDIR}/lib/darwin/amd64 -ltokenizers
+#cgo darwin,arm64 LDFLAGS: -L${SRCDIR}/lib/darwin/arm64 -ltokenizers
+
+// Linux
+#cgo linux,amd64 LDFLAGS: -L${SRCDIR}/lib/linux/amd64 -ltokenizers
+#cgo linux,arm64 LDFLAGS: -L${SRCDIR}/lib/linux/arm64 -ltokenizers
+*/
+import "C"
+
+// rustBackend encodes with the Rust tokenizers library, linked statically from lib.
+type rustBackend struct {
+	tk *tokenizers.Tokenizer
+	// untruncatedConfig is the tokenizer.json file without its truncation, nil when it has none.
+	untruncatedConfig []byte
+}
+
+// NewTokenizerFromBytes loads a tokenizer from the content of a Hugging Face tokenizer.json file.
+// Builds without cgo use NewPureGoTokenizerFromBytes instead of the Rust library.
+func NewTokenizerFromBytes(data []byte) (Tokenizer, error) {
+	// The Rust library aborts on invalid input, so the input is checked first.
+	if err := validateTokenizerJSON(data); err != nil {
+		return nil, err
+	}
+	untruncated, err := untruncatedConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing tokenizer: %w", err)
+	}
+	tk, err := tokenizers.FromBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("loading tokenizer: %w", err)
+	}
+	return newTokenizer(&rustBackend{tk: tk, untruncatedConfig: untruncated}, data), nil
+}
+
+// untruncatedConfig returns data with its truncation removed, nil when it has none.
+// The Rust library applies the truncation of the file to every encoding.
+func untruncatedConfig(data []byte) ([]byte, error) {
+	var config map[string]json.RawMessage
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	if truncation := config["truncation"]; len(truncation) == 0 || string(truncation) == "null" {
+		return nil, nil
+	}
+	config["truncation"] = json.RawMessage("null")
+	return json.Marshal(config)
+}
+
+func (r *rustBackend) encode(text string, encodeOptions *options.EncodeOptions) *models.EncodeResponse {
+	var options []tokenizers.EncodeOption
+	encodeSpecialTokens := false
+
+	if encodeOptions == nil {
+		options = append(options, tokenizers.WithReturnTokens())
+	} else {
+		if encodeOptions.ReturnAllAttributes {
+			options = append(options, tokenizers.WithReturnAllAttributes())
+			encodeSpecialTokens = true
+		}
+
+		if encodeOptions.ReturnAttentionMask {
+			options = append(options, tokenizers.WithReturnAttentionMask())
+		}
+
+		if encodeOptions.ReturnTokens {
+			options = append(options, tokenizers.WithReturnTokens())
+		}
+
+		if encodeOptions.ReturnOffsets {
+			options = append(options, tokenizers.WithReturnOffsets())
+		}
+
+		if encodeOptions.ReturnSpecialTokensMask {
+			options = append(options, tokenizers.WithReturnSpecialTokensMask())
+		}
+
+		if encodeOptions.ReturnTypeIDs {
+			options = append(options, tokenizers.WithReturnTypeIDs())
+		}
+
+		if encodeOptions.EncodeSpecialTokens {
+			encodeSpecialTokens = true
+		}
+	}
+
+	encoded := r.tk.EncodeWithOptions(text, encodeSpecialTokens, options...)
+	offsets := make([]models.Offset, len(encoded.Offsets))
+	for i, extOffset := range encoded.Offsets {
+		offsets[i] = models.Offset{extOffset[0], extOffset[1]}
+	}
+
+	encodeResponse := &models.EncodeResponse{
+		IDs:               encoded.IDs,
+		TypeIDs:           encoded.TypeIDs,
+		SpecialTokensMask: encoded.SpecialTokensMask,
+		AttentionMask:     encoded.AttentionMask,
+		Tokens:            encoded.Tokens,
+		Offsets:           offsets,
+	}
+	return encodeResponse
+}
+
+func (r *rustBackend) decode(tokenIDs []uint32, skipSpecialTokens bool) string {
+	return r.tk.Decode(tokenIDs, skipSpecialTokens)
+}
+
+func (r *rustBackend) withoutTruncation() (backend, error) {
+	if r.untruncatedConfig == nil {
+		return r, nil
+	}
+	tk, err := tokenizers.FromBytes(r.untruncatedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("loading tokenizer without truncation: %w", err)
+	}
+	return &rustBackend{tk: tk}, nil
+}
+
+func (r *rustBackend) close() error {
+	return r.tk.Close()
+}
@@ -0,0 +1,180 @@
+package tokenizer
+
+import (
+	"context"
+	"github.com/Trendyol/go-triton-client/tokenizer/options"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// StreamDecoder decodes generated token IDs one at a time. Decoding every token alone breaks byte-level BPE
+// tokens that hold part of a character and loses the spaces that depend on the previous token, so the decoder
+// decodes the new tokens with the previous ones and emits the text once its characters are complete.
+type StreamDecoder interface {
+	// Add decodes the next token ID and returns the text it completes, which is empty while a character or
+	// a possible stop sequence is incomplete. stopped reports that the generation reached a stop sequence
+	// or a stop token, after which Add returns no text.
+	Add(tokenID uint32) (text string, stopped bool)
+	// Flush returns the text held back at the end of the generation, such as the start of a stop sequence
+	// that didn't complete or the bytes of an incomplete character. The text ends before a stop sequence
+	// completed by the held back tokens, as with Add.
+	Flush() string
+	// Text returns the text emitted so far.
+	Text() string
+}
+
+type streamDecoder struct {
+	tk            Tokenizer
+	decodeOptions *options.DecodeOptions
+	stopSequences []string
+	stopTokenIDs  []uint32
+
+	tokenIDs []uint32
+	// prefixOffset and readOffset delimit the tokens decoded again as the context of the next ones.
+	prefixOffset int
+	readOffset   int
+	// pending is the decoded text held back because it may start a stop sequence.
+	pending string
+	emitted strings.Builder
+	stopped bool
+}
+
+// NewStreamDecoder returns a decoder of the token IDs generated for a single sequence.
+func NewStreamDecoder(tk Tokenizer, streamOptions *options.StreamDecodeOptions) StreamDecoder {
+	if streamOptions == nil {
+		streamOptions = &options.StreamDecodeOptions{}
+	}
+	var stopSequences []string
+	for _, stop := range streamOptions.StopSequences {
+		if stop != "" {
+			stopSequences = append(stopSequences, stop)
+		}
+	}
+	return &streamDecoder{
+		tk:            tk,
+		decodeOptions: &options.DecodeOptions{SkipSpecialTokens: streamOptions.SkipSpecialTokens},
+		stopSequences: stopSequences,
+		stopTokenIDs:  streamOptions.StopTokenIDs,
+	}
+}
+
+func (d *streamDecoder) Add(tokenID uint32) (string, bool) {
+	if d.stopped {
+		return "", true
+	}
+	if slices.Contains(d.stopTokenIDs, tokenID) {
+		d.stopped = true
+		return d.release(d.pending), true
+	}
+
+	d.tokenIDs = append(d.tokenIDs, tokenID)
+	prefixText := d.tk.Decode(d.tokenIDs[d.prefixOffset:d.readOffset], d.decodeOptions).Decoded
+	text := d.tk.Decode(d.tokenIDs[d.prefixOffset:], d.decodeOptions).Decoded
+	// An incomplete character decodes to the replacement character, or to nothing with the Rust library.
+	if len(text) <= len(prefixText) || strings.HasSuffix(text, string(utf8.RuneError)) {
+		return "", false
+	}
+	d.prefixOffset, d.readOffset = d.readOffset, len(d.tokenIDs)
+	return d.hold(d.pending + text[len(prefixText):])
+}
+
+// hold emits text up to a stop sequence, or up to the end that may start a stop sequence.
+func (d *streamDecoder) hold(text string) (string, bool) {
+	if stop := d.stopIndex(text); stop >= 0 {
+		d.stopped = true
+		d.pending = ""
+		return d.release(text[:stop]), true
+	}
+
+	held := 0
+	for _, sequence := range d.stopSequences {
+		for length := min(len(sequence)-1, len(text)); length > held; length-- {
+			if strings.HasSuffix(text, sequence[:length]) {
+				held = length
+				break
+			}
+		}
+	}
+	d.pending = text[len(text)-held:]
+	return d.release(text[:len(text)-held]), false
+}
+
+// stopIndex returns the index of the first stop sequence in text, -1 when it has none.
+func (d *streamDecoder) stopIndex(text string) int {
+	stop := -1
+	for _, sequence := range d.stopSequences {
+		if i := strings.Index(text, sequence); i >= 0 && (stop < 0 || i < stop) {
+			stop = i
+		}
+	}
+	return stop
+}
+
+func (d *streamDecoder) release(text string) string {
+	d.emitted.WriteString(text)
+	return text
+}
+
+func (d *streamDecoder) Flush() string {
+	if d.stopped {
+		return ""
+	}
+	// The tokens of an incomplete character are decoded as they are.
+	text := d.pending
+	prefixText := d.tk.Decode(d.tokenIDs[d.prefixOffset:d.readOffset], d.decodeOptions).Decoded
+	if remaining := d.tk.Decode(d.tokenIDs[d.prefixOffset:], d.decodeOptions).Decoded; len(remaining) > len(prefixText) {
+		text += remaining[len(prefixText):]
+	}
+	d.pending = ""
+	d.prefixOffset, d.readOffset = len(d.tokenIDs), len(d.tokenIDs)
+	if stop := d.stopIndex(text); stop >= 0 {
+		d.stopped = true
+		text = text[:stop]
+	}
+	return d.release(text)
+}
+
+func (d *streamDecoder) Text() string {
+	return d.emitted.String()
+}
+
+// DecodeStream decodes the token IDs received from tokenIDs, e.g. from a streaming inference, and sends the
+// completed text on the returned channel. The channel is closed when tokenIDs is closed, when the generation
+// reaches a stop sequence or a stop token, or when ctx is done. DecodeStream stops receiving at a stop, so the
+// producer should stop the generation, e.g. by canceling ctx.
+func DecodeStream(ctx context.Context, tk Tokenizer, tokenIDs <-chan uint32, streamOptions *options.StreamDecodeOptions) <-chan string {
+	texts := make(chan string)
+	go func() {
+		defer close(texts)
+		send := func(text string) bool {
+			if text == "" {
+				return true
+			}
+			select {
+			case <-ctx.Done():
+				return false
+			case texts <- text:
+				return true
+			}
+		}
+
+		decoder := NewStreamDecoder(tk, streamOptions)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case tokenID, ok := <-tokenIDs:
+				if !ok {
+					send(decoder.Flush())
+					return
+				}
+				text, stopped := decoder.Add(tokenID)
+				if !send(text) || stopped {
+					return
+				}
+			}
+		}
+	}()
+	return texts
+}
@@ -0,0 +1,114 @@
+package tokenizer
+
+import (
+	"context"
+	"github.com/Trendyol/go-triton-client/tokenizer/models"
+	"github.com/Trendyol/go-triton-client/tokenizer/options"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStreamDecoder(t *testing.T) {
+	tk := loadFixture(t, NewPureGoTokenizerFromBytes, "bpe.json")
+
+	// "na", then the two bytes of "ï" in separate tokens, then "v".
+	decoder := NewStreamDecoder(tk, nil)
+	var texts []string
+	for _, id := range []uint32{285, 127, 107, 85} {
+		text, stopped := decoder.Add(id)
+		if stopped {
+			t.Fatal("stopped without stop sequence")
+		}
+		texts = append(texts, text)
+	}
+	if want := []string{"na", "", "ï", "v"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("got %q, want %q", texts, want)
+	}
+
+	// "the cat and the hat" stops at " the h", holding back " the" until the stop sequence completes.
+	decoder = NewStreamDecoder(tk, &options.StreamDecodeOptions{StopSequences: []string{" the h"}})
+	texts = nil
+	for _, id := range []uint32{83, 257, 264, 261, 258, 266} {
+		text, stopped := decoder.Add(id)
+		texts = append(texts, text)
+		if stopped {
+			break
+		}
+	}
+	if want := []string{"t", "he", " cat", " and", "", ""}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("got %q, want %q", texts, want)
+	}
+	if text := decoder.Text(); text != "the cat and" {
+		t.Errorf("got text %q", text)
+	}
+}
+
+// tokenTexts decodes every token ID to its text, like byte-level BPE tokens that may hold part of a character.
+type tokenTexts map[uint32]string
+
+func (t tokenTexts) Encode(string, *options.EncodeOptions) *models.EncodeResponse {
+	return &models.EncodeResponse{}
+}
+
+func (t tokenTexts) Decode(tokenIDs []uint32, _ *options.DecodeOptions) *models.DecodeResponse {
+	var decoded strings.Builder
+	for _, id := range tokenIDs {
+		decoded.WriteString(t[id])
+	}
+	return &models.DecodeResponse{Decoded: strings.ToValidUTF8(decoded.String(), "\uFFFD")}
+}
+
+func TestStreamDecoderFlushStops(t *testing.T) {
+	// The last token completes "world" but ends with the first byte of "é", so Add holds it back until Flush.
+	tk := tokenTexts{1: "Hello", 2: " wor", 3: "ld caf\xc3"}
+	decoder := NewStreamDecoder(tk, &options.StreamDecodeOptions{StopSequences: []string{"world"}})
+	var texts []string
+	for _, id := range []uint32{1, 2, 3} {
+		text, stopped := decoder.Add(id)
+		if stopped {
+			t.Fatalf("stopped at token %d before the stop sequence completed", id)
+		}
+		texts = append(texts, text)
+	}
+	if want := []string{"Hello", " ", ""}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("got %q, want %q", texts, want)
+	}
+
+	if text := decoder.Flush(); text != "" {
+		t.Errorf("flushed %q past the stop sequence", text)
+	}
+	if text := decoder.Text(); text != "Hello " {
+		t.Errorf("got text %q", text)
+	}
+	if _, stopped := decoder.Add(4); !stopped {
+		t.Error("the decoder should be stopped after flushing a stop sequence")
+	}
+
+	// Without stop sequence, Flush emits the held back tokens.
+	decoder = NewStreamDecoder(tk, nil)
+	for _, id := range []uint32{1, 2, 3} {
+		decoder.Add(id)
+	}
+	if text := decoder.Flush(); text != "ld caf\uFFFD" {
+		t.Errorf("flushed %q", text)
+	}
+}
+
+func TestDecodeStream(t *testing.T) {
+	tk := loadFixture(t, NewPureGoTokenizerFromBytes, "bpe.json")
+
+	tokenIDs := make(chan uint32, 8)
+	for _, id := range []uint32{270, 11, 275, 299, 0} {
+		tokenIDs <- id
+	}
+	close(tokenIDs)
+
+	var decoded string
+	for text := range DecodeStream(context.Background(), tk, tokenIDs, &options.StreamDecodeOptions{StopTokenIDs: []uint32{299}}) {
+		decoded += text
+	}
+	if decoded != "Hello, world" {
+		t.Errorf("got %q", decoded)
+	}
+}
@@ -1,106 +1,276 @@
 package tokenizer
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"github.com/Trendyol/go-triton-client/tokenizer/models"
 	"github.com/Trendyol/go-triton-client/tokenizer/options"
-	"github.com/daulet/tokenizers"
+	"io"
 	"log"
+	"os"
 	"strings"
+	"sync"
 )
 
-/*
-// macOS (darwin)
-#cgo darwin,amd64 LDFLAGS: -L${SRCDIR}/lib/darwin/amd64 -ltokenizers
-#cgo darwin,arm64 LDFLAGS: -L${SRCDIR}/lib/darwin/arm64 -ltokenizers
-
-// Linux
-#cgo linux,amd64 LDFLAGS: -L${SRCDIR}/lib/linux/amd64 -ltokenizers
-#cgo linux,arm64 LDFLAGS: -L${SRCDIR}/lib/linux/arm64 -ltokenizers
-*/
-import "C"
-
 type Tokenizer interface {
 	Encode(text string, encodeOptions *options.EncodeOptions) *models.EncodeResponse
 	Decode(tokenIDs []uint32, decodeOptions *options.DecodeOptions) *models.DecodeResponse
 }
 
+// BatchEncoder is implemented by the tokenizers encoding batches themselves, such as the tokenizers of this package.
+type BatchEncoder interface {
+	// EncodeBatch encodes the texts concurrently, truncating and padding them into rectangular matrices.
+	EncodeBatch(texts []string, batchOptions *options.BatchEncodeOptions) (*models.BatchEncodeResponse, error)
+}
+
+// EncodeBatch encodes the texts concurrently with tk, truncating and padding them into rectangular matrices.
+// Tokenizers not implementing BatchEncoder encode every text with Encode.
+func EncodeBatch(tk Tokenizer, texts []string, batchOptions *options.BatchEncodeOptions) (*models.BatchEncodeResponse, error) {
+	if encoder, ok := tk.(BatchEncoder); ok {
+		return encoder.EncodeBatch(texts, batchOptions)
+	}
+	return encodeTexts(texts, batchOptions, func(text string) *models.EncodeResponse {
+		return tk.Encode(text, batchEncodeOptions(batchOptions))
+	})
+}
+
+// batchEncodeOptions returns the options encoding a text of a batch, with the attributes the batch returns.
+func batchEncodeOptions(batchOptions *options.BatchEncodeOptions) *options.EncodeOptions {
+	return &options.EncodeOptions{
+		ReturnAttentionMask:     true,
+		ReturnTypeIDs:           true,
+		ReturnSpecialTokensMask: true,
+		EncodeSpecialTokens:     batchOptions != nil && batchOptions.EncodeSpecialTokens,
+	}
+}
+
+// PairEncoder is implemented by the tokenizers encoding pairs of sequences, such as the tokenizers of this package.
+type PairEncoder interface {
+	// EncodePair encodes a pair of sequences, such as a query and a passage, merged by the post-processor
+	// template of the tokenizer. The response has every attribute, and SequenceIDs tells the sequence of each token.
+	EncodePair(text string, pair string, encodeOptions *options.EncodeOptions) (*models.EncodeResponse, error)
+	// EncodePairBatch encodes texts[i] with pairs[i] like EncodeBatch, truncating both sequences of a pair
+	// according to the truncation strategy.
+	EncodePairBatch(texts []string, pairs []string, batchOptions *options.BatchEncodeOptions) (*models.BatchEncodeResponse, error)
+}
+
+// EncodePair encodes a pair of sequences with tk, which must implement PairEncoder.
+func EncodePair(tk Tokenizer, text string, pair string, encodeOptions *options.EncodeOptions) (*models.EncodeResponse, error) {
+	encoder, ok := tk.(PairEncoder)
+	if !ok {
+		return nil, fmt.Errorf("%T can't encode pairs of sequences", tk)
+	}
+	return encoder.EncodePair(text, pair, encodeOptions)
+}
+
+// EncodePairBatch encodes texts[i] with pairs[i] with tk, which must implement PairEncoder.
+func EncodePairBatch(tk Tokenizer, texts []string, pairs []string, batchOptions *options.BatchEncodeOptions) (*models.BatchEncodeResponse, error) {
+	encoder, ok := tk.(PairEncoder)
+	if !ok {
+		return nil, fmt.Errorf("%T can't encode pairs of sequences", tk)
+	}
+	return encoder.EncodePairBatch(texts, pairs, batchOptions)
+}
+
+// Close frees tk when it implements io.Closer, as the tokenizers of this package do. Their Encode and Decode
+// return empty responses once they are closed, and EncodeBatch returns ErrClosed. Calls in progress complete
+// before Close frees the tokenizer.
+func Close(tk Tokenizer) error {
+	if closer, ok := tk.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// ErrClosed is returned by the methods of a closed tokenizer.
+var ErrClosed = errors.New("tokenizer is closed")
+
+// backend encodes and decodes texts for a tokenizer: the Rust library with cgo, or the pure Go implementation.
+type backend interface {
+	encode(text string, encodeOptions *options.EncodeOptions) *models.EncodeResponse
+	decode(tokenIDs []uint32, skipSpecialTokens bool) string
+	// withoutTruncation returns a backend ignoring the truncation of the tokenizer.json file,
+	// the backend itself when the file has none.
+	withoutTruncation() (backend, error)
+	close() error
+}
+
 type tokenizer struct {
-	tk *tokenizers.Tokenizer
+	// mu guards tk, which is nil once the tokenizer is closed.
+	mu sync.RWMutex
+	tk backend
+	// untruncated encodes the documents of EncodeChunks, loaded on first use and guarded by untruncatedMu.
+	untruncatedMu sync.Mutex
+	untruncated   backend
+	// processor is the pair template of the post-processor, nil when processorErr is set.
+	processor    *postProcessor
+	processorErr error
 }
 
+// NewTokenizer loads a tokenizer from a tokenizer.json file and exits the process when it fails.
+//
+// Deprecated: Use NewTokenizerFromFile, which returns the error.
 func NewTokenizer(path string) Tokenizer {
+	tk, err := NewTokenizerFromFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return tk
+}
+
+// NewTokenizerFromFile loads a tokenizer from a Hugging Face tokenizer.json file.
+func NewTokenizerFromFile(path string) (Tokenizer, error) {
 	if strings.TrimSpace(path) == "" {
-		log.Fatal("path can't be empty")
+		return nil, errors.New("path can't be empty")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading tokenizer: %w", err)
 	}
+	return NewTokenizerFromBytes(data)
+}
 
-	tk, err := tokenizers.FromFile(path)
+// NewTokenizerFromReader loads a tokenizer from the content of a Hugging Face tokenizer.json file.
+func NewTokenizerFromReader(reader io.Reader) (Tokenizer, error) {
+	data, err := io.ReadAll(reader)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("reading tokenizer: %w", err)
 	}
-	return &tokenizer{tk: tk}
+	return NewTokenizerFromBytes(data)
 }
 
-func (t *tokenizer) Encode(text string, encodeOptions *options.EncodeOptions) *models.EncodeResponse {
-	var options []tokenizers.EncodeOption
-	encodeSpecialTokens := false
-
-	if encodeOptions == nil {
-		options = append(options, tokenizers.WithReturnTokens())
-	} else {
-		if encodeOptions.ReturnAllAttributes {
-			options = append(options, tokenizers.WithReturnAllAttributes())
-			encodeSpecialTokens = true
-		}
+// newTokenizer wraps a backend loaded from data, the content of a tokenizer.json file.
+func newTokenizer(tk backend, data []byte) *tokenizer {
+	// An unsupported post-processor only fails pair encoding.
+	processor, processorErr := parsePostProcessor(data)
+	return &tokenizer{tk: tk, processor: processor, processorErr: processorErr}
+}
 
-		if encodeOptions.ReturnAttentionMask {
-			options = append(options, tokenizers.WithReturnAttentionMask())
-		}
+func validateTokenizerJSON(data []byte) error {
+	var config struct {
+		Model json.RawMessage `json:"model"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("parsing tokenizer: %w", err)
+	}
+	if len(config.Model) == 0 {
+		return errors.New("parsing tokenizer: missing model")
+	}
+	return nil
+}
 
-		if encodeOptions.ReturnTokens {
-			options = append(options, tokenizers.WithReturnTokens())
-		}
+func (t *tokenizer) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.tk == nil {
+		return nil
+	}
+	err := t.tk.close()
+	if t.untruncated != nil && t.untruncated != t.tk {
+		err = errors.Join(err, t.untruncated.close())
+	}
+	t.tk = nil
+	t.untruncated = nil
+	return err
+}
 
-		if encodeOptions.ReturnOffsets {
-			options = append(options, tokenizers.WithReturnOffsets())
-		}
+func (t *tokenizer) Encode(text string, encodeOptions *options.EncodeOptions) *models.EncodeResponse {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.tk == nil {
+		return &models.EncodeResponse{}
+	}
+	return t.tk.encode(text, encodeOptions)
+}
 
-		if encodeOptions.ReturnSpecialTokensMask {
-			options = append(options, tokenizers.WithReturnSpecialTokensMask())
-		}
+// encodeDocument encodes text without the truncation of the tokenizer.json file, which would cut a document
+// before EncodeChunks splits it into windows.
+func (t *tokenizer) encodeDocument(text string, encodeOptions *options.EncodeOptions) (*models.EncodeResponse, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.tk == nil {
+		return nil, ErrClosed
+	}
 
-		if encodeOptions.ReturnTypeIDs {
-			options = append(options, tokenizers.WithReturnTypeIDs())
+	t.untruncatedMu.Lock()
+	if t.untruncated == nil {
+		untruncated, err := t.tk.withoutTruncation()
+		if err != nil {
+			t.untruncatedMu.Unlock()
+			return nil, err
 		}
+		t.untruncated = untruncated
+	}
+	untruncated := t.untruncated
+	t.untruncatedMu.Unlock()
+	return untruncated.encode(text, encodeOptions), nil
+}
 
-		if encodeOptions.EncodeSpecialTokens {
-			encodeSpecialTokens = true
-		}
+func (t *tokenizer) EncodeBatch(texts []string, batchOptions *options.BatchEncodeOptions) (*models.BatchEncodeResponse, error) {
+	// The read lock keeps the tokenizer open for the whole batch.
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.tk == nil {
+		return nil, ErrClosed
 	}
 
-	encoded := t.tk.EncodeWithOptions(text, encodeSpecialTokens, options...)
-	offsets := make([]models.Offset, len(encoded.Offsets))
-	for i, extOffset := range encoded.Offsets {
-		offsets[i] = models.Offset{extOffset[0], extOffset[1]}
+	encodeOptions := batchEncodeOptions(batchOptions)
+	return encodeTexts(texts, batchOptions, func(text string) *models.EncodeResponse {
+		return t.tk.encode(text, encodeOptions)
+	})
+}
+
+func (t *tokenizer) EncodePair(text string, pair string, encodeOptions *options.EncodeOptions) (*models.EncodeResponse, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.tk == nil {
+		return nil, ErrClosed
 	}
+	if t.processorErr != nil {
+		return nil, t.processorErr
+	}
+
+	addSpecialTokens := encodeOptions != nil && (encodeOptions.EncodeSpecialTokens || encodeOptions.ReturnAllAttributes)
+	return encodePair(t.encodeSequence(text), t.encodeSequence(pair), t.processor, addSpecialTokens, 0, options.TruncationLongestFirst)
+}
 
-	encodeResponse := &models.EncodeResponse{
-		IDs:               encoded.IDs,
-		TypeIDs:           encoded.TypeIDs,
-		SpecialTokensMask: encoded.SpecialTokensMask,
-		AttentionMask:     encoded.AttentionMask,
-		Tokens:            encoded.Tokens,
-		Offsets:           offsets,
+func (t *tokenizer) EncodePairBatch(texts []string, pairs []string, batchOptions *options.BatchEncodeOptions) (*models.BatchEncodeResponse, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.tk == nil {
+		return nil, ErrClosed
+	}
+	if t.processorErr != nil {
+		return nil, t.processorErr
 	}
-	return encodeResponse
+	return encodePairs(texts, pairs, batchOptions, t.processor, t.encodeSequence)
+}
+
+// encodeSequence encodes one sequence of a pair without special tokens, which the template adds.
+func (t *tokenizer) encodeSequence(text string) *models.EncodeResponse {
+	return t.tk.encode(text, &options.EncodeOptions{
+		ReturnTokens:            true,
+		ReturnOffsets:           true,
+		ReturnAttentionMask:     true,
+		ReturnTypeIDs:           true,
+		ReturnSpecialTokensMask: true,
+	})
 }
 
 func (t *tokenizer) Decode(tokenIDs []uint32, decodeOptions *options.DecodeOptions) *models.DecodeResponse {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.tk == nil {
+		return &models.DecodeResponse{}
+	}
+
 	skipSpecialTokens := false
 	if decodeOptions != nil {
 		skipSpecialTokens = decodeOptions.SkipSpecialTokens
 	}
 
-	decoded := t.tk.Decode(tokenIDs, skipSpecialTokens)
+	decoded := t.tk.decode(tokenIDs, skipSpecialTokens)
 
 	decodeResponse := &models.DecodeResponse{
 		Decoded: decoded,
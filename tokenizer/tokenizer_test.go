@@ -0,0 +1,142 @@
+package tokenizer
+
+import (
+	"errors"
+	"github.com/Trendyol/go-triton-client/tokenizer/options"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewTokenizerFromFile(t *testing.T) {
+	dir := t.TempDir()
+	invalidPath := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalidPath, []byte(`{"model":`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	noModelPath := filepath.Join(dir, "no-model.json")
+	if err := os.WriteFile(noModelPath, []byte(`{"version":"1.0"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		err  string
+	}{
+		{"empty path", "", "path can't be empty"},
+		{"blank path", "  ", "path can't be empty"},
+		{"missing file", filepath.Join(dir, "missing.json"), "reading tokenizer"},
+		{"invalid JSON", invalidPath, "parsing tokenizer"},
+		{"missing model", noModelPath, "parsing tokenizer: missing model"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tk, err := NewTokenizerFromFile(tt.path)
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("got error %v, want %q", err, tt.err)
+			}
+			if tk != nil {
+				t.Errorf("got tokenizer %v with an error", tk)
+			}
+		})
+	}
+
+	_, err := NewTokenizerFromFile(filepath.Join(dir, "missing.json"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got error %v, want a wrapped os.ErrNotExist", err)
+	}
+
+	tk, err := NewTokenizerFromFile(filepath.Join("testdata", "wordpiece.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Close(tk)
+	if ids := tk.Encode("lazy dog", nil).IDs; !equalIDs(ids, []uint32{221123, 22452}) {
+		t.Errorf("got IDs %v", ids)
+	}
+}
+
+// errReader fails every read.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func TestNewTokenizerFromReader(t *testing.T) {
+	readErr := errors.New("connection reset")
+	if _, err := NewTokenizerFromReader(errReader{readErr}); !errors.Is(err, readErr) {
+		t.Errorf("got error %v, want a wrapped %v", err, readErr)
+	}
+	if _, err := NewTokenizerFromReader(strings.NewReader("not json")); err == nil || !strings.Contains(err.Error(), "parsing tokenizer") {
+		t.Errorf("got error %v for invalid JSON", err)
+	}
+	if _, err := NewTokenizerFromReader(strings.NewReader("")); err == nil {
+		t.Error("loading an empty reader should fail")
+	}
+
+	file, err := os.Open(filepath.Join("testdata", "wordpiece.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	tk, err := NewTokenizerFromReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Close(tk)
+	if ids := tk.Encode("lazy dog", nil).IDs; !equalIDs(ids, []uint32{221123, 22452}) {
+		t.Errorf("got IDs %v", ids)
+	}
+}
+
+func TestClose(t *testing.T) {
+	constructors := map[string]func(data []byte) (Tokenizer, error){
+		"default": NewTokenizerFromBytes,
+		"pure Go": NewPureGoTokenizerFromBytes,
+	}
+	for name, newTokenizer := range constructors {
+		t.Run(name, func(t *testing.T) {
+			tk := loadFixture(t, newTokenizer, "wordpiece.json")
+			if err := Close(tk); err != nil {
+				t.Fatal(err)
+			}
+			if err := Close(tk); err != nil {
+				t.Errorf("closing twice: %v", err)
+			}
+
+			if encoded := tk.Encode("lazy dog", &options.EncodeOptions{ReturnAllAttributes: true}); len(encoded.IDs) != 0 {
+				t.Errorf("got IDs %v from a closed tokenizer", encoded.IDs)
+			}
+			if decoded := tk.Decode([]uint32{221123, 22452}, nil).Decoded; decoded != "" {
+				t.Errorf("got %q from a closed tokenizer", decoded)
+			}
+			if _, err := EncodeBatch(tk, []string{"lazy dog"}, nil); !errors.Is(err, ErrClosed) {
+				t.Errorf("EncodeBatch: got error %v, want ErrClosed", err)
+			}
+			if _, err := EncodePair(tk, "lazy", "dog", nil); !errors.Is(err, ErrClosed) {
+				t.Errorf("EncodePair: got error %v, want ErrClosed", err)
+			}
+			if _, err := EncodePairBatch(tk, []string{"lazy"}, []string{"dog"}, nil); !errors.Is(err, ErrClosed) {
+				t.Errorf("EncodePairBatch: got error %v, want ErrClosed", err)
+			}
+			if _, err := EncodeChunks(tk, "lazy dog", &options.ChunkOptions{MaxLength: 4}); !errors.Is(err, ErrClosed) {
+				t.Errorf("EncodeChunks: got error %v, want ErrClosed", err)
+			}
+		})
+	}
+}
+
+func TestCloseWithoutCloser(t *testing.T) {
+	tk := encodeOnly{loadFixture(t, NewPureGoTokenizerFromBytes, "wordpiece.json")}
+	if err := Close(tk); err != nil {
+		t.Errorf("got error %v", err)
+	}
+	if ids := tk.Encode("lazy dog", nil).IDs; len(ids) == 0 {
+		t.Error("Close must leave a tokenizer without io.Closer usable")
+	}
+}